@@ -0,0 +1,88 @@
+// Package api is the low level Singularity API: a model type for each
+// Singularity model and a Client method for each endpoint, generated from
+// the Swagger description in swagger.json.
+//
+// The calls of github.com/lenfree/go-mesos-singularity are sent through a
+// Client of this package, with requests from Client.R, and its Client.API
+// method returns that Client for endpoints it has no call for. Version 2 of
+// the client sends its calls through Client.Do, and its errors are an
+// *Error. The models of github.com/lenfree/go-mesos-singularity keep their
+// own Go types, such as Millis and RequestType, but their tests check that
+// they have the same JSON fields as the models generated here.
+//
+// To add an endpoint, or a field to a model, add it to swagger.json and run
+// go generate.
+package api
+
+//go:generate go run ../cmd/singularity-gen/main.go ../cmd/singularity-gen/gen.go -spec swagger.json -package api -models models_gen.go -endpoints endpoints_gen.go
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-resty/resty"
+)
+
+// Client calls the Singularity API through a resty client whose host URL is
+// set to Singularity.
+type Client struct {
+	rest *resty.Client
+	ctx  context.Context
+}
+
+// NewClient returns a Client which sends requests with r.
+func NewClient(r *resty.Client) *Client {
+	return &Client{rest: r}
+}
+
+// WithContext returns a copy of c whose calls are made with ctx.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return &Client{rest: c.rest, ctx: ctx}
+}
+
+// Error is returned when Singularity answers with a status outside 2xx.
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Message is the response body, which Singularity fills with the
+	// reason for the error.
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Singularity %s %s error: %d %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// R returns a new request made with the context of c, for calls which
+// handle the response themselves rather than through Do.
+func (c *Client) R() *resty.Request {
+	r := c.rest.R()
+	if c.ctx != nil {
+		r.SetContext(c.ctx)
+	}
+	return r
+}
+
+// Do sends body, if not nil, with method to path, and decodes the response
+// into out, if not nil. A status outside 2xx is returned as an *Error.
+func (c *Client) Do(method, path string, query map[string]string, body, out interface{}) (*resty.Response, error) {
+	req := c.R().SetQueryParams(query)
+	if v := reflect.ValueOf(body); body != nil && (v.Kind() != reflect.Ptr || !v.IsNil()) {
+		req.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	res, err := req.Execute(method, path)
+	if err != nil {
+		return res, fmt.Errorf("Singularity %s %s error: %v", method, path, err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return res, &Error{Method: method, Path: path, StatusCode: res.StatusCode(), Message: string(res.Body())}
+	}
+	if out != nil && len(res.Body()) > 0 {
+		if err := c.rest.JSONUnmarshal(res.Body(), out); err != nil {
+			return res, fmt.Errorf("Parse Singularity %s %s error: %v", method, path, err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-resty/resty"
+)
+
+func newTestClient(ts *httptest.Server) *Client {
+	return NewClient(resty.New().SetHostURL(ts.URL))
+}
+
+func TestEndpoints(t *testing.T) {
+	var method, path, query, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		method, path, query, body = r.Method, r.URL.EscapedPath(), r.URL.RawQuery, string(b)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/history/request/my service/requests":
+			fmt.Fprint(w, `[{"eventType": "PAUSED", "request": {"id": "my service", "requestType": "SERVICE"}}]`)
+		default:
+			fmt.Fprint(w, `{"request": {"id": "my service", "requestType": "SERVICE"}, "state": "PAUSED"}`)
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	_, p, err := c.Pause("my service", &SingularityPauseRequest{KillTasks: true, Message: "maintenance"})
+	if err != nil {
+		t.Fatalf("Pause: unexpected error %v", err)
+	}
+	if method != "POST" || path != "/api/requests/request/my%20service/pause" {
+		t.Errorf("Pause: expected POST /api/requests/request/my%%20service/pause, got %s %s", method, path)
+	}
+	if body != `{"killTasks":true,"message":"maintenance"}` {
+		t.Errorf("Pause: unexpected body %s", body)
+	}
+	if p.State != "PAUSED" || p.Request == nil || p.Request.ID != "my service" {
+		t.Errorf("Pause: unexpected result %+v", p)
+	}
+
+	if _, _, err := c.Unpause("my service", nil); err != nil {
+		t.Fatalf("Unpause: unexpected error %v", err)
+	}
+	if body != "" {
+		t.Errorf("Unpause(nil): expected no body, got %s", body)
+	}
+
+	_, h, err := c.GetRequestHistoryForRequest("my service", map[string]string{"count": "1"})
+	if err != nil {
+		t.Fatalf("GetRequestHistoryForRequest: unexpected error %v", err)
+	}
+	if query != "count=1" {
+		t.Errorf("GetRequestHistoryForRequest: expected query count=1, got %s", query)
+	}
+	if len(h) != 1 || h[0].EventType != "PAUSED" {
+		t.Errorf("GetRequestHistoryForRequest: unexpected result %+v", h)
+	}
+}
+
+func TestEndpointError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Couldn't find request with id missing", http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	_, p, err := newTestClient(ts).GetRequest("missing")
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("GetRequest: expected *Error, got %v", err)
+	}
+	if p != nil || e.StatusCode != http.StatusNotFound || e.Method != "GET" || e.Path != "/api/requests/request/missing" {
+		t.Errorf("GetRequest: unexpected error %+v", e)
+	}
+}
+
+// TestModelsRoundTrip checks that the generated models keep every field of
+// the payloads which the models of the parent package are tested with.
+func TestModelsRoundTrip(t *testing.T) {
+	tests := []struct {
+		file  string
+		model interface{}
+	}{
+		{"request_parent.json", &SingularityRequestParent{}},
+		{"deploy_request.json", &SingularityDeployRequest{}},
+		{"deploy_history.json", &SingularityDeployHistory{}},
+	}
+	for _, tt := range tests {
+		data, err := ioutil.ReadFile(filepath.Join("..", "testdata", tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, tt.model); err != nil {
+			t.Errorf("Unmarshal(%s): unexpected error %v", tt.file, err)
+			continue
+		}
+		b, err := json.Marshal(tt.model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected, got interface{}
+		if err := json.Unmarshal(data, &expected); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(nonZero(expected), got) {
+			t.Errorf("Marshal(%s): expected %v, got %v", tt.file, nonZero(expected), got)
+		}
+	}
+}
+
+// nonZero returns v without the zero values of objects, which the generated
+// models leave out.
+func nonZero(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, f := range v {
+			switch f := nonZero(f).(type) {
+			case nil, bool, float64, string:
+				if f != nil && f != false && f != 0.0 && f != "" {
+					m[k] = f
+				}
+			case map[string]interface{}:
+				if len(f) > 0 {
+					m[k] = f
+				}
+			case []interface{}:
+				if len(f) > 0 {
+					m[k] = f
+				}
+			}
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i := range v {
+			s[i] = nonZero(v[i])
+		}
+		return s
+	}
+	return v
+}
@@ -0,0 +1,213 @@
+// Code generated by singularity-gen. DO NOT EDIT.
+
+package api
+
+import (
+	"net/url"
+
+	"github.com/go-resty/resty"
+)
+
+// Bounce sends POST /api/requests/request/{requestId}/bounce. Bounce a specific
+// Singularity request. A bounce launches replacement task(s), and then kills
+// the original task(s) if the replacement(s) are healthy.
+func (c *Client) Bounce(requestID string, body *SingularityBounceRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("POST", "/api/requests/request/"+url.PathEscape(requestID)+"/bounce", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// CancelDeploy sends DELETE /api/deploys/deploy/{deployId}/request/{requestId}.
+// Cancel a pending deployment (best effort - the deploy may still succeed or
+// fail).
+func (c *Client) CancelDeploy(deployID string, requestID string) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("DELETE", "/api/deploys/deploy/"+url.PathEscape(deployID)+"/request/"+url.PathEscape(requestID), nil, nil, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// DeleteExpiringScale sends DELETE /api/requests/request/{requestId}/scale.
+// Delete/cancel the expiring scale. This makes the scale request permanent.
+func (c *Client) DeleteExpiringScale(requestID string) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("DELETE", "/api/requests/request/"+url.PathEscape(requestID)+"/scale", nil, nil, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// DeleteRequest sends DELETE /api/requests/request/{requestId}. Delete a
+// specific Request by ID and return the deleted Request.
+func (c *Client) DeleteRequest(requestID string, body *SingularityDeleteRequestRequest) (*resty.Response, *SingularityRequest, error) {
+	var out SingularityRequest
+	res, err := c.Do("DELETE", "/api/requests/request/"+url.PathEscape(requestID), nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// Deploy sends POST /api/deploys. Start a new deployment for a Request.
+func (c *Client) Deploy(body *SingularityDeployRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("POST", "/api/deploys", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// ExitCooldown sends POST /api/requests/request/{requestId}/exit-cooldown.
+// Immediately exits cooldown, scheduling new tasks immediately.
+func (c *Client) ExitCooldown(requestID string, body *SingularityExitCooldownRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("POST", "/api/requests/request/"+url.PathEscape(requestID)+"/exit-cooldown", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// GetDeploy sends GET /api/history/request/{requestId}/deploy/{deployId}.
+// Retrieve the history for a specific deploy.
+func (c *Client) GetDeploy(requestID string, deployID string) (*resty.Response, *SingularityDeployHistory, error) {
+	var out SingularityDeployHistory
+	res, err := c.Do("GET", "/api/history/request/"+url.PathEscape(requestID)+"/deploy/"+url.PathEscape(deployID), nil, nil, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// GetRequest sends GET /api/requests/request/{requestId}. Retrieve a specific
+// Request by ID.
+func (c *Client) GetRequest(requestID string) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("GET", "/api/requests/request/"+url.PathEscape(requestID), nil, nil, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// GetRequestHistoryForRequest sends GET
+// /api/history/request/{requestId}/requests. Get request history for a single
+// request. query may set count (maximum number of items to return), page (which
+// page of items to view).
+func (c *Client) GetRequestHistoryForRequest(requestID string, query map[string]string) (*resty.Response, []SingularityRequestHistory, error) {
+	var out []SingularityRequestHistory
+	res, err := c.Do("GET", "/api/history/request/"+url.PathEscape(requestID)+"/requests", query, nil, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, out, nil
+}
+
+// GetRequests sends GET /api/requests. Retrieve the list of all requests.
+func (c *Client) GetRequests() (*resty.Response, []SingularityRequestParent, error) {
+	var out []SingularityRequestParent
+	res, err := c.Do("GET", "/api/requests", nil, nil, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, out, nil
+}
+
+// GetTaskHistoryForRequest sends GET
+// /api/history/request/{requestId}/tasks/active. Retrieve the history for all
+// active tasks of a specific request.
+func (c *Client) GetTaskHistoryForRequest(requestID string) (*resty.Response, []SingularityTaskIDHistory, error) {
+	var out []SingularityTaskIDHistory
+	res, err := c.Do("GET", "/api/history/request/"+url.PathEscape(requestID)+"/tasks/active", nil, nil, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, out, nil
+}
+
+// KillTask sends DELETE /api/tasks/task/{taskId}. Attempt to kill task,
+// optionally overriding an existing cleanup request (that may be waiting for
+// replacement tasks to become healthy).
+func (c *Client) KillTask(taskID string, body *SingularityKillTaskRequest) (*resty.Response, *SingularityTaskCleanup, error) {
+	var out SingularityTaskCleanup
+	res, err := c.Do("DELETE", "/api/tasks/task/"+url.PathEscape(taskID), nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// Pause sends POST /api/requests/request/{requestId}/pause. Pause a Singularity
+// request, future tasks will not run until it is manually unpaused. API can
+// optionally choose to kill existing tasks.
+func (c *Client) Pause(requestID string, body *SingularityPauseRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("POST", "/api/requests/request/"+url.PathEscape(requestID)+"/pause", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// PostRequest sends POST /api/requests. Create or update a Singularity Request.
+func (c *Client) PostRequest(body *SingularityRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("POST", "/api/requests", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// Scale sends PUT /api/requests/request/{requestId}/scale. Scale the number of
+// instances up or down for a specific Request.
+func (c *Client) Scale(requestID string, body *SingularityScaleRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("PUT", "/api/requests/request/"+url.PathEscape(requestID)+"/scale", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// ScheduleImmediately sends POST /api/requests/request/{requestId}/run.
+// Schedule a one-off or scheduled Singularity request for immediate or delayed
+// execution.
+func (c *Client) ScheduleImmediately(requestID string, body *SingularityRunNowRequest) (*resty.Response, *SingularityPendingRequestParent, error) {
+	var out SingularityPendingRequestParent
+	res, err := c.Do("POST", "/api/requests/request/"+url.PathEscape(requestID)+"/run", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// SkipHealthchecks sends PUT
+// /api/requests/request/{requestId}/skip-healthchecks. Update the
+// skipHealthchecks field for the request, possibly temporarily.
+func (c *Client) SkipHealthchecks(requestID string, body *SingularitySkipHealthchecksRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("PUT", "/api/requests/request/"+url.PathEscape(requestID)+"/skip-healthchecks", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
+
+// Unpause sends POST /api/requests/request/{requestId}/unpause. Unpause a
+// Singularity Request, scheduling new tasks immediately.
+func (c *Client) Unpause(requestID string, body *SingularityUnpauseRequest) (*resty.Response, *SingularityRequestParent, error) {
+	var out SingularityRequestParent
+	res, err := c.Do("POST", "/api/requests/request/"+url.PathEscape(requestID)+"/unpause", nil, body, &out)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, &out, nil
+}
@@ -0,0 +1,769 @@
+// Code generated by singularity-gen. DO NOT EDIT.
+
+package api
+
+// EmbeddedArtifact is the EmbeddedArtifact model of the Singularity API.
+type EmbeddedArtifact struct {
+	Content                    string `json:"content,omitempty"`
+	Filename                   string `json:"filename,omitempty"`
+	Md5sum                     string `json:"md5sum,omitempty"`
+	Name                       string `json:"name,omitempty"`
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"`
+}
+
+// ExecutorData is the ExecutorData model of the Singularity API.
+type ExecutorData struct {
+	// Command for the custom executor to run.
+	Cmd string `json:"cmd"`
+	// A list of the full content of any embedded artifacts.
+	EmbeddedArtifacts []EmbeddedArtifact `json:"embeddedArtifacts,omitempty"`
+	// A list of external artifacts for the executor to download.
+	ExternalArtifacts []ExternalArtifact `json:"externalArtifacts,omitempty"`
+	// Extra arguments in addition to any provided in the cmd field.
+	ExtraCmdLineArgs   []string          `json:"extraCmdLineArgs,omitempty"`
+	LoggingExtraFields map[string]string `json:"loggingExtraFields,omitempty"`
+	LoggingTag         string            `json:"loggingTag,omitempty"`
+	// Run logrotate this often. Allowable values: HOURLY, DAILY, WEEKLY, MONTHLY.
+	LogrotateFrequency string `json:"logrotateFrequency,omitempty"`
+	// Maximum number of open files the task process is allowed.
+	MaxOpenFiles int `json:"maxOpenFiles,omitempty"`
+	// Maximum number of threads a task is allowed to use.
+	MaxTaskThreads int `json:"maxTaskThreads,omitempty"`
+	// If true, do not delete files in the task sandbox after the task process has
+	// terminated.
+	PreserveTaskSandboxAfterFinish bool   `json:"preserveTaskSandboxAfterFinish,omitempty"`
+	RunningSentinel                string `json:"runningSentinel,omitempty"`
+	// A list of signatures use to verify downloaded s3artifacts.
+	S3ArtifactSignatures []S3ArtifactSignature `json:"s3ArtifactSignatures,omitempty"`
+	// List of s3 artifacts for the executor to download.
+	S3Artifacts []S3Artifact `json:"s3Artifacts,omitempty"`
+	// Send a sigkill to a process if it has not shut down this many millis after
+	// being sent a term signal.
+	SigKillProcessesAfterMillis int64 `json:"sigKillProcessesAfterMillis,omitempty"`
+	// If true, do not run logrotate or compress old log files.
+	SkipLogrotateAndCompress bool `json:"skipLogrotateAndCompress,omitempty"`
+	// Allowable exit codes for the task to be considered FINISHED instead of
+	// FAILED.
+	SuccessfulExitCodes []int `json:"successfulExitCodes,omitempty"`
+	// Run the task process as this user.
+	User string `json:"user,omitempty"`
+}
+
+// ExternalArtifact is the ExternalArtifact model of the Singularity API.
+type ExternalArtifact struct {
+	Filename                   string `json:"filename,omitempty"`
+	Filesize                   int64  `json:"filesize,omitempty"`
+	IsArtifactList             bool   `json:"isArtifactList,omitempty"`
+	Md5sum                     string `json:"md5sum,omitempty"`
+	Name                       string `json:"name,omitempty"`
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"`
+	URL                        string `json:"url,omitempty"`
+}
+
+// LoadBalancerRequestID is the LoadBalancerRequestId model of the Singularity
+// API.
+type LoadBalancerRequestID struct {
+	AttemptNumber int    `json:"attemptNumber,omitempty"`
+	ID            string `json:"id,omitempty"`
+	// Allowable values: ADD, REMOVE, DEPLOY, DELETE.
+	RequestType string `json:"requestType,omitempty"`
+}
+
+// Resources is the Resources model of the Singularity API.
+type Resources struct {
+	Cpus     float64 `json:"cpus,omitempty"`
+	DiskMb   float64 `json:"diskMb,omitempty"`
+	MemoryMb float64 `json:"memoryMb,omitempty"`
+	NumPorts int     `json:"numPorts,omitempty"`
+}
+
+// S3Artifact is the S3Artifact model of the Singularity API.
+type S3Artifact struct {
+	Filename                   string `json:"filename,omitempty"`
+	Filesize                   int64  `json:"filesize,omitempty"`
+	IsArtifactList             bool   `json:"isArtifactList,omitempty"`
+	Md5sum                     string `json:"md5sum,omitempty"`
+	Name                       string `json:"name,omitempty"`
+	S3Bucket                   string `json:"s3Bucket,omitempty"`
+	S3ObjectKey                string `json:"s3ObjectKey,omitempty"`
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"`
+}
+
+// S3ArtifactSignature is the S3ArtifactSignature model of the Singularity API.
+type S3ArtifactSignature struct {
+	ArtifactFilename           string `json:"artifactFilename,omitempty"`
+	Filename                   string `json:"filename,omitempty"`
+	Filesize                   int64  `json:"filesize,omitempty"`
+	IsArtifactList             bool   `json:"isArtifactList,omitempty"`
+	Md5sum                     string `json:"md5sum,omitempty"`
+	Name                       string `json:"name,omitempty"`
+	S3Bucket                   string `json:"s3Bucket,omitempty"`
+	S3ObjectKey                string `json:"s3ObjectKey,omitempty"`
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"`
+}
+
+// SingularityAppcImage is the SingularityAppcImage model of the Singularity
+// API.
+type SingularityAppcImage struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// SingularityBounceRequest is the SingularityBounceRequest model of the
+// Singularity API.
+type SingularityBounceRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// The number of milliseconds to wait before reversing the effects of this
+	// action.
+	DurationMillis int64 `json:"durationMillis,omitempty"`
+	// If present and set to true, old tasks will be killed as soon as replacement
+	// tasks are available, instead of waiting for all replacement tasks to be
+	// healthy.
+	Incremental bool `json:"incremental,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+	// Instruct replacement tasks for this bounce only to skip healthchecks.
+	SkipHealthchecks bool `json:"skipHealthchecks,omitempty"`
+}
+
+// SingularityContainerInfo is the SingularityContainerInfo model of the
+// Singularity API.
+type SingularityContainerInfo struct {
+	// Information specific to docker runtime settings.
+	Docker *SingularityDockerInfo `json:"docker,omitempty"`
+	// Information specific to the Mesos containerizer.
+	Mesos *SingularityMesosInfo `json:"mesos,omitempty"`
+	// Container type, can be MESOS or DOCKER. Default is MESOS. Allowable values:
+	// MESOS, DOCKER.
+	Type string `json:"type"`
+	// List of volumes to mount. Applicable only to DOCKER containers.
+	Volumes []SingularityVolume `json:"volumes,omitempty"`
+}
+
+// SingularityDeleteRequestRequest is the SingularityDeleteRequestRequest model
+// of the Singularity API.
+type SingularityDeleteRequestRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// Should the service associated with the request be removed from the load
+	// balancer.
+	DeleteFromLoadBalancer bool `json:"deleteFromLoadBalancer,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+}
+
+// SingularityDeploy is the SingularityDeploy model of the Singularity API.
+type SingularityDeploy struct {
+	// Command arguments.
+	Arguments []string `json:"arguments,omitempty"`
+	// automatically advance to the next target instance count after
+	// deployStepWaitTimeMs seconds.
+	AutoAdvanceDeploySteps bool `json:"autoAdvanceDeploySteps,omitempty"`
+	// Command to execute for this deployment.
+	Command string `json:"command,omitempty"`
+	// Number of seconds that a service must be healthy to consider the deployment
+	// to be successful.
+	ConsiderHealthyAfterRunningForSeconds int64 `json:"considerHealthyAfterRunningForSeconds,omitempty"`
+	// Container information for deployment into a container.
+	ContainerInfo *SingularityContainerInfo `json:"containerInfo,omitempty"`
+	// Custom Mesos executor.
+	CustomExecutorCmd string `json:"customExecutorCmd,omitempty"`
+	// Custom Mesos executor id.
+	CustomExecutorID string `json:"customExecutorId,omitempty"`
+	// Custom Mesos executor source.
+	CustomExecutorSource string `json:"customExecutorSource,omitempty"`
+	// Number of seconds that Singularity waits for this service to become healthy.
+	DeployHealthTimeoutSeconds int64 `json:"deployHealthTimeoutSeconds,omitempty"`
+	// deploy this many instances at a time.
+	DeployInstanceCountPerStep int `json:"deployInstanceCountPerStep,omitempty"`
+	// wait this long between deploy steps.
+	DeployStepWaitTimeMs int `json:"deployStepWaitTimeMs,omitempty"`
+	// Map of environment variable definitions.
+	Env map[string]string `json:"env,omitempty"`
+	// Executor specific information.
+	ExecutorData *ExecutorData `json:"executorData,omitempty"`
+	// Singularity deploy id.
+	ID string `json:"id"`
+	// Labels for all tasks associated with this deploy.
+	Labels map[string]string `json:"labels,omitempty"`
+	// allowed at most this many failed tasks to be retried before failing the
+	// deploy.
+	MaxTaskRetries int `json:"maxTaskRetries,omitempty"`
+	// Labels for all tasks associated with this deploy.
+	MesosLabels []SingularityMesosTaskLabel `json:"mesosLabels,omitempty"`
+	// Labels for specific tasks associated with this deploy, indexed by instance
+	// number.
+	MesosTaskLabels map[string][]SingularityMesosTaskLabel `json:"mesosTaskLabels,omitempty"`
+	// Map of metadata key/value pairs associated with the deployment.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Singularity Request Id which is associated with this deploy.
+	RequestID string `json:"requestId"`
+	// Resources required for this deploy.
+	Resources *Resources `json:"resources,omitempty"`
+	// Settings used to run this deploy immediately.
+	RunImmediately *SingularityRunNowRequest `json:"runImmediately,omitempty"`
+	// The base path for the API exposed by the deploy. Used in conjunction with
+	// the Load balancer API.
+	ServiceBasePath string `json:"serviceBasePath,omitempty"`
+	// Override the shell property on the mesos task.
+	Shell bool `json:"shell,omitempty"`
+	// Allows skipping of health checks when deploying.
+	SkipHealthchecksOnDeploy bool `json:"skipHealthchecksOnDeploy,omitempty"`
+	// Map of environment variable overrides for specific task instances, keyed by
+	// instance number.
+	TaskEnv map[string]map[string]string `json:"taskEnv,omitempty"`
+	// (Deprecated) Labels for specific tasks associated with this deploy, indexed
+	// by instance number.
+	TaskLabels map[string]map[string]string `json:"taskLabels,omitempty"`
+	// Deploy timestamp.
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// List of URIs to download before executing the deploy command.
+	Uris []SingularityMesosArtifact `json:"uris,omitempty"`
+	// Run tasks as this user.
+	User string `json:"user,omitempty"`
+	// Deploy version.
+	Version string `json:"version,omitempty"`
+}
+
+// SingularityDeployFailure is the SingularityDeployFailure model of the
+// Singularity API.
+type SingularityDeployFailure struct {
+	Message string `json:"message,omitempty"`
+	// Allowable values: TASK_FAILED_ON_STARTUP, TASK_FAILED_HEALTH_CHECKS,
+	// TASK_COULD_NOT_BE_SCHEDULED, TASK_NEVER_ENTERED_RUNNING,
+	// TASK_EXPECTED_RUNNING_FINISHED, DEPLOY_CANCELLED, DEPLOY_OVERDUE,
+	// FAILED_TO_SAVE_DEPLOY_STATE, LOAD_BALANCER_UPDATE_FAILED,
+	// PENDING_DEPLOY_REMOVED.
+	Reason string             `json:"reason,omitempty"`
+	TaskID *SingularityTaskID `json:"taskId,omitempty"`
+}
+
+// SingularityDeployHistory is the SingularityDeployHistory model of the
+// Singularity API.
+type SingularityDeployHistory struct {
+	Deploy       *SingularityDeploy       `json:"deploy,omitempty"`
+	DeployMarker *SingularityDeployMarker `json:"deployMarker,omitempty"`
+	DeployResult *SingularityDeployResult `json:"deployResult,omitempty"`
+}
+
+// SingularityDeployMarker is the SingularityDeployMarker model of the
+// Singularity API.
+type SingularityDeployMarker struct {
+	// The deploy id.
+	DeployID string `json:"deployId,omitempty"`
+	// An optional message associated with this deploy.
+	Message string `json:"message,omitempty"`
+	// The request associated with this deploy.
+	RequestID string `json:"requestId,omitempty"`
+	// The time the deploy was created.
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// The user associated with this deploy.
+	User string `json:"user,omitempty"`
+}
+
+// SingularityDeployProgress is the SingularityDeployProgress model of the
+// Singularity API.
+type SingularityDeployProgress struct {
+	AutoAdvanceDeploySteps     bool                `json:"autoAdvanceDeploySteps,omitempty"`
+	CurrentActiveInstances     int                 `json:"currentActiveInstances,omitempty"`
+	DeployInstanceCountPerStep int                 `json:"deployInstanceCountPerStep,omitempty"`
+	DeployStepWaitTimeMs       int64               `json:"deployStepWaitTimeMs,omitempty"`
+	FailedDeployTasks          []SingularityTaskID `json:"failedDeployTasks,omitempty"`
+	StepComplete               bool                `json:"stepComplete,omitempty"`
+	TargetActiveInstances      int                 `json:"targetActiveInstances,omitempty"`
+	Timestamp                  int64               `json:"timestamp,omitempty"`
+}
+
+// SingularityDeployRequest is the SingularityDeployRequest model of the
+// Singularity API.
+type SingularityDeployRequest struct {
+	// The Singularity deploy object, containing all the required details about the
+	// Deploy.
+	Deploy *SingularityDeploy `json:"deploy"`
+	// A message to show users about this deploy (metadata).
+	Message string `json:"message,omitempty"`
+	// If deploy is successful, also unpause the request.
+	UnpauseOnSuccessfulDeploy bool `json:"unpauseOnSuccessfulDeploy,omitempty"`
+	// use this request data for this deploy, and update the request on successful
+	// deploy.
+	UpdatedRequest *SingularityRequest `json:"updatedRequest,omitempty"`
+}
+
+// SingularityDeployResult is the SingularityDeployResult model of the
+// Singularity API.
+type SingularityDeployResult struct {
+	DeployFailures []SingularityDeployFailure `json:"deployFailures,omitempty"`
+	// Allowable values: SUCCEEDED, FAILED_INTERNAL_STATE, CANCELING, WAITING,
+	// OVERDUE, FAILED, CANCELED.
+	DeployState string `json:"deployState,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Timestamp   int64  `json:"timestamp,omitempty"`
+}
+
+// SingularityDockerImage is the SingularityDockerImage model of the Singularity
+// API.
+type SingularityDockerImage struct {
+	Name string `json:"name,omitempty"`
+}
+
+// SingularityDockerInfo is the SingularityDockerInfo model of the Singularity
+// API.
+type SingularityDockerInfo struct {
+	// Other docker run command line options to be set.
+	DockerParameters []SingularityDockerParameter `json:"dockerParameters,omitempty"`
+	// Always run docker pull even if the image already exists locally.
+	ForcePullImage bool `json:"forcePullImage,omitempty"`
+	// Docker image name.
+	Image string `json:"image"`
+	// Docker network type. Value can be BRIDGE, HOST, or NONE. Allowable values:
+	// NONE, HOST, BRIDGE.
+	Network    string            `json:"network,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// List of port mappings.
+	PortMappings []SingularityDockerPortMapping `json:"portMappings,omitempty"`
+	// Controls use of the docker --privileged flag.
+	Privileged bool `json:"privileged,omitempty"`
+}
+
+// SingularityDockerParameter is the SingularityDockerParameter model of the
+// Singularity API.
+type SingularityDockerParameter struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// SingularityDockerPortMapping is the SingularityDockerPortMapping model of the
+// Singularity API.
+type SingularityDockerPortMapping struct {
+	// Port number, or index of port from offer within the container.
+	ContainerPort int `json:"containerPort,omitempty"`
+	// Container port. Use the port number provided (LITERAL) or the dynamically
+	// allocated port at this index (FROM_OFFER). Allowable values: LITERAL,
+	// FROM_OFFER.
+	ContainerPortType string `json:"containerPortType,omitempty"`
+	// Port number, or index of port from offer on the host.
+	HostPort int `json:"hostPort,omitempty"`
+	// Host port. Use the port number provided (LITERAL) or the dynamically
+	// allocated port at this index (FROM_OFFER). Allowable values: LITERAL,
+	// FROM_OFFER.
+	HostPortType string `json:"hostPortType,omitempty"`
+	// Protocol for binding the port. Default is tcp.
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// SingularityExitCooldownRequest is the SingularityExitCooldownRequest model of
+// the Singularity API.
+type SingularityExitCooldownRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+	// Instruct new tasks that are scheduled immediately while executing cooldown
+	// to skip healthchecks.
+	SkipHealthchecks bool `json:"skipHealthchecks,omitempty"`
+}
+
+// SingularityExpiringBounce is the SingularityExpiringBounce model of the
+// Singularity API.
+type SingularityExpiringBounce struct {
+	ActionID                 string                    `json:"actionId,omitempty"`
+	DeployID                 string                    `json:"deployId,omitempty"`
+	ExpiringAPIRequestObject *SingularityBounceRequest `json:"expiringAPIRequestObject,omitempty"`
+	RequestID                string                    `json:"requestId,omitempty"`
+	StartMillis              int64                     `json:"startMillis,omitempty"`
+	User                     string                    `json:"user,omitempty"`
+}
+
+// SingularityExpiringPause is the SingularityExpiringPause model of the
+// Singularity API.
+type SingularityExpiringPause struct {
+	ActionID                 string                   `json:"actionId,omitempty"`
+	ExpiringAPIRequestObject *SingularityPauseRequest `json:"expiringAPIRequestObject,omitempty"`
+	RequestID                string                   `json:"requestId,omitempty"`
+	StartMillis              int64                    `json:"startMillis,omitempty"`
+	User                     string                   `json:"user,omitempty"`
+}
+
+// SingularityExpiringScale is the SingularityExpiringScale model of the
+// Singularity API.
+type SingularityExpiringScale struct {
+	ActionID                 string                   `json:"actionId,omitempty"`
+	Bounce                   bool                     `json:"bounce,omitempty"`
+	DurationMillis           int64                    `json:"durationMillis,omitempty"`
+	ExpiringAPIRequestObject *SingularityScaleRequest `json:"expiringAPIRequestObject,omitempty"`
+	RequestID                string                   `json:"requestId,omitempty"`
+	RevertToInstances        int                      `json:"revertToInstances,omitempty"`
+	StartMillis              int64                    `json:"startMillis,omitempty"`
+	User                     string                   `json:"user,omitempty"`
+}
+
+// SingularityExpiringSkipHealthchecks is the
+// SingularityExpiringSkipHealthchecks model of the Singularity API.
+type SingularityExpiringSkipHealthchecks struct {
+	ActionID                 string                              `json:"actionId,omitempty"`
+	ExpiringAPIRequestObject *SingularitySkipHealthchecksRequest `json:"expiringAPIRequestObject,omitempty"`
+	RequestID                string                              `json:"requestId,omitempty"`
+	RevertToSkipHealthchecks bool                                `json:"revertToSkipHealthchecks,omitempty"`
+	StartMillis              int64                               `json:"startMillis,omitempty"`
+	User                     string                              `json:"user,omitempty"`
+}
+
+// SingularityKillTaskRequest is the SingularityKillTaskRequest model of the
+// Singularity API.
+type SingularityKillTaskRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+	// If set to true, instructs the executor to attempt to immediately kill the
+	// task, rather than waiting gracefully.
+	Override bool `json:"override,omitempty"`
+	// If set to true, treats this task kill as a bounce - launching another task
+	// and waiting for it to become healthy.
+	WaitForReplacementTask bool `json:"waitForReplacementTask,omitempty"`
+}
+
+// SingularityLoadBalancerUpdate is the SingularityLoadBalancerUpdate model of
+// the Singularity API.
+type SingularityLoadBalancerUpdate struct {
+	LoadBalancerRequestID *LoadBalancerRequestID `json:"loadBalancerRequestId,omitempty"`
+	// Allowable values: UNKNOWN, FAILED, WAITING, SUCCESS, CANCELING, CANCELED,
+	// INVALID_REQUEST_NOOP.
+	LoadBalancerState string `json:"loadBalancerState,omitempty"`
+	Message           string `json:"message,omitempty"`
+	// Allowable values: PRE_ENQUEUE, ENQUEUE, CHECK_STATE, CANCEL, DELETE.
+	Method    string `json:"method,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	URI       string `json:"uri,omitempty"`
+}
+
+// SingularityMesosArtifact is the SingularityMesosArtifact model of the
+// Singularity API.
+type SingularityMesosArtifact struct {
+	Cache      bool   `json:"cache,omitempty"`
+	Executable bool   `json:"executable,omitempty"`
+	Extract    bool   `json:"extract,omitempty"`
+	URI        string `json:"uri,omitempty"`
+}
+
+// SingularityMesosImage is the SingularityMesosImage model of the Singularity
+// API.
+type SingularityMesosImage struct {
+	Appc   *SingularityAppcImage   `json:"appc,omitempty"`
+	Cached bool                    `json:"cached,omitempty"`
+	Docker *SingularityDockerImage `json:"docker,omitempty"`
+	// Allowable values: APPC, DOCKER.
+	Type string `json:"type,omitempty"`
+}
+
+// SingularityMesosInfo is the SingularityMesosInfo model of the Singularity
+// API.
+type SingularityMesosInfo struct {
+	Image *SingularityMesosImage `json:"image,omitempty"`
+}
+
+// SingularityMesosTaskLabel is the SingularityMesosTaskLabel model of the
+// Singularity API.
+type SingularityMesosTaskLabel struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// SingularityPauseRequest is the SingularityPauseRequest model of the
+// Singularity API.
+type SingularityPauseRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// The number of milliseconds to wait before reversing the effects of this
+	// action (unpausing it).
+	DurationMillis int64 `json:"durationMillis,omitempty"`
+	// If set to false, tasks will be allowed to finish instead of killed
+	// immediately.
+	KillTasks bool `json:"killTasks,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+}
+
+// SingularityPendingDeploy is the SingularityPendingDeploy model of the
+// Singularity API.
+type SingularityPendingDeploy struct {
+	// Allowable values: SUCCEEDED, FAILED_INTERNAL_STATE, CANCELING, WAITING,
+	// OVERDUE, FAILED, CANCELED.
+	CurrentDeployState     string                         `json:"currentDeployState,omitempty"`
+	DeployMarker           *SingularityDeployMarker       `json:"deployMarker,omitempty"`
+	DeployProgress         *SingularityDeployProgress     `json:"deployProgress,omitempty"`
+	LastLoadBalancerUpdate *SingularityLoadBalancerUpdate `json:"lastLoadBalancerUpdate,omitempty"`
+	UpdatedRequest         *SingularityRequest            `json:"updatedRequest,omitempty"`
+}
+
+// SingularityPendingRequest is the SingularityPendingRequest model of the
+// Singularity API.
+type SingularityPendingRequest struct {
+	ActionID         string   `json:"actionId,omitempty"`
+	CmdLineArgsList  []string `json:"cmdLineArgsList,omitempty"`
+	DeployID         string   `json:"deployId,omitempty"`
+	Message          string   `json:"message,omitempty"`
+	PendingType      string   `json:"pendingType,omitempty"`
+	RequestID        string   `json:"requestId,omitempty"`
+	RunID            string   `json:"runId,omitempty"`
+	SkipHealthchecks bool     `json:"skipHealthchecks,omitempty"`
+	Timestamp        int64    `json:"timestamp,omitempty"`
+	User             string   `json:"user,omitempty"`
+}
+
+// SingularityPendingRequestParent is the SingularityPendingRequestParent model
+// of the Singularity API.
+type SingularityPendingRequestParent struct {
+	ActiveDeploy       *SingularityDeploy             `json:"activeDeploy,omitempty"`
+	PendingRequest     *SingularityPendingRequest     `json:"pendingRequest,omitempty"`
+	Request            *SingularityRequest            `json:"request,omitempty"`
+	RequestDeployState *SingularityRequestDeployState `json:"requestDeployState,omitempty"`
+	// Allowable values: ACTIVE, DELETING, DELETED, PAUSED, SYSTEM_COOLDOWN,
+	// FINISHED, DEPLOYING_TO_UNPAUSE.
+	State string `json:"state,omitempty"`
+}
+
+// SingularityRequest is the SingularityRequest model of the Singularity API.
+type SingularityRequest struct {
+	// If set to true, allow tasks to be scheduled on the same host as an existing
+	// active task when bouncing.
+	AllowBounceToSameHost bool `json:"allowBounceToSameHost,omitempty"`
+	// Allow tasks to run on slaves with these attributes, but do not restrict them
+	// to only these slaves.
+	AllowedSlaveAttributes map[string]string `json:"allowedSlaveAttributes,omitempty"`
+	// Used for SingularityUI. Indicates that a bounce should be performed after
+	// the scale.
+	BounceAfterScale bool `json:"bounceAfterScale,omitempty"`
+	// Auth group associated with this request. Users in this group are allowed
+	// read/write access to this request.
+	Group string `json:"group,omitempty"`
+	// Used for the SingularityUI.
+	HideEvenNumberAcrossRacksHint bool `json:"hideEvenNumberAcrossRacksHint,omitempty"`
+	// A unique id for the request.
+	ID string `json:"id"`
+	// A count of tasks to run for long-running requests.
+	Instances int `json:"instances,omitempty"`
+	// For non-long-running request types, kill a task after this amount of time if
+	// it has been put into CLEANING and has not shut down.
+	KillOldNonLongRunningTasksAfterMillis int64 `json:"killOldNonLongRunningTasksAfterMillis,omitempty"`
+	// Indicates that a SERVICE should be load balanced.
+	LoadBalanced bool `json:"loadBalanced,omitempty"`
+	// Maximum number of tasks allowed to be scheduled in a single offer.
+	MaxTasksPerOffer int `json:"maxTasksPerOffer,omitempty"`
+	// For scheduled jobs, retry up to this many times if the job fails.
+	NumRetriesOnFailure int `json:"numRetriesOnFailure,omitempty"`
+	// A list of emails for the owners of this request.
+	Owners []string `json:"owners,omitempty"`
+	// A schedule in quartz format.
+	QuartzSchedule string `json:"quartzSchedule,omitempty"`
+	// If set, prefer this specific rack when launching tasks.
+	RackAffinity []string `json:"rackAffinity,omitempty"`
+	// Spread instances for this request evenly across separate racks.
+	RackSensitive bool `json:"rackSensitive,omitempty"`
+	// Users in these groups are allowed read only access to this request.
+	ReadOnlyGroups []string `json:"readOnlyGroups,omitempty"`
+	// Users in these groups are allowed read/write access to this request.
+	ReadWriteGroups []string `json:"readWriteGroups,omitempty"`
+	// The type of request, can be SERVICE, WORKER, SCHEDULED, ON_DEMAND, or
+	// RUN_ONCE. Allowable values: SERVICE, WORKER, SCHEDULED, ON_DEMAND, RUN_ONCE.
+	RequestType string `json:"requestType"`
+	// Mesos Role required for this request. Only offers with the required role
+	// will be accepted to execute the tasks associated with the request.
+	RequiredRole string `json:"requiredRole,omitempty"`
+	// Only allow tasks for this request to run on slaves which have these
+	// attributes.
+	RequiredSlaveAttributes map[string]string `json:"requiredSlaveAttributes,omitempty"`
+	// A schedule in cron, RFC5545, or quartz format.
+	Schedule string `json:"schedule,omitempty"`
+	// Time zone to use when running the schedule.
+	ScheduleTimeZone string `json:"scheduleTimeZone,omitempty"`
+	// The type of schedule. Allowable values: CRON, QUARTZ, RFC5545.
+	ScheduleType string `json:"scheduleType,omitempty"`
+	// For scheduled jobs, the expected runtime of a task.
+	ScheduledExpectedRuntimeMillis int64 `json:"scheduledExpectedRuntimeMillis,omitempty"`
+	// If true, do not run healthchecks.
+	SkipHealthchecks bool `json:"skipHealthchecks,omitempty"`
+	// Strategy for determining where to place new tasks. Allowable values:
+	// SEPARATE, OPTIMISTIC, GREEDY, SEPARATE_BY_DEPLOY, SEPARATE_BY_REQUEST,
+	// SPREAD_ALL_SLAVES.
+	SlavePlacement string `json:"slavePlacement,omitempty"`
+	// If set, don't allow any tasks for this request to run for longer than this
+	// amount of time.
+	TaskExecutionTimeLimitMillis int64 `json:"taskExecutionTimeLimitMillis,omitempty"`
+	// Searching for errors in task logs to display.
+	TaskLogErrorRegex string `json:"taskLogErrorRegex,omitempty"`
+	// Determines if taskLogErrorRegex is case sensitive.
+	TaskLogErrorRegexCaseSensitive bool `json:"taskLogErrorRegexCaseSensitive,omitempty"`
+	// a priority level from 0.0 to 1.0 for all tasks associated with the request.
+	TaskPriorityLevel float64 `json:"taskPriorityLevel,omitempty"`
+	// When a scheduled job finishes, wait at least this long before rescheduling
+	// it.
+	WaitAtLeastMillisAfterTaskFinishesForReschedule int64 `json:"waitAtLeastMillisAfterTaskFinishesForReschedule,omitempty"`
+}
+
+// SingularityRequestDeployState is the SingularityRequestDeployState model of
+// the Singularity API.
+type SingularityRequestDeployState struct {
+	// The active deploy for this request.
+	ActiveDeploy *SingularityDeployMarker `json:"activeDeploy,omitempty"`
+	// The pending deploy for this request.
+	PendingDeploy *SingularityDeployMarker `json:"pendingDeploy,omitempty"`
+	// Request id.
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// SingularityRequestHistory is the SingularityRequestHistory model of the
+// Singularity API.
+type SingularityRequestHistory struct {
+	CreatedAt int64 `json:"createdAt,omitempty"`
+	// Allowable values: CREATED, UPDATED, DELETING, DELETED, PAUSED, UNPAUSED,
+	// ENTERED_COOLDOWN, EXITED_COOLDOWN, FINISHED, DEPLOYED_TO_UNPAUSE, BOUNCED,
+	// SCALED, SCALE_REVERTED.
+	EventType string              `json:"eventType,omitempty"`
+	Message   string              `json:"message,omitempty"`
+	Request   *SingularityRequest `json:"request,omitempty"`
+	User      string              `json:"user,omitempty"`
+}
+
+// SingularityRequestParent is the SingularityRequestParent model of the
+// Singularity API. A request together with its state, its deploys and any
+// expiring actions on it.
+type SingularityRequestParent struct {
+	// The active deploy for this request.
+	ActiveDeploy *SingularityDeploy `json:"activeDeploy,omitempty"`
+	// Information about a bounce which may expire.
+	ExpiringBounce *SingularityExpiringBounce `json:"expiringBounce,omitempty"`
+	// Information about a pause which may expire.
+	ExpiringPause *SingularityExpiringPause `json:"expiringPause,omitempty"`
+	// Information about a scale which may expire.
+	ExpiringScale *SingularityExpiringScale `json:"expiringScale,omitempty"`
+	// Information about skipped healthchecks which may expire.
+	ExpiringSkipHealthchecks *SingularityExpiringSkipHealthchecks `json:"expiringSkipHealthchecks,omitempty"`
+	// The pending deploy for this request.
+	PendingDeploy *SingularityDeploy `json:"pendingDeploy,omitempty"`
+	// The state of the pending deploy for this request.
+	PendingDeployState *SingularityPendingDeploy `json:"pendingDeployState,omitempty"`
+	// Singularity Request Object.
+	Request *SingularityRequest `json:"request,omitempty"`
+	// Current deploy state of the request.
+	RequestDeployState *SingularityRequestDeployState `json:"requestDeployState,omitempty"`
+	// State of the request. Allowable values: ACTIVE, DELETING, DELETED, PAUSED,
+	// SYSTEM_COOLDOWN, FINISHED, DEPLOYING_TO_UNPAUSE.
+	State string `json:"state,omitempty"`
+}
+
+// SingularityRunNowRequest is the SingularityRunNowRequest model of the
+// Singularity API.
+type SingularityRunNowRequest struct {
+	// Command line arguments to be passed to the task.
+	CommandLineArgs []string `json:"commandLineArgs,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+	// Override the resources from the active deploy for this run.
+	Resources *Resources `json:"resources,omitempty"`
+	// Schedule this task to run at a specified time.
+	RunAt int64 `json:"runAt,omitempty"`
+	// An id to associate with this request which will be associated with the
+	// corresponding launched tasks.
+	RunID string `json:"runId,omitempty"`
+	// If set to true, healthchecks will be skipped for this task run.
+	SkipHealthchecks bool `json:"skipHealthchecks,omitempty"`
+}
+
+// SingularityScaleRequest is the SingularityScaleRequest model of the
+// Singularity API.
+type SingularityScaleRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// Bounce the request to get better distribution across hosts.
+	Bounce bool `json:"bounce,omitempty"`
+	// The number of milliseconds to wait before reversing the effects of this
+	// action.
+	DurationMillis int64 `json:"durationMillis,omitempty"`
+	// If present and set to true, old tasks will be killed as soon as replacement
+	// tasks are available, instead of waiting for all replacement tasks to be
+	// healthy.
+	Incremental bool `json:"incremental,omitempty"`
+	// The number of instances to scale to.
+	Instances int `json:"instances,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+	// If set to true, healthchecks will be skipped while scaling this request
+	// (only).
+	SkipHealthchecks bool `json:"skipHealthchecks,omitempty"`
+}
+
+// SingularitySkipHealthchecksRequest is the SingularitySkipHealthchecksRequest
+// model of the Singularity API.
+type SingularitySkipHealthchecksRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// The number of milliseconds to wait before reversing the effects of this
+	// action.
+	DurationMillis int64 `json:"durationMillis,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+	// If set to true, healthchecks will be skipped for all tasks for this request
+	// until reversed.
+	SkipHealthchecks bool `json:"skipHealthchecks,omitempty"`
+}
+
+// SingularityTaskCleanup is the SingularityTaskCleanup model of the Singularity
+// API.
+type SingularityTaskCleanup struct {
+	ActionID    string             `json:"actionId,omitempty"`
+	CleanupType string             `json:"cleanupType,omitempty"`
+	Message     string             `json:"message,omitempty"`
+	TaskID      *SingularityTaskID `json:"taskId,omitempty"`
+	Timestamp   int64              `json:"timestamp,omitempty"`
+	User        string             `json:"user,omitempty"`
+}
+
+// SingularityTaskID is the SingularityTaskId model of the Singularity API.
+type SingularityTaskID struct {
+	DeployID        string `json:"deployId,omitempty"`
+	Host            string `json:"host,omitempty"`
+	ID              string `json:"id,omitempty"`
+	InstanceNo      int    `json:"instanceNo,omitempty"`
+	RackID          string `json:"rackId,omitempty"`
+	RequestID       string `json:"requestId,omitempty"`
+	SanitizedHost   string `json:"sanitizedHost,omitempty"`
+	SanitizedRackID string `json:"sanitizedRackId,omitempty"`
+	StartedAt       int64  `json:"startedAt,omitempty"`
+}
+
+// SingularityTaskIDHistory is the SingularityTaskIdHistory model of the
+// Singularity API.
+type SingularityTaskIDHistory struct {
+	// Allowable values: TASK_LAUNCHED, TASK_STAGING, TASK_STARTING, TASK_RUNNING,
+	// TASK_CLEANING, TASK_KILLING, TASK_FINISHED, TASK_FAILED, TASK_KILLED,
+	// TASK_LOST, TASK_LOST_WHILE_DOWN, TASK_ERROR, TASK_DROPPED, TASK_GONE,
+	// TASK_UNREACHABLE, TASK_GONE_BY_OPERATOR, TASK_UNKNOWN.
+	LastTaskState string             `json:"lastTaskState,omitempty"`
+	RunID         string             `json:"runId,omitempty"`
+	TaskID        *SingularityTaskID `json:"taskId,omitempty"`
+	UpdatedAt     int64              `json:"updatedAt,omitempty"`
+}
+
+// SingularityUnpauseRequest is the SingularityUnpauseRequest model of the
+// Singularity API.
+type SingularityUnpauseRequest struct {
+	// An id to associate with this action for metadata purposes.
+	ActionID string `json:"actionId,omitempty"`
+	// A message to show to users about why this action was taken.
+	Message string `json:"message,omitempty"`
+	// If set to true, instructs new tasks that are scheduled immediately while
+	// unpausing to skip healthchecks.
+	SkipHealthchecks bool `json:"skipHealthchecks,omitempty"`
+}
+
+// SingularityVolume is the SingularityVolume model of the Singularity API.
+type SingularityVolume struct {
+	// path to mount the volume in the container.
+	ContainerPath string `json:"containerPath,omitempty"`
+	// path of the volume on the host.
+	HostPath string `json:"hostPath,omitempty"`
+	// Allowable values: RO, RW.
+	Mode string `json:"mode,omitempty"`
+}
@@ -0,0 +1,343 @@
+package singularity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Selector picks requests by type, owner and the labels of their active
+// deploy. Empty fields match every request.
+type Selector struct {
+	RequestType RequestType
+	Owner       string
+	Labels      map[string]string
+}
+
+// Matches returns true if r is picked by s.
+func (s Selector) Matches(r Request) bool {
+	if s.RequestType != "" && r.RequestType != s.RequestType {
+		return false
+	}
+	if s.Owner != "" {
+		found := false
+		for _, o := range r.Owners {
+			found = found || o == s.Owner
+		}
+		if !found {
+			return false
+		}
+	}
+	for k, v := range s.Labels {
+		if l, ok := r.ActiveDeploy.Labels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectRequests retrieves every request, with its active deploy, and
+// returns the IDs of those picked by s, sorted.
+func SelectRequests(c *Client, s Selector) ([]string, error) {
+	reqs, err := getFullRequests(c)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, r := range reqs {
+		if s.Matches(r) {
+			ids = append(ids, r.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// getFullRequests retrieves every request like GetRequests. Singularity
+// only includes the active deploy of each with includeFullRequestData.
+func getFullRequests(c *Client) (Requests, error) {
+	res, err := c.request().
+		SetQueryParam("includeFullRequestData", "true").
+		Get("/api/requests")
+	if err != nil {
+		return nil, fmt.Errorf("Get Singularity requests error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return nil, fmt.Errorf("Get Singularity requests error: %v", string(res.Body()))
+	}
+
+	var body Requests
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return nil, fmt.Errorf("Parse Singularity requests error: %v", err)
+	}
+	return body, nil
+}
+
+// BulkItem is the outcome of a bulk operation on a single request.
+type BulkItem struct {
+	RequestID string
+	// Request is the request after the operation, if it succeeded.
+	Request SingularityRequestParent
+	Err     error
+}
+
+// BulkResult holds an item for every request of a bulk operation, in the
+// order the IDs were given.
+type BulkResult struct {
+	Items []BulkItem
+	// Undo, if not nil, restores what the operation changed. Only Scale
+	// records it, when SetRecordUndo is used.
+	Undo *UndoRecord
+}
+
+// Failed returns the items which failed.
+func (r BulkResult) Failed() []BulkItem {
+	var failed []BulkItem
+	for _, i := range r.Items {
+		if i.Err != nil {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
+
+// BulkError holds the items which failed a bulk operation. The other
+// requests were still changed.
+type BulkError []BulkItem
+
+func (e BulkError) Error() string {
+	s := make([]string, 0, len(e))
+	for _, i := range e {
+		s = append(s, i.RequestID+": "+i.Err.Error())
+	}
+	return fmt.Sprintf("Bulk Singularity request error: %d failed: %s", len(e), strings.Join(s, "; "))
+}
+
+// UndoRecord holds the instance counts of requests before they were scaled.
+// It can be saved as JSON, and given to Bulk.Undo to scale them back.
+type UndoRecord struct {
+	Instances map[string]int `json:"instances"`
+}
+
+// defaultBulkConcurrency is how many requests a Bulk changes at once unless
+// SetConcurrency is used.
+const defaultBulkConcurrency = 4
+
+// Bulk runs the same operation on many requests, on a bounded pool of
+// workers and optionally rate limited.
+type Bulk struct {
+	client      *Client
+	concurrency int
+	interval    time.Duration
+	recordUndo  bool
+}
+
+// NewBulk returns a Bulk which changes requests through c.
+func NewBulk(c *Client) *Bulk {
+	return &Bulk{
+		client:      c,
+		concurrency: defaultBulkConcurrency,
+	}
+}
+
+// SetConcurrency sets how many requests are changed at once. Values below 1
+// are treated as 1.
+func (b *Bulk) SetConcurrency(n int) *Bulk {
+	if n < 1 {
+		n = 1
+	}
+	b.concurrency = n
+	return b
+}
+
+// SetRateLimit limits calls to Singularity to perSecond. Zero removes the
+// limit.
+func (b *Bulk) SetRateLimit(perSecond float64) *Bulk {
+	b.interval = 0
+	if perSecond > 0 {
+		b.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return b
+}
+
+// SetRecordUndo makes Scale record the instance counts it replaces in
+// BulkResult.Undo.
+func (b *Bulk) SetRecordUndo(r bool) *Bulk {
+	b.recordUndo = r
+	return b
+}
+
+// Scale scales every request in ids to instances.
+func (b *Bulk) Scale(ids []string, instances int, message string) (BulkResult, error) {
+	var undo *UndoRecord
+	if b.recordUndo {
+		_, reqs, err := b.client.GetRequests()
+		if err != nil {
+			return BulkResult{}, err
+		}
+		undo = &UndoRecord{Instances: make(map[string]int)}
+		for _, r := range reqs {
+			n := int(r.Instances)
+			// Singularity leaves out instances when it is the default of 1.
+			if n == 0 && (r.RequestType == RequestTypeService || r.RequestType == RequestTypeWorker) {
+				n = 1
+			}
+			undo.Instances[r.ID] = n
+		}
+	}
+
+	res, err := b.run(ids, func(id string) (SingularityRequestParent, error) {
+		return b.scale(id, instances, message)
+	})
+	if undo != nil {
+		// Only requests which were scaled need to be scaled back.
+		res.Undo = &UndoRecord{Instances: make(map[string]int)}
+		for _, i := range res.Items {
+			if n, ok := undo.Instances[i.RequestID]; ok && i.Err == nil {
+				res.Undo.Instances[i.RequestID] = n
+			}
+		}
+	}
+	return res, err
+}
+
+// Undo scales every request in u back to its recorded instance count.
+func (b *Bulk) Undo(u UndoRecord, message string) (BulkResult, error) {
+	ids := make([]string, 0, len(u.Instances))
+	for id := range u.Instances {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return b.run(ids, func(id string) (SingularityRequestParent, error) {
+		return b.scale(id, u.Instances[id], message)
+	})
+}
+
+func (b *Bulk) scale(id string, instances int, message string) (SingularityRequestParent, error) {
+	res, err := ScaleRequest(b.client, *NewRequestScale(id, message, instances, 0))
+	if err != nil {
+		return SingularityRequestParent{}, err
+	}
+	if code := res.RestyResponse.StatusCode(); code < 200 || code > 299 {
+		return SingularityRequestParent{}, fmt.Errorf("Scale Singularity request error: %s", res.RestyResponse.Body())
+	}
+	return res.RequestParent, nil
+}
+
+// Pause pauses every request in ids, killing their tasks if kill is true.
+func (b *Bulk) Pause(ids []string, message string, kill bool) (BulkResult, error) {
+	return b.run(ids, func(id string) (SingularityRequestParent, error) {
+		res, err := PauseRequest(b.client, NewPauseRequest(id, message, kill))
+		return res.RequestParent, err
+	})
+}
+
+// Unpause unpauses every request in ids.
+func (b *Bulk) Unpause(ids []string, message string) (BulkResult, error) {
+	return b.run(ids, func(id string) (SingularityRequestParent, error) {
+		res, err := UnpauseRequest(b.client, NewUnpauseRequest(id, message))
+		return res.RequestParent, err
+	})
+}
+
+// Bounce restarts the tasks of every request in ids.
+func (b *Bulk) Bounce(ids []string, message string, incremental bool) (BulkResult, error) {
+	return b.run(ids, func(id string) (SingularityRequestParent, error) {
+		res, err := BounceRequest(b.client, NewBounceRequest(id, message, incremental))
+		return res.RequestParent, err
+	})
+}
+
+// PauseGroup pauses every request of the request group id, killing their
+// tasks if kill is true.
+func (b *Bulk) PauseGroup(id, message string, kill bool) (BulkResult, error) {
+	ids, err := b.group(id)
+	if err != nil {
+		return BulkResult{}, err
+	}
+	return b.Pause(ids, message, kill)
+}
+
+// UnpauseGroup unpauses every request of the request group id.
+func (b *Bulk) UnpauseGroup(id, message string) (BulkResult, error) {
+	ids, err := b.group(id)
+	if err != nil {
+		return BulkResult{}, err
+	}
+	return b.Unpause(ids, message)
+}
+
+// BounceGroup restarts the tasks of every request of the request group id.
+func (b *Bulk) BounceGroup(id, message string, incremental bool) (BulkResult, error) {
+	ids, err := b.group(id)
+	if err != nil {
+		return BulkResult{}, err
+	}
+	return b.Bounce(ids, message, incremental)
+}
+
+// group returns the ids of the requests of the request group id.
+func (b *Bulk) group(id string) ([]string, error) {
+	_, g, err := b.client.GetRequestGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	return g.RequestIDs, nil
+}
+
+// Delete deletes every request in ids.
+func (b *Bulk) Delete(ids []string, message string) (BulkResult, error) {
+	return b.run(ids, func(id string) (SingularityRequestParent, error) {
+		res, err := DeleteRequest(b.client, NewDeleteRequest(id, message, "", false))
+		if err != nil {
+			return SingularityRequestParent{}, err
+		}
+		if code := res.RestyResponse.StatusCode(); code < 200 || code > 299 {
+			return SingularityRequestParent{}, fmt.Errorf("Delete Singularity request error: %s", res.RestyResponse.Body())
+		}
+		var p SingularityRequestParent
+		p.SingularityRequest = res.Response
+		return p, nil
+	})
+}
+
+// run calls f for every id on the worker pool, and returns a BulkError of
+// the items which failed, or nil.
+func (b *Bulk) run(ids []string, f func(id string) (SingularityRequestParent, error)) (BulkResult, error) {
+	res := BulkResult{Items: make([]BulkItem, len(ids))}
+	var tick <-chan time.Time
+	if b.interval > 0 {
+		t := time.NewTicker(b.interval)
+		defer t.Stop()
+		tick = t.C
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < b.concurrency && w < len(ids); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				p, err := f(ids[i])
+				res.Items[i] = BulkItem{RequestID: ids[i], Request: p, Err: err}
+			}
+		}()
+	}
+	for i := range ids {
+		if tick != nil && i > 0 {
+			<-tick
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failed := res.Failed(); len(failed) > 0 {
+		return res, BulkError(failed)
+	}
+	return res, nil
+}
@@ -0,0 +1,173 @@
+package singularity
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bulkServer serves requests w1, w2, svc and one, which has the default single
+// instance and so no instances field, and the request group web of w1 and svc,
+// and fails every call for w2.
+func bulkServer(t *testing.T) (*httptest.Server, map[string]int) {
+	var mu sync.Mutex
+	instances := map[string]int{"w1": 3, "w2": 5, "svc": 2, "one": 1}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/requests" {
+			// Active deploys are only listed with full request data.
+			deploy := ""
+			if r.URL.Query().Get("includeFullRequestData") == "true" {
+				deploy = `,"activeDeploy":{"labels":{"team":"data"}}`
+			}
+			fmt.Fprintf(w, `[{"request":{"id":"w1","requestType":"WORKER","owners":["ops"],"instances":3}%s},`+
+				`{"request":{"id":"w2","requestType":"WORKER","owners":["ops"],"instances":5}%s},`+
+				`{"request":{"id":"svc","requestType":"SERVICE","owners":["web"],"instances":2}},`+
+				`{"request":{"id":"one","requestType":"WORKER","owners":["ops"]}}]`, deploy, deploy)
+			return
+		}
+		if r.URL.Path == "/api/groups/group/web" {
+			w.Write([]byte(`{"id":"web","requestIds":["w1","svc"]}`))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/groups/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		path := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/requests/request/"), "/")
+		id := path[0]
+		if id == "w2" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message":"request is locked"}`))
+			return
+		}
+		if len(path) == 2 && path[1] == "scale" {
+			var body SingularityScaleRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			instances[id] = body.Instances
+		}
+		if r.Method == "DELETE" {
+			// Deleting replies with the deleted request itself.
+			w.Write([]byte(`{"id":"` + id + `"}`))
+			return
+		}
+		w.Write([]byte(`{"request":{"id":"` + id + `"},"state":"ACTIVE"}`))
+	}))
+	return ts, instances
+}
+
+func TestSelector(t *testing.T) {
+	ts, _ := bulkServer(t)
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	var data = []struct {
+		selector Selector
+		expected []string
+	}{
+		{Selector{}, []string{"one", "svc", "w1", "w2"}},
+		{Selector{RequestType: "WORKER"}, []string{"one", "w1", "w2"}},
+		{Selector{Owner: "web"}, []string{"svc"}},
+		{Selector{Labels: map[string]string{"team": "data"}}, []string{"w1", "w2"}},
+		{Selector{Labels: map[string]string{"team": "web"}}, nil},
+	}
+	for _, tt := range data {
+		ids, err := SelectRequests(c, tt.selector)
+		if err != nil {
+			t.Fatalf("SelectRequests: unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(ids, tt.expected) {
+			t.Errorf("SelectRequests(%+v): expected %v, got %v", tt.selector, tt.expected, ids)
+		}
+	}
+}
+
+func TestBulkScale(t *testing.T) {
+	ts, instances := bulkServer(t)
+	defer ts.Close()
+	b := NewBulk(newTestClient(ts)).SetConcurrency(2).SetRecordUndo(true)
+
+	res, err := b.Scale([]string{"w1", "w2", "svc", "one"}, 0, "maintenance")
+	failed, ok := err.(BulkError)
+	if !ok || len(failed) != 1 || failed[0].RequestID != "w2" {
+		t.Fatalf("Scale: expected only w2 to fail, got %v", err)
+	}
+	if len(res.Items) != 4 || res.Items[0].RequestID != "w1" || res.Items[0].Request.ID != "w1" || res.Items[0].Err != nil {
+		t.Errorf("Scale: expected an item per request in order, got %+v", res.Items)
+	}
+	if instances["w1"] != 0 || instances["svc"] != 0 || instances["one"] != 0 {
+		t.Errorf("Scale: expected w1, svc and one to be scaled to 0, got %v", instances)
+	}
+	expected := map[string]int{"w1": 3, "svc": 2, "one": 1}
+	if res.Undo == nil || !reflect.DeepEqual(res.Undo.Instances, expected) {
+		t.Fatalf("Scale: expected undo record %v, got %+v", expected, res.Undo)
+	}
+
+	if _, err := b.Undo(*res.Undo, "maintenance over"); err != nil {
+		t.Fatalf("Undo: unexpected error %v", err)
+	}
+	if instances["w1"] != 3 || instances["svc"] != 2 || instances["one"] != 1 {
+		t.Errorf("Undo: expected instances to be restored, got %v", instances)
+	}
+}
+
+func TestBulkActions(t *testing.T) {
+	ts, _ := bulkServer(t)
+	defer ts.Close()
+	b := NewBulk(newTestClient(ts)).SetRateLimit(200)
+	ids := []string{"w1", "svc"}
+
+	start := time.Now()
+	for name, f := range map[string]func() (BulkResult, error){
+		"Pause":   func() (BulkResult, error) { return b.Pause(ids, "", true) },
+		"Unpause": func() (BulkResult, error) { return b.Unpause(ids, "") },
+		"Bounce":  func() (BulkResult, error) { return b.Bounce(ids, "", false) },
+		"Delete":  func() (BulkResult, error) { return b.Delete(ids, "") },
+	} {
+		res, err := f()
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if len(res.Items) != 2 || res.Items[1].Request.ID != "svc" {
+			t.Errorf("%s: expected items for w1 and svc, got %d items", name, len(res.Items))
+		}
+	}
+	// Four operations on two requests wait for a tick before each second call.
+	if elapsed := time.Since(start); elapsed < 4*5*time.Millisecond {
+		t.Errorf("SetRateLimit: expected calls to be spaced out, took %v", elapsed)
+	}
+
+	if _, err := b.Pause([]string{"w2"}, "", false); err == nil {
+		t.Errorf("Pause: expected error for w2")
+	}
+}
+
+func TestBulkGroupActions(t *testing.T) {
+	ts, _ := bulkServer(t)
+	defer ts.Close()
+	b := NewBulk(newTestClient(ts))
+
+	for name, f := range map[string]func(string) (BulkResult, error){
+		"PauseGroup":   func(id string) (BulkResult, error) { return b.PauseGroup(id, "", true) },
+		"UnpauseGroup": func(id string) (BulkResult, error) { return b.UnpauseGroup(id, "") },
+		"BounceGroup":  func(id string) (BulkResult, error) { return b.BounceGroup(id, "", true) },
+	} {
+		res, err := f("web")
+		if err != nil {
+			t.Errorf("%s(web): unexpected error %v", name, err)
+		}
+		if len(res.Items) != 2 || res.Items[0].RequestID != "w1" || res.Items[1].Request.ID != "svc" {
+			t.Errorf("%s(web): expected items for w1 and svc, got %+v", name, res.Items)
+		}
+		if _, err := f("missing"); err == nil {
+			t.Errorf("%s(missing): expected error", name)
+		}
+	}
+}
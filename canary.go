@@ -0,0 +1,147 @@
+package singularity
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultPollInterval is how often deploys are polled when no interval is
+// given.
+const defaultPollInterval = 5 * time.Second
+
+// CanaryCheck is called each time a deploy step completes, with the progress
+// of the deploy. Returning false holds the deploy at this step and calls the
+// check again after the poll interval; returning an error aborts the deploy.
+type CanaryCheck func(SingularityDeployProgress) (bool, error)
+
+// Canary drives a pending deploy with autoAdvanceDeploySteps false one step
+// at a time. Create one with NewCanary and pass it to Client.RunCanary.
+type Canary struct {
+	RequestID string
+	DeployID  string
+	Check     CanaryCheck
+
+	// Step is the number of instances added by each step. Zero uses the
+	// deploy's deployInstanceCountPerStep, or 1 if that is not set.
+	Step int
+	// Interval is how often the deploy is polled. Zero polls every five
+	// seconds.
+	Interval time.Duration
+	// Timeout aborts the deploy if it has not finished in time. Zero waits
+	// forever.
+	Timeout time.Duration
+	// OnProgress, if set, is called with the progress of the deploy on
+	// every poll.
+	OnProgress func(SingularityDeployProgress)
+}
+
+// NewCanary accepts a request id, a deploy id and a check and returns a
+// Canary which advances that deploy only after check passes.
+func NewCanary(requestID, deployID string, check CanaryCheck) *Canary {
+	return &Canary{
+		RequestID: requestID,
+		DeployID:  deployID,
+		Check:     check,
+	}
+}
+
+// RunCanary advances the pending deploy of canary a step at a time until it
+// reaches the request's instance count, then waits for it to finish and
+// returns its result. If the check fails or the timeout passes, the deploy is
+// cancelled with DeleteHTTPDeploy and an error is returned. Once the last step
+// has been requested Singularity finishes the deploy by itself, so the check
+// is not called for it.
+func (c *Client) RunCanary(canary *Canary) (SingularityDeployResult, error) {
+	interval := canary.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	var deadline time.Time
+	if canary.Timeout > 0 {
+		deadline = time.Now().Add(canary.Timeout)
+	}
+
+	for {
+		parent, err := c.GetRequestParent(canary.RequestID)
+		if err != nil {
+			return SingularityDeployResult{}, err
+		}
+		pending := parent.SingularityPendingDeploy
+		if pending.SingularityDeployMarker.DeployID != canary.DeployID {
+			// The deploy is no longer pending, so it has a result.
+			return c.WaitForDeploy(canary.RequestID, canary.DeployID, interval, remaining(deadline))
+		}
+
+		progress := pending.SingularityDeployProgress
+		if canary.OnProgress != nil {
+			canary.OnProgress(progress)
+		}
+		final := int(parent.SingularityRequest.Instances)
+		if final < 1 {
+			final = 1
+		}
+		if progress.StepComplete && progress.TargetActiveInstances < final {
+			ok, err := canary.Check(progress)
+			if err != nil {
+				return c.abortCanary(canary, err)
+			}
+			if ok {
+				next := progress.TargetActiveInstances + canary.step(progress)
+				if next > final {
+					next = final
+				}
+				if _, err := c.UpdatePendingDeploy(canary.RequestID, canary.DeployID, next); err != nil {
+					return SingularityDeployResult{}, err
+				}
+			}
+		}
+
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return c.abortCanary(canary, fmt.Errorf("timed out after %v", canary.Timeout))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// remaining returns the time left until deadline as a WaitForDeploy timeout:
+// zero for no deadline, and at least a nanosecond once it has passed.
+func remaining(deadline time.Time) time.Duration {
+	if deadline.IsZero() {
+		return 0
+	}
+	if d := time.Until(deadline); d > 0 {
+		return d
+	}
+	return time.Nanosecond
+}
+
+func (canary *Canary) step(p SingularityDeployProgress) int {
+	switch {
+	case canary.Step > 0:
+		return canary.Step
+	case p.DeployInstanceCountPerStep > 0:
+		return p.DeployInstanceCountPerStep
+	}
+	return 1
+}
+
+// abortCanary cancels the deploy of canary because of cause, and returns the
+// state Singularity reports for it. Cancelling is asynchronous, so this is
+// usually CANCELING rather than CANCELED.
+func (c *Client) abortCanary(canary *Canary, cause error) (SingularityDeployResult, error) {
+	res, err := NewDeleteDeploy(canary.RequestID, canary.DeployID).Delete(c)
+	if err == nil && (res.RestyResponse.StatusCode() < 200 || res.RestyResponse.StatusCode() > 299) {
+		err = fmt.Errorf("%s", res.RestyResponse.Body())
+	}
+	if err != nil {
+		return SingularityDeployResult{}, fmt.Errorf("Canary Singularity deploy %s error: %v, and cancelling it failed: %v", canary.DeployID, cause, err)
+	}
+
+	var result SingularityDeployResult
+	if p := res.RequestParent.SingularityPendingDeploy; p.SingularityDeployMarker.DeployID == canary.DeployID {
+		result.DeployState = p.CurrentDeployState
+	} else if _, h, err := c.GetDeployHistory(canary.RequestID, canary.DeployID); err == nil && h.DeployResult != nil {
+		result = *h.DeployResult
+	}
+	return result, fmt.Errorf("Canary Singularity deploy %s aborted: %v", canary.DeployID, cause)
+}
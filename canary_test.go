@@ -0,0 +1,157 @@
+package singularity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// canaryServer serves request r1 with 3 instances and a pending deploy d1
+// which advances one step per update.
+func canaryServer(t *testing.T) (*httptest.Server, *[]string) {
+	var (
+		mu      sync.Mutex
+		target  = 1
+		pending = true
+		calls   []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/requests/request/r1":
+			if !pending {
+				w.Write([]byte(`{"request":{"id":"r1","instances":3}}`))
+				return
+			}
+			fmt.Fprintf(w, `{"request":{"id":"r1","instances":3},"pendingDeployState":{"deployMarker":{"requestId":"r1","deployId":"d1"},`+
+				`"deployProgress":{"stepComplete":true,"deployInstanceCountPerStep":1,"targetActiveInstances":%d,"currentActiveInstances":%d}}}`, target, target)
+		case "PUT /api/deploys/update":
+			var body SingularityUpdatePendingDeployRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.RequestID != "r1" || body.DeployID != "d1" || body.TargetActiveInstances != target+1 {
+				t.Errorf("UpdatePendingDeploy: unexpected body %+v", body)
+			}
+			target = body.TargetActiveInstances
+			pending = target < 3
+			w.Write([]byte(`{"request":{"id":"r1","instances":3}}`))
+		case "DELETE /api/deploys/deploy/d1/request/r1":
+			pending = false
+			w.Write([]byte(`{"request":{"id":"r1","instances":3},"pendingDeployState":{"deployMarker":{"requestId":"r1","deployId":"d1"},"currentDeployState":"CANCELING"}}`))
+		case "GET /api/history/request/r1/deploy/d1":
+			state := "SUCCEEDED"
+			if target < 3 {
+				state = "CANCELED"
+			}
+			fmt.Fprintf(w, `{"deployMarker":{"requestId":"r1","deployId":"d1"},"deployResult":{"deployState":%q}}`, state)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`not found`))
+		}
+	}))
+	return ts, &calls
+}
+
+func TestClientRunCanary(t *testing.T) {
+	ts, _ := canaryServer(t)
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	var checked, seen []int
+	canary := NewCanary("r1", "d1", func(p SingularityDeployProgress) (bool, error) {
+		checked = append(checked, p.TargetActiveInstances)
+		// Hold the first step for one extra poll.
+		return len(checked) > 1, nil
+	})
+	canary.Interval = time.Millisecond
+	canary.OnProgress = func(p SingularityDeployProgress) {
+		seen = append(seen, p.TargetActiveInstances)
+	}
+
+	res, err := c.RunCanary(canary)
+	if err != nil {
+		t.Fatalf("RunCanary: unexpected error %v", err)
+	}
+	if !res.Succeeded() {
+		t.Errorf("RunCanary: expected a successful deploy, got %+v", res)
+	}
+	if expected := []int{1, 1, 2}; !reflect.DeepEqual(checked, expected) {
+		t.Errorf("RunCanary: expected checks at %v instances, got %v", expected, checked)
+	}
+	if expected := []int{1, 1, 2}; !reflect.DeepEqual(seen, expected) {
+		t.Errorf("RunCanary: expected progress at %v instances, got %v", expected, seen)
+	}
+}
+
+func TestClientRunCanaryAbort(t *testing.T) {
+	ts, calls := canaryServer(t)
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	canary := NewCanary("r1", "d1", func(p SingularityDeployProgress) (bool, error) {
+		return false, errors.New("error rate too high")
+	})
+	canary.Interval = time.Millisecond
+	res, err := c.RunCanary(canary)
+	if err == nil {
+		t.Fatalf("RunCanary: expected error")
+	}
+	if res.DeployState != "CANCELING" {
+		t.Errorf("RunCanary: expected CANCELING, got %s", res.DeployState)
+	}
+	if last := (*calls)[len(*calls)-1]; last != "DELETE /api/deploys/deploy/d1/request/r1" {
+		t.Errorf("RunCanary: expected the deploy to be cancelled, last call was %s", last)
+	}
+
+	ts, _ = canaryServer(t)
+	defer ts.Close()
+	canary = NewCanary("r1", "d1", func(p SingularityDeployProgress) (bool, error) {
+		return false, nil
+	})
+	canary.Interval = time.Millisecond
+	canary.Timeout = 10 * time.Millisecond
+	if _, err := newTestClient(ts).RunCanary(canary); err == nil {
+		t.Errorf("RunCanary: expected timeout error")
+	}
+}
+
+func TestClientRunCanaryTimeoutWhileFinishing(t *testing.T) {
+	// The deploy has left the pending state but never gets a result.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/requests/request/r1":
+			w.Write([]byte(`{"request":{"id":"r1","instances":3}}`))
+		case "/api/history/request/r1/deploy/d1":
+			w.Write([]byte(`{"deployMarker":{"requestId":"r1","deployId":"d1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	canary := NewCanary("r1", "d1", func(p SingularityDeployProgress) (bool, error) {
+		return true, nil
+	})
+	canary.Interval = time.Millisecond
+	canary.Timeout = 20 * time.Millisecond
+	done := make(chan error, 1)
+	go func() {
+		_, err := newTestClient(ts).RunCanary(canary)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("RunCanary: expected timeout error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("RunCanary: did not stop after the timeout")
+	}
+}
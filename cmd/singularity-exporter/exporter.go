@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// exporter serves the state of a Singularity cluster in the Prometheus text
+// format. The cluster is read at most once per ttl, and scrapes in between
+// get the same metrics.
+type exporter struct {
+	client *singularity.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	fetched time.Time
+	body    []byte
+	// errors counts failed reads of each source since the exporter
+	// started.
+	errors map[string]int
+}
+
+func newExporter(c *singularity.Client, ttl time.Duration) *exporter {
+	return &exporter{
+		client: c,
+		ttl:    ttl,
+		now:    time.Now,
+		errors: map[string]int{},
+	}
+}
+
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	if e.body == nil || e.now().Sub(e.fetched) >= e.ttl {
+		e.body = e.collect()
+		e.fetched = e.now()
+	}
+	body := e.body
+	e.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(body)
+}
+
+// sources are the parts of the cluster state which are read separately, so
+// that one failing doesn't hide the rest.
+var sources = []string{"requests", "active_tasks", "pending_tasks", "cleaning_tasks", "pending_deploys", "slaves", "racks"}
+
+// collect reads the cluster and returns its metrics. The caller must hold
+// e.mu.
+func (e *exporter) collect() []byte {
+	start := e.now()
+	m := newMetrics()
+	failed := map[string]bool{}
+	fail := func(source string, err error) bool {
+		if err != nil {
+			failed[source] = true
+			e.errors[source]++
+		}
+		return err != nil
+	}
+
+	_, reqs, err := e.client.GetRequests()
+	if !fail("requests", err) {
+		m.declare("singularity_requests", "gauge", "Number of requests by state and type.")
+		m.declare("singularity_request_instances_desired", "gauge", "Number of instances a request asks for.")
+		for _, r := range reqs {
+			m.add("singularity_requests", 1, "state", r.State.String(), "type", r.RequestType.String())
+			m.set("singularity_request_instances_desired", float64(r.Instances), "request", r.ID)
+		}
+	}
+
+	_, tasks, err := e.client.GetActiveTasks()
+	if !fail("active_tasks", err) {
+		m.declare("singularity_tasks_active", "gauge", "Number of active tasks.")
+		m.set("singularity_tasks_active", float64(len(tasks)))
+		if !failed["requests"] {
+			// Every request gets a sample, so that requests without
+			// tasks show up as 0 rather than missing.
+			m.declare("singularity_request_instances_active", "gauge", "Number of active tasks of a request.")
+			for _, r := range reqs {
+				m.add("singularity_request_instances_active", 0, "request", r.ID)
+			}
+			for _, t := range tasks {
+				m.add("singularity_request_instances_active", 1, "request", t.TaskID.RequestID)
+			}
+		}
+	}
+
+	_, pending, err := e.client.GetScheduledTaskIDs()
+	if !fail("pending_tasks", err) {
+		m.declare("singularity_tasks_pending", "gauge", "Number of tasks waiting to be launched.")
+		m.set("singularity_tasks_pending", float64(len(pending)))
+	}
+
+	_, cleaning, err := e.client.GetCleaningTasks()
+	if !fail("cleaning_tasks", err) {
+		m.declare("singularity_tasks_cleaning", "gauge", "Number of tasks being cleaned up, by cleanup type.")
+		for _, t := range cleaning {
+			m.add("singularity_tasks_cleaning", 1, "type", t.CleanupType)
+		}
+	}
+
+	_, deploys, err := e.client.GetPendingDeploys()
+	if !fail("pending_deploys", err) {
+		m.declare("singularity_deploys_pending", "gauge", "Number of pending deploys by state.")
+		for _, d := range deploys {
+			m.add("singularity_deploys_pending", 1, "state", d.CurrentDeployState.String())
+		}
+	}
+
+	_, slaves, err := e.client.GetSlaves("")
+	if !fail("slaves", err) {
+		m.declare("singularity_slaves", "gauge", "Number of agents by state.")
+		for _, s := range slaves {
+			m.add("singularity_slaves", 1, "state", s.CurrentState.State)
+		}
+	}
+
+	_, racks, err := e.client.GetRacks("")
+	if !fail("racks", err) {
+		m.declare("singularity_racks", "gauge", "Number of racks by state.")
+		for _, r := range racks {
+			m.add("singularity_racks", 1, "state", r.CurrentState.State)
+		}
+	}
+
+	m.declare("singularity_up", "gauge", "Whether every source was read on the last scrape.")
+	up := 1.0
+	if len(failed) > 0 {
+		up = 0
+	}
+	m.set("singularity_up", up)
+	m.declare("singularity_scrape_errors_total", "counter", "Number of failed reads of each source.")
+	for _, s := range sources {
+		m.set("singularity_scrape_errors_total", float64(e.errors[s]), "source", s)
+	}
+	m.declare("singularity_scrape_duration_seconds", "gauge", "How long reading the cluster took.")
+	m.set("singularity_scrape_duration_seconds", e.now().Sub(start).Seconds())
+	return m.bytes()
+}
+
+// metrics builds a Prometheus text exposition. Metrics are written in the
+// order they are declared, and their samples sorted by labels.
+type metrics struct {
+	names   []string
+	help    map[string]string
+	types   map[string]string
+	samples map[string]map[string]float64
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		help:    map[string]string{},
+		types:   map[string]string{},
+		samples: map[string]map[string]float64{},
+	}
+}
+
+func (m *metrics) declare(name, typ, help string) {
+	m.names = append(m.names, name)
+	m.help[name] = help
+	m.types[name] = typ
+	m.samples[name] = map[string]float64{}
+}
+
+// add adds v to the sample of name with labels, given as name and value
+// pairs.
+func (m *metrics) add(name string, v float64, labels ...string) {
+	m.samples[name][formatLabels(labels)] += v
+}
+
+// set sets the sample of name with labels to v.
+func (m *metrics) set(name string, v float64, labels ...string) {
+	m.samples[name][formatLabels(labels)] = v
+}
+
+func (m *metrics) bytes() []byte {
+	var b bytes.Buffer
+	for _, name := range m.names {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, m.help[name], name, m.types[name])
+		labels := make([]string, 0, len(m.samples[name]))
+		for l := range m.samples[name] {
+			labels = append(labels, l)
+		}
+		sort.Strings(labels)
+		for _, l := range labels {
+			fmt.Fprintf(&b, "%s%s %s\n", name, l, strconv.FormatFloat(m.samples[name][l], 'g', -1, 64))
+		}
+	}
+	return b.Bytes()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	s := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		s = append(s, labels[i]+`="`+labelEscaper.Replace(labels[i+1])+`"`)
+	}
+	return "{" + strings.Join(s, ",") + "}"
+}
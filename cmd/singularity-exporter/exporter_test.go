@@ -0,0 +1,116 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+	"github.com/lenfree/go-mesos-singularity/singularitytest"
+)
+
+func scrape(e *exporter) string {
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(w.Body)
+	return string(body)
+}
+
+func TestExporter(t *testing.T) {
+	s := singularitytest.NewServer()
+	defer s.Close()
+	s.AddRequest(singularity.SingularityRequest{ID: "web", RequestType: "SERVICE", Instances: 3})
+	s.AddRequest(singularity.SingularityRequest{ID: "api", RequestType: "SERVICE", Instances: 2})
+	s.AddRequest(singularity.SingularityRequest{ID: "job", RequestType: "SCHEDULED"})
+	for _, id := range []string{"web-1", "web-2"} {
+		s.AddTask(singularity.SingularityTask{TaskID: singularity.SingularityTaskID{ID: id, RequestID: "web"}})
+	}
+	s.AddPendingTask(singularity.SingularityPendingTaskID{ID: "web-3", RequestID: "web"})
+	s.AddCleaningTask(singularity.SingularityTaskCleanup{CleanupType: "BOUNCING"})
+	s.AddSlave(singularity.SingularitySlave{ID: "a1", CurrentState: singularity.SingularityMachineState{State: "ACTIVE"}})
+	s.AddSlave(singularity.SingularitySlave{ID: "a2", CurrentState: singularity.SingularityMachineState{State: "DECOMMISSIONED"}})
+	s.AddRack(singularity.SingularityRack{ID: "r1", CurrentState: singularity.SingularityMachineState{State: "ACTIVE"}})
+
+	now := time.Unix(0, 0)
+	e := newExporter(s.Client(), time.Minute)
+	e.now = func() time.Time { return now }
+	got := scrape(e)
+
+	for _, line := range []string{
+		"# TYPE singularity_requests gauge",
+		`singularity_requests{state="ACTIVE",type="SERVICE"} 2`,
+		`singularity_requests{state="ACTIVE",type="SCHEDULED"} 1`,
+		`singularity_request_instances_desired{request="web"} 3`,
+		`singularity_request_instances_active{request="web"} 2`,
+		`singularity_request_instances_active{request="api"} 0`,
+		"singularity_tasks_active 2",
+		"singularity_tasks_pending 1",
+		`singularity_tasks_cleaning{type="BOUNCING"} 1`,
+		`singularity_slaves{state="DECOMMISSIONED"} 1`,
+		`singularity_racks{state="ACTIVE"} 1`,
+		"singularity_up 1",
+		`singularity_scrape_errors_total{source="requests"} 0`,
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("ServeHTTP: expected %q in:\n%s", line, got)
+		}
+	}
+
+	// Until the cache expires, scrapes don't read the cluster again.
+	s.AddRequest(singularity.SingularityRequest{ID: "new", RequestType: "WORKER", Instances: 1})
+	if again := scrape(e); again != got {
+		t.Errorf("ServeHTTP: expected cached metrics, got:\n%s", again)
+	}
+	now = now.Add(time.Minute)
+	if again := scrape(e); !strings.Contains(again, `singularity_request_instances_desired{request="new"} 1`) {
+		t.Errorf("ServeHTTP: expected refreshed metrics, got:\n%s", again)
+	}
+}
+
+func TestExporterErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/racks" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`boom`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	e := newExporter(singularity.NewClient(singularity.NewConfig().SetHost(strings.TrimPrefix(ts.URL, "http://")).Build()), 0)
+	scrape(e)
+	got := scrape(e)
+	for _, line := range []string{
+		"singularity_up 0",
+		`singularity_scrape_errors_total{source="racks"} 2`,
+		`singularity_scrape_errors_total{source="slaves"} 0`,
+		"singularity_tasks_active 0",
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("ServeHTTP: expected %q in:\n%s", line, got)
+		}
+	}
+	if strings.Contains(got, "singularity_racks") {
+		t.Errorf("ServeHTTP: expected no rack metrics when racks fail, got:\n%s", got)
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	var data = []struct {
+		labels   []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"state", "ACTIVE"}, `{state="ACTIVE"}`},
+		{[]string{"a", `x"y\z`, "b", "1\n2"}, `{a="x\"y\\z",b="1\n2"}`},
+	}
+	for _, tt := range data {
+		if got := formatLabels(tt.labels); got != tt.expected {
+			t.Errorf("formatLabels(%v): expected %s, got %s", tt.labels, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Command singularity-exporter serves the state of a Singularity cluster as
+// Prometheus metrics on /metrics.
+//
+// The cluster is taken from the -host, -port and -retry flags, or the
+// SINGULARITY_HOST, SINGULARITY_PORT and SINGULARITY_RETRY environment
+// variables. It is read at most once per -cache interval, however often
+// Prometheus scrapes.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+func main() {
+	fs := flag.NewFlagSet("singularity-exporter", flag.ExitOnError)
+	listen := fs.String("listen", ":9479", "`address` to serve metrics on")
+	host := fs.String("host", os.Getenv("SINGULARITY_HOST"), "Singularity `host`, including any path prefix")
+	port := fs.Int("port", envInt("SINGULARITY_PORT"), "Singularity `port`")
+	retry := fs.Int("retry", envInt("SINGULARITY_RETRY"), "number of retries for failed HTTP requests")
+	ttl := fs.Duration("cache", 15*time.Second, "how long to serve the same metrics before reading the cluster again")
+	fs.Parse(os.Args[1:])
+
+	if *host == "" {
+		fmt.Fprintln(os.Stderr, "singularity-exporter: no host given, use -host or SINGULARITY_HOST")
+		os.Exit(2)
+	}
+	c := singularity.NewClient(singularity.NewConfig().SetHost(*host).SetPort(*port).SetRetry(*retry).Build())
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", newExporter(c, *ttl))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, `<html><body><a href="/metrics">Metrics</a></body></html>`)
+	})
+	log.Printf("serving metrics of %s on %s", *host, *listen)
+	log.Fatal(http.ListenAndServe(*listen, mux))
+}
+
+// envInt returns the integer in an environment variable, or 0 if it is unset
+// or not a number.
+func envInt(name string) int {
+	n, _ := strconv.Atoi(os.Getenv(name))
+	return n
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// spec is the part of a Swagger 2.0 description the generator reads.
+type spec struct {
+	Paths       map[string]map[string]*operation `json:"paths"`
+	Definitions map[string]*schema               `json:"definitions"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []parameter          `json:"parameters"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Type        string  `json:"type"`
+	Schema      *schema `json:"schema"`
+}
+
+type response struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Enum                 []string           `json:"enum"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+}
+
+const header = "// Code generated by singularity-gen. DO NOT EDIT.\n\n"
+
+func parseSpec(data []byte) (*spec, error) {
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	for name, d := range s.Definitions {
+		if err := checkRefs(&s, d); err != nil {
+			return nil, fmt.Errorf("definition %s: %v", name, err)
+		}
+	}
+	for path, ops := range s.Paths {
+		for method, op := range ops {
+			if op.OperationID == "" {
+				return nil, fmt.Errorf("%s %s has no operationId", strings.ToUpper(method), path)
+			}
+			for _, p := range op.Parameters {
+				if err := checkRefs(&s, p.Schema); err != nil {
+					return nil, fmt.Errorf("%s: %v", op.OperationID, err)
+				}
+			}
+			if r := op.Responses["200"]; r != nil {
+				if err := checkRefs(&s, r.Schema); err != nil {
+					return nil, fmt.Errorf("%s: %v", op.OperationID, err)
+				}
+			}
+		}
+	}
+	return &s, nil
+}
+
+// checkRefs returns an error if sc refers to a definition which isn't in s.
+func checkRefs(s *spec, sc *schema) error {
+	if sc == nil {
+		return nil
+	}
+	if sc.Ref != "" {
+		if _, ok := s.Definitions[refName(sc.Ref)]; !ok {
+			return fmt.Errorf("unknown reference %s", sc.Ref)
+		}
+	}
+	for _, p := range sc.Properties {
+		if err := checkRefs(s, p); err != nil {
+			return err
+		}
+	}
+	if err := checkRefs(s, sc.Items); err != nil {
+		return err
+	}
+	return checkRefs(s, sc.AdditionalProperties)
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/definitions/")
+}
+
+// generateModels returns a struct type for every definition in s.
+func generateModels(s *spec, pkg string) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	fmt.Fprintf(&b, "package %s\n", pkg)
+
+	for _, name := range sortedKeys(s.Definitions) {
+		d := s.Definitions[name]
+		typ := goName(name)
+		b.WriteString("\n")
+		doc := fmt.Sprintf("%s is the %s model of the Singularity API.", typ, name)
+		if d.Description != "" {
+			doc += " " + sentence(d.Description)
+		}
+		writeComment(&b, "", doc)
+		fmt.Fprintf(&b, "type %s struct {\n", typ)
+
+		required := map[string]bool{}
+		for _, r := range d.Required {
+			required[r] = true
+		}
+		for _, prop := range sortedKeys(d.Properties) {
+			p := d.Properties[prop]
+			doc := sentence(p.Description)
+			if len(p.Enum) > 0 {
+				doc = strings.TrimSpace(doc + " Allowable values: " + strings.Join(p.Enum, ", ") + ".")
+			}
+			writeComment(&b, "\t", doc)
+			tag := prop
+			if !required[prop] {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", goName(prop), goType(p, true), tag)
+		}
+		b.WriteString("}\n")
+	}
+	return gofmt(b.Bytes())
+}
+
+// generateEndpoints returns a Client method for every operation in s.
+func generateEndpoints(s *spec, pkg string) ([]byte, error) {
+	type endpoint struct {
+		method, path string
+		op           *operation
+	}
+	var endpoints []endpoint
+	usesURL := false
+	for path, ops := range s.Paths {
+		for method, op := range ops {
+			endpoints = append(endpoints, endpoint{strings.ToUpper(method), path, op})
+			for _, p := range op.Parameters {
+				usesURL = usesURL || p.In == "path"
+			}
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return goName(endpoints[i].op.OperationID) < goName(endpoints[j].op.OperationID)
+	})
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", pkg)
+	if usesURL {
+		b.WriteString("\t\"net/url\"\n\n")
+	}
+	b.WriteString("\t\"github.com/go-resty/resty\"\n)\n")
+
+	for _, e := range endpoints {
+		name := goName(e.op.OperationID)
+		var args, query []string
+		body := "nil"
+		for _, p := range e.op.Parameters {
+			switch p.In {
+			case "path":
+				args = append(args, argName(p.Name)+" string")
+			case "body":
+				args = append(args, "body "+goType(p.Schema, true))
+				body = "body"
+			case "query":
+				q := p.Name
+				if p.Description != "" {
+					q += " (" + strings.TrimSuffix(lowerFirst(p.Description), ".") + ")"
+				}
+				query = append(query, q)
+			}
+		}
+		queryArg := "nil"
+		if len(query) > 0 {
+			args = append(args, "query map[string]string")
+			queryArg = "query"
+		}
+
+		var out *schema
+		if r := e.op.Responses["200"]; r != nil {
+			out = r.Schema
+		}
+
+		b.WriteString("\n")
+		doc := fmt.Sprintf("%s sends %s %s. %s", name, e.method, e.path, sentence(e.op.Summary))
+		if len(query) > 0 {
+			doc += " query may set " + strings.Join(query, ", ") + "."
+		}
+		writeComment(&b, "", doc)
+		if out == nil {
+			fmt.Fprintf(&b, "func (c *Client) %s(%s) (*resty.Response, error) {\n", name, strings.Join(args, ", "))
+			fmt.Fprintf(&b, "\treturn c.Do(%q, %s, %s, %s, nil)\n}\n", e.method, pathExpr(e.path), queryArg, body)
+			continue
+		}
+		typ := goType(out, false)
+		result := "&out"
+		if out.Ref != "" {
+			typ = "*" + typ
+		} else {
+			result = "out"
+		}
+		fmt.Fprintf(&b, "func (c *Client) %s(%s) (*resty.Response, %s, error) {\n", name, strings.Join(args, ", "), typ)
+		fmt.Fprintf(&b, "\tvar out %s\n", goType(out, false))
+		fmt.Fprintf(&b, "\tres, err := c.Do(%q, %s, %s, %s, &out)\n", e.method, pathExpr(e.path), queryArg, body)
+		fmt.Fprintf(&b, "\tif err != nil {\n\t\treturn res, nil, err\n\t}\n\treturn res, %s, nil\n}\n", result)
+	}
+	return gofmt(b.Bytes())
+}
+
+// goType returns the Go type of sc. References are pointers when ref is
+// true, so that a missing object can be told apart from an empty one.
+func goType(sc *schema, ref bool) string {
+	if sc.Ref != "" {
+		if ref {
+			return "*" + goName(refName(sc.Ref))
+		}
+		return goName(refName(sc.Ref))
+	}
+	switch sc.Type {
+	case "array":
+		return "[]" + goType(sc.Items, false)
+	case "object":
+		if sc.AdditionalProperties == nil {
+			return "map[string]interface{}"
+		}
+		return "map[string]" + goType(sc.AdditionalProperties, false)
+	case "integer":
+		if sc.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "string":
+		return "string"
+	}
+	return "interface{}"
+}
+
+// pathExpr returns a Go expression for path, with its parameters escaped.
+func pathExpr(path string) string {
+	var parts []string
+	for path != "" {
+		i := strings.Index(path, "{")
+		if i < 0 {
+			parts = append(parts, fmt.Sprintf("%q", path))
+			break
+		}
+		j := strings.Index(path[i:], "}") + i
+		if i > 0 {
+			parts = append(parts, fmt.Sprintf("%q", path[:i]))
+		}
+		parts = append(parts, "url.PathEscape("+argName(path[i+1:j])+")")
+		path = path[j+1:]
+	}
+	return strings.Join(parts, " + ")
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]bool{
+	"API": true, "HTTP": true, "ID": true, "JSON": true, "URI": true, "URL": true,
+}
+
+// goName returns the exported Go name of a Swagger name, such as RequestID
+// for requestId.
+func goName(name string) string {
+	var b bytes.Buffer
+	for _, w := range words(name) {
+		if initialisms[strings.ToUpper(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// argName returns the unexported Go name of a Swagger name.
+func argName(name string) string {
+	n := goName(name)
+	for _, w := range words(name)[:1] {
+		if initialisms[strings.ToUpper(w)] {
+			return strings.ToLower(w) + n[len(w):]
+		}
+	}
+	return lowerFirst(n)
+}
+
+// words splits a camel case name, keeping runs of upper case letters, such
+// as the API in expiringAPIRequestObject, together.
+func words(name string) []string {
+	var ws []string
+	start := 0
+	r := []rune(name)
+	for i := 1; i < len(r); i++ {
+		lowerToUpper := unicode.IsLower(r[i-1]) && unicode.IsUpper(r[i])
+		acronymEnd := i+1 < len(r) && unicode.IsUpper(r[i-1]) && unicode.IsUpper(r[i]) && unicode.IsLower(r[i+1])
+		if lowerToUpper || acronymEnd {
+			ws = append(ws, string(r[start:i]))
+			start = i
+		}
+	}
+	return append(ws, string(r[start:]))
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// sentence returns s with a full stop.
+func sentence(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// writeComment writes text as a comment wrapped at 80 columns.
+func writeComment(b *bytes.Buffer, indent, text string) {
+	if text == "" {
+		return
+	}
+	line := indent + "//"
+	for _, w := range strings.Fields(text) {
+		if len(line)+1+len(w) > 80 && line != indent+"//" {
+			b.WriteString(line + "\n")
+			line = indent + "//"
+		}
+		line += " " + w
+	}
+	b.WriteString(line + "\n")
+}
+
+func sortedKeys(m map[string]*schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func gofmt(src []byte) ([]byte, error) {
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %v\n%s", err, src)
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedFilesUpToDate fails when api/swagger.json was changed
+// without running go generate.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "api")
+	data, err := ioutil.ReadFile(filepath.Join(dir, "swagger.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := parseSpec(data)
+	if err != nil {
+		t.Fatalf("parseSpec: unexpected error %v", err)
+	}
+	models, err := generateModels(s, "api")
+	if err != nil {
+		t.Fatalf("generateModels: unexpected error %v", err)
+	}
+	endpoints, err := generateEndpoints(s, "api")
+	if err != nil {
+		t.Fatalf("generateEndpoints: unexpected error %v", err)
+	}
+
+	tests := []struct {
+		file      string
+		generated []byte
+	}{
+		{"models_gen.go", models},
+		{"endpoints_gen.go", endpoints},
+	}
+	for _, tt := range tests {
+		existing, err := ioutil.ReadFile(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(existing, tt.generated) {
+			t.Errorf("%s: out of date, run go generate in api", tt.file)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		arg      string
+	}{
+		{"id", "ID", "id"},
+		{"requestId", "RequestID", "requestID"},
+		{"uri", "URI", "uri"},
+		{"expiringAPIRequestObject", "ExpiringAPIRequestObject", "expiringAPIRequestObject"},
+		{"LoadBalancerRequestId", "LoadBalancerRequestID", "loadBalancerRequestID"},
+		{"s3Bucket", "S3Bucket", "s3Bucket"},
+		{"getRequests", "GetRequests", "getRequests"},
+	}
+	for _, tt := range tests {
+		if got := goName(tt.name); got != tt.expected {
+			t.Errorf("goName(%s): expected %s, got %s", tt.name, tt.expected, got)
+		}
+		if got := argName(tt.name); got != tt.arg {
+			t.Errorf("argName(%s): expected %s, got %s", tt.name, tt.arg, got)
+		}
+	}
+}
+
+func TestPathExpr(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/requests", `"/api/requests"`},
+		{"/api/requests/request/{requestId}", `"/api/requests/request/" + url.PathEscape(requestID)`},
+		{"/api/deploys/deploy/{deployId}/request/{requestId}",
+			`"/api/deploys/deploy/" + url.PathEscape(deployID) + "/request/" + url.PathEscape(requestID)`},
+	}
+	for _, tt := range tests {
+		if got := pathExpr(tt.path); got != tt.expected {
+			t.Errorf("pathExpr(%s): expected %s, got %s", tt.path, tt.expected, got)
+		}
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		schema   schema
+		expected string
+	}{
+		{schema{Type: "integer", Format: "int64"}, "int64"},
+		{schema{Type: "integer", Format: "int32"}, "int"},
+		{schema{Type: "number", Format: "double"}, "float64"},
+		{schema{Ref: "#/definitions/SingularityTaskId"}, "*SingularityTaskID"},
+		{schema{Type: "array", Items: &schema{Ref: "#/definitions/SingularityVolume"}}, "[]SingularityVolume"},
+		{schema{Type: "object", AdditionalProperties: &schema{Type: "object", AdditionalProperties: &schema{Type: "string"}}}, "map[string]map[string]string"},
+		{schema{Type: "object"}, "map[string]interface{}"},
+	}
+	for _, tt := range tests {
+		if got := goType(&tt.schema, true); got != tt.expected {
+			t.Errorf("goType(%+v): expected %s, got %s", tt.schema, tt.expected, got)
+		}
+	}
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{`{"definitions": {"A": {"properties": {"b": {"$ref": "#/definitions/B"}}}}}`, "unknown reference"},
+		{`{"paths": {"/api/requests": {"get": {}}}}`, "no operationId"},
+		{`{"paths": {"/api/requests": {"get": {"operationId": "getRequests", "responses": {"200": {"schema": {"$ref": "#/definitions/X"}}}}}}}`, "unknown reference"},
+	}
+	for _, tt := range tests {
+		_, err := parseSpec([]byte(tt.spec))
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("parseSpec(%s): expected error containing %q, got %v", tt.spec, tt.expected, err)
+		}
+	}
+}
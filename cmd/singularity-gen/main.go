@@ -0,0 +1,49 @@
+// Command singularity-gen generates Go model types and endpoint functions
+// from a Swagger 2.0 description of the Singularity API. It is run by go
+// generate in the api package:
+//
+//	singularity-gen -spec swagger.json -package api -models models_gen.go -endpoints endpoints_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	spec := flag.String("spec", "swagger.json", "Swagger 2.0 description to read")
+	pkg := flag.String("package", "api", "package name of the generated files")
+	models := flag.String("models", "models_gen.go", "file to write the model types to")
+	endpoints := flag.String("endpoints", "endpoints_gen.go", "file to write the endpoint functions to")
+	flag.Parse()
+
+	if err := run(*spec, *pkg, *models, *endpoints); err != nil {
+		fmt.Fprintf(os.Stderr, "singularity-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specFile, pkg, modelsFile, endpointsFile string) error {
+	data, err := ioutil.ReadFile(specFile)
+	if err != nil {
+		return err
+	}
+	s, err := parseSpec(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", specFile, err)
+	}
+	models, err := generateModels(s, pkg)
+	if err != nil {
+		return err
+	}
+	endpoints, err := generateEndpoints(s, pkg)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(modelsFile, models, 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(endpointsFile, endpoints, 0644)
+}
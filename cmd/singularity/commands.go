@@ -0,0 +1,618 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// parse parses a command's flags and checks it got between min and max
+// positional arguments. A negative max allows any number.
+func parse(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() < min || (max >= 0 && fs.NArg() > max) {
+		fs.Usage()
+		return nil, fmt.Errorf("%s: wrong number of arguments", fs.Name())
+	}
+	return fs.Args(), nil
+}
+
+func requestsCmd(e *env, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("requests: expected list, get, create or delete")
+	}
+	switch args[0] {
+	case "list":
+		return requestsListCmd(e, args[1:])
+	case "get":
+		return requestsGetCmd(e, args[1:])
+	case "create":
+		return requestsCreateCmd(e, args[1:])
+	case "delete":
+		return requestsDeleteCmd(e, args[1:])
+	}
+	return fmt.Errorf("requests: unknown command %q, expected list, get, create or delete", args[0])
+}
+
+func requestsListCmd(e *env, args []string) error {
+	if _, err := parse(e.flags("requests list", ""), args, 0, 0); err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	_, reqs, err := c.GetRequests()
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"ID", "TYPE", "STATE", "INSTANCES", "DEPLOY"}}
+	for _, r := range reqs {
+		t.add(r.SingularityRequest.ID, r.RequestType.String(), r.State.String(),
+			strconv.FormatInt(r.Instances, 10), r.RequestDeployState.ActiveDeploy.DeployID)
+	}
+	return e.out.print(reqs, t)
+}
+
+func requestsGetCmd(e *env, args []string) error {
+	args, err := parse(e.flags("requests get", "ID"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := c.GetRequestByID(args[0])
+	if err != nil {
+		return err
+	}
+	if res.RestyResponse.StatusCode() == http.StatusNotFound {
+		return fmt.Errorf("request %s not found", args[0])
+	}
+	r := res.Body
+	t := &table{header: []string{"ID", "TYPE", "STATE", "INSTANCES", "DEPLOY", "PENDING DEPLOY"}}
+	t.add(r.SingularityRequest.ID, r.RequestType.String(), r.State.String(), strconv.FormatInt(r.Instances, 10),
+		r.RequestDeployState.ActiveDeploy.DeployID, r.RequestDeployState.PendingDeployState.DeployID)
+	return e.out.print(r, t)
+}
+
+func requestsCreateCmd(e *env, args []string) error {
+	fs := e.flags("requests create", "-f FILE")
+	file := fs.String("f", "", "manifest `file`")
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("requests create: -f is required")
+	}
+	m, err := singularity.LoadManifest(*file)
+	if err != nil {
+		return err
+	}
+	r, err := m.BuildRequest()
+	if err != nil {
+		return err
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := r.Create(c)
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"ID", "TYPE", "STATE"}}
+	t.add(res.Body.SingularityRequest.ID, res.Body.RequestType.String(), res.Body.State.String())
+	return e.out.print(res.Body, t)
+}
+
+func requestsDeleteCmd(e *env, args []string) error {
+	fs := e.flags("requests delete", "ID")
+	msg := fs.String("m", "", "`message` to record with the action")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := singularity.DeleteRequest(c, singularity.NewDeleteRequest(args[0], *msg, "", false))
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"ID", "TYPE"}}
+	t.add(res.Response.ID, res.Response.RequestType.String())
+	return e.out.print(res.Response, t)
+}
+
+// printParent prints the request returned by an action on a request.
+func printParent(e *env, p singularity.SingularityRequestParent) error {
+	t := &table{header: []string{"ID", "TYPE", "STATE", "INSTANCES"}}
+	t.add(p.SingularityRequest.ID, p.SingularityRequest.RequestType.String(), p.State.String(),
+		strconv.FormatInt(p.SingularityRequest.Instances, 10))
+	return e.out.print(p, t)
+}
+
+func scaleCmd(e *env, args []string) error {
+	fs := e.flags("scale", "ID INSTANCES")
+	msg := fs.String("m", "", "`message` to record with the action")
+	duration := fs.Duration("duration", 0, "scale back to the current instance count after this long")
+	bounce := fs.Bool("bounce", false, "bounce the request after scaling it")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("scale: invalid instance count %q", args[1])
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	r := singularity.NewRequestScale(args[0], *msg, n, 0).SetDuration(*duration).SetBounce(*bounce)
+	res, err := singularity.ScaleRequest(c, *r)
+	if err != nil {
+		return err
+	}
+	return printParent(e, res.RequestParent)
+}
+
+func deployCmd(e *env, args []string) error {
+	fs := e.flags("deploy", "-f FILE")
+	file := fs.String("f", "", "manifest `file`")
+	msg := fs.String("m", "", "`message` to record with the deploy")
+	update := fs.Bool("update-request", false, "also update the request from the manifest")
+	wait := fs.Bool("wait", false, "wait for the deploy to finish")
+	interval := fs.Duration("interval", 5*time.Second, "how often to check the deploy when waiting")
+	timeout := fs.Duration("timeout", 0, "give up waiting after this long (default forever)")
+	rollback := fs.Bool("rollback", false, "with -wait, roll back to the previous deploy if the deploy fails")
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("deploy: -f is required")
+	}
+	m, err := singularity.LoadManifest(*file)
+	if err != nil {
+		return err
+	}
+	d, err := m.BuildDeploy()
+	if err != nil {
+		return err
+	}
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	dr := singularity.NewDeployRequest().AttachDeploy(d).SetMessage(*msg)
+	if *update {
+		r, err := m.BuildRequest()
+		if err != nil {
+			return err
+		}
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		dr.AttachRequest(singularity.Request{SingularityRequest: r.Get()})
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := dr.Create(c)
+	if err != nil {
+		return err
+	}
+	// Create reports a 409, an already pending deploy, as success.
+	if res.RestyResponse.StatusCode() == http.StatusConflict {
+		return fmt.Errorf("deploy %s: %s", d.Get().ID, res.RestyResponse.Body())
+	}
+	switch {
+	case !*wait:
+		return printParent(e, res.RequestParent)
+	case *rollback:
+		r, err := c.WaitForDeployOrRollback(m.Request.ID, d.Get().ID, *interval, *timeout)
+		if err != nil {
+			return err
+		}
+		return printRollback(e, r)
+	}
+	return waitDeploy(e, c, m.Request.ID, d.Get().ID, *interval, *timeout)
+}
+
+func waitCmd(e *env, args []string) error {
+	fs := e.flags("wait", "REQUEST_ID DEPLOY_ID")
+	interval := fs.Duration("interval", 5*time.Second, "how often to check the deploy")
+	timeout := fs.Duration("timeout", 0, "give up after this long (default forever)")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	return waitDeploy(e, c, args[0], args[1], *interval, *timeout)
+}
+
+func waitDeploy(e *env, c *singularity.Client, requestID, deployID string, interval, timeout time.Duration) error {
+	res, err := c.WaitForDeploy(requestID, deployID, interval, timeout)
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"REQUEST", "DEPLOY", "STATE", "MESSAGE"}}
+	t.add(requestID, deployID, res.DeployState.String(), res.Message)
+	if err := e.out.print(res, t); err != nil {
+		return err
+	}
+	if !res.Succeeded() {
+		return fmt.Errorf("deploy %s of request %s finished as %s", deployID, requestID, res.DeployState)
+	}
+	return nil
+}
+
+func rollbackCmd(e *env, args []string) error {
+	fs := e.flags("rollback", "ID")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	r, err := c.Rollback(args[0])
+	if err != nil {
+		return err
+	}
+	return printRollback(e, r)
+}
+
+func printRollback(e *env, r singularity.RollbackResult) error {
+	t := &table{header: []string{"REQUEST", "DEPLOY", "STATE", "MESSAGE"}}
+	if r.Result.DeployState != "" {
+		t.add(r.RequestID, r.DeployID, r.Result.DeployState.String(), r.Result.Message)
+	}
+	if r.RolledBack() {
+		t.add(r.RequestID, r.RollbackDeployID, r.RollbackResult.DeployState.String(), "rollback to "+r.PreviousDeployID)
+	}
+	if err := e.out.print(r, t); err != nil {
+		return err
+	}
+	switch {
+	case r.RolledBack() && !r.RollbackResult.Succeeded():
+		return fmt.Errorf("rollback %s of request %s finished as %s", r.RollbackDeployID, r.RequestID, r.RollbackResult.DeployState)
+	case r.Result.DeployState != "" && !r.Result.Succeeded():
+		return fmt.Errorf("deploy %s of request %s finished as %s and was rolled back", r.DeployID, r.RequestID, r.Result.DeployState)
+	}
+	return nil
+}
+
+// pairs is a repeatable KEY=VALUE flag.
+type pairs map[string]string
+
+func (p pairs) String() string {
+	return ""
+}
+
+func (p pairs) Set(s string) error {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("expected KEY=VALUE, got %q", s)
+	}
+	p[kv[0]] = kv[1]
+	return nil
+}
+
+func promoteCmd(e *env, args []string) error {
+	fs := e.flags("promote", "-to CLUSTER ID")
+	to := fs.String("to", "", "`cluster` from the profile file to promote to")
+	deployID := fs.String("deploy-id", "", "`id` of the new deploy (default generated)")
+	dryRun := fs.Bool("dry-run", false, "show what would change without deploying")
+	p := singularity.Promotion{Env: pairs{}, Labels: pairs{}}
+	fs.Var(pairs(p.Env), "env", "set an environment variable, as `KEY=VALUE` (repeatable)")
+	fs.Var(pairs(p.Labels), "label", "set a label, as `KEY=VALUE` (repeatable)")
+	fs.Int64Var(&p.Instances, "instances", 0, "number of instances (default unchanged)")
+	fs.Float64Var(&p.Resources.Cpus, "cpus", 0, "CPUs per task (default unchanged)")
+	fs.Float64Var(&p.Resources.MemoryMb, "memory", 0, "memory per task in `MB` (default unchanged)")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *to == "" {
+		return fmt.Errorf("promote: -to is required")
+	}
+	prof, err := loadProfile(e.opts.Config, true)
+	if err != nil {
+		return err
+	}
+	target, ok := prof.Clusters[*to]
+	if !ok {
+		return fmt.Errorf("cluster %q not found in %s", *to, e.opts.Config)
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	p.RequestID = args[0]
+	p.DeployID = *deployID
+	p.DryRun = *dryRun
+
+	res, err := singularity.Promote(c, target.client(), p)
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"CHANGE"}}
+	for _, d := range res.Diff {
+		t.add(d)
+	}
+	if !res.Created {
+		t.add("dry run: deploy " + res.Deploy.ID + " was not created")
+	}
+	return e.out.print(res, t)
+}
+
+func scheduleCmd(e *env, args []string) error {
+	fs := e.flags("schedule", "[-f FILE | ID]")
+	file := fs.String("f", "", "preview the request in a manifest `file` instead")
+	n := fs.Int("n", 5, "number of runs to show")
+	args, err := parse(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	if (*file == "") == (len(args) == 0) {
+		fs.Usage()
+		return fmt.Errorf("expected either -f FILE or a request ID")
+	}
+
+	var r singularity.SingularityRequest
+	if *file != "" {
+		m, err := singularity.LoadManifest(*file)
+		if err != nil {
+			return err
+		}
+		sr, err := m.BuildRequest()
+		if err != nil {
+			return err
+		}
+		r = sr.Get()
+	} else {
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+		res, err := c.GetRequestByID(args[0])
+		if err != nil {
+			return err
+		}
+		if res.RestyResponse.StatusCode() == http.StatusNotFound {
+			return fmt.Errorf("request %s not found", args[0])
+		}
+		r = res.Body.SingularityRequest
+	}
+
+	runs, err := r.NextRuns(*n, time.Now())
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"RUN"}}
+	for _, run := range runs {
+		t.add(run.Format(time.RFC3339))
+	}
+	return e.out.print(runs, t)
+}
+
+func pauseCmd(e *env, args []string) error {
+	fs := e.flags("pause", "ID")
+	msg := fs.String("m", "", "`message` to record with the action")
+	kill := fs.Bool("kill", false, "kill running tasks instead of letting them finish")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := singularity.PauseRequest(c, singularity.NewPauseRequest(args[0], *msg, *kill))
+	if err != nil {
+		return err
+	}
+	return printParent(e, res.RequestParent)
+}
+
+func unpauseCmd(e *env, args []string) error {
+	fs := e.flags("unpause", "ID")
+	msg := fs.String("m", "", "`message` to record with the action")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := singularity.UnpauseRequest(c, singularity.NewUnpauseRequest(args[0], *msg))
+	if err != nil {
+		return err
+	}
+	return printParent(e, res.RequestParent)
+}
+
+func bounceCmd(e *env, args []string) error {
+	fs := e.flags("bounce", "ID")
+	msg := fs.String("m", "", "`message` to record with the action")
+	incremental := fs.Bool("incremental", false, "replace tasks one at a time")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := singularity.BounceRequest(c, singularity.NewBounceRequest(args[0], *msg, *incremental))
+	if err != nil {
+		return err
+	}
+	return printParent(e, res.RequestParent)
+}
+
+func runCmd(e *env, args []string) error {
+	fs := e.flags("run", "ID [ARGS...]")
+	msg := fs.String("m", "", "`message` to record with the action")
+	args, err := parse(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := singularity.RunRequest(c, singularity.NewRunRequest(args[0], *msg, args[1:]...))
+	if err != nil {
+		return err
+	}
+	return printParent(e, res.RequestParent)
+}
+
+func tasksCmd(e *env, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("tasks: expected list or kill")
+	}
+	switch args[0] {
+	case "list":
+		return tasksListCmd(e, args[1:])
+	case "kill":
+		return tasksKillCmd(e, args[1:])
+	}
+	return fmt.Errorf("tasks: unknown command %q, expected list or kill", args[0])
+}
+
+func tasksListCmd(e *env, args []string) error {
+	args, err := parse(e.flags("tasks list", "[REQUEST_ID]"), args, 0, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		_, tasks, err := c.GetActiveTasksByRequestID(args[0])
+		if err != nil {
+			return err
+		}
+		t := &table{header: []string{"TASK", "DEPLOY", "HOST", "STATE", "UPDATED"}}
+		for _, h := range tasks {
+			t.add(h.TaskID.ID, h.TaskID.DeployID, h.TaskID.Host, h.LastTaskState, formatMillis(h.UpdatedAt))
+		}
+		return e.out.print(tasks, t)
+	}
+
+	_, tasks, err := c.GetActiveTasks()
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"TASK", "REQUEST", "DEPLOY", "HOST", "STARTED"}}
+	for _, task := range tasks {
+		id := task.TaskID
+		t.add(id.ID, id.RequestID, id.DeployID, id.Host, formatMillis(id.StartedAt))
+	}
+	return e.out.print(tasks, t)
+}
+
+func tasksKillCmd(e *env, args []string) error {
+	fs := e.flags("tasks kill", "TASK_ID")
+	msg := fs.String("m", "", "`message` to record with the action")
+	override := fs.Bool("override", false, "kill immediately instead of shutting down gracefully")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := singularity.KillTask(c, singularity.NewKillTask(args[0], *msg, *override))
+	if err != nil {
+		return err
+	}
+	cleanup, _ := res.Task.(singularity.SingularityTaskCleanup)
+	t := &table{header: []string{"TASK", "CLEANUP", "USER"}}
+	t.add(cleanup.TaskID.ID, cleanup.CleanupType, cleanup.User)
+	return e.out.print(cleanup, t)
+}
+
+// logChunkSize is how much of a log file is requested at a time.
+const logChunkSize = 64 * 1024
+
+func logsCmd(e *env, args []string) error {
+	fs := e.flags("logs", "TASK_ID")
+	path := fs.String("path", "stdout", "`file` in the task sandbox")
+	follow := fs.Bool("f", false, "keep printing the log as it grows")
+	last := fs.Int64("n", 4096, "start this many `bytes` from the end of the file, -1 for the beginning")
+	interval := fs.Duration("interval", 2*time.Second, "how often to check for more output with -f")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	return tailLog(c, e.stdout, args[0], *path, *last, *follow, *interval)
+}
+
+// tailLog writes a sandbox file to w starting last bytes from its end, and
+// keeps polling for more if follow is set.
+func tailLog(c *singularity.Client, w io.Writer, taskID, path string, last int64, follow bool, interval time.Duration) error {
+	var offset int64
+	if last >= 0 {
+		size, err := c.ReadSandboxFile(taskID, path, -1, 0)
+		if err != nil {
+			return err
+		}
+		if offset = size.Offset - last; offset < 0 {
+			offset = 0
+		}
+	}
+	for {
+		chunk, err := c.ReadSandboxFile(taskID, path, offset, logChunkSize)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, chunk.Data); err != nil {
+			return err
+		}
+		offset += int64(len(chunk.Data))
+		if len(chunk.Data) == logChunkSize {
+			continue
+		}
+		if !follow {
+			return nil
+		}
+		time.Sleep(interval)
+	}
+}
+
+// formatMillis formats milliseconds since the epoch.
+func formatMillis(ms singularity.EpochMillis) string {
+	if ms == 0 {
+		return ""
+	}
+	return ms.Time().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// profile is the content of the profile file, which lists the Singularity
+// clusters this tool can talk to.
+//
+//	current: staging
+//	clusters:
+//	  staging:
+//	    host: singularity.staging.example.com/singularity
+//	    port: 443
+//	    retry: 3
+type profile struct {
+	Current  string             `yaml:"current"`
+	Clusters map[string]cluster `yaml:"clusters"`
+}
+
+// cluster contains the endpoint of a single Singularity cluster.
+type cluster struct {
+	Host  string `yaml:"host"`
+	Port  int    `yaml:"port"`
+	Retry int    `yaml:"retry"`
+}
+
+// options are the global command line flags. Empty values fall back to
+// environment variables and then to the profile file.
+type options struct {
+	Config  string
+	Cluster string
+	Host    string
+	Port    int
+	Retry   int
+	Output  string
+}
+
+// defaultProfilePath returns ~/.singularity.yaml.
+func defaultProfilePath(getenv func(string) string) string {
+	return filepath.Join(getenv("HOME"), ".singularity.yaml")
+}
+
+// loadProfile reads a profile file. A missing file is an empty profile
+// unless it was asked for explicitly.
+func loadProfile(path string, explicit bool) (profile, error) {
+	var p profile
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && !explicit {
+		return p, nil
+	}
+	if err != nil {
+		return p, fmt.Errorf("read profile: %v", err)
+	}
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return p, fmt.Errorf("parse profile %s: %v", path, err)
+	}
+	return p, nil
+}
+
+// resolve merges flags, environment variables and the profile, in that order
+// of precedence, into the options and cluster to use.
+func resolve(o options, getenv func(string) string) (options, cluster, error) {
+	if o.Config == "" {
+		o.Config = getenv("SINGULARITY_CONFIG")
+	}
+	explicit := o.Config != ""
+	if !explicit {
+		o.Config = defaultProfilePath(getenv)
+	}
+	if o.Cluster == "" {
+		o.Cluster = getenv("SINGULARITY_CLUSTER")
+	}
+	if o.Host == "" {
+		o.Host = getenv("SINGULARITY_HOST")
+	}
+	if o.Output == "" {
+		o.Output = getenv("SINGULARITY_OUTPUT")
+	}
+	if o.Output == "" {
+		o.Output = "table"
+	}
+	var err error
+	if o.Port == 0 && getenv("SINGULARITY_PORT") != "" {
+		if o.Port, err = strconv.Atoi(getenv("SINGULARITY_PORT")); err != nil {
+			return o, cluster{}, fmt.Errorf("invalid SINGULARITY_PORT: %v", err)
+		}
+	}
+	if o.Retry == 0 && getenv("SINGULARITY_RETRY") != "" {
+		if o.Retry, err = strconv.Atoi(getenv("SINGULARITY_RETRY")); err != nil {
+			return o, cluster{}, fmt.Errorf("invalid SINGULARITY_RETRY: %v", err)
+		}
+	}
+
+	var c cluster
+	if o.Host == "" || o.Cluster != "" {
+		p, err := loadProfile(o.Config, explicit)
+		if err != nil {
+			return o, c, err
+		}
+		name := o.Cluster
+		if name == "" {
+			name = p.Current
+		}
+		if name != "" {
+			var ok bool
+			if c, ok = p.Clusters[name]; !ok {
+				return o, c, fmt.Errorf("cluster %q not found in %s", name, o.Config)
+			}
+		}
+	}
+	if o.Host != "" {
+		c.Host = o.Host
+	}
+	if o.Port != 0 {
+		c.Port = o.Port
+	}
+	if o.Retry != 0 {
+		c.Retry = o.Retry
+	}
+	if c.Host == "" {
+		return o, c, fmt.Errorf("no Singularity host: use -host, SINGULARITY_HOST or a cluster in %s", o.Config)
+	}
+	return o, c, nil
+}
+
+// client returns a Singularity client for this cluster.
+func (c cluster) client() *singularity.Client {
+	return singularity.NewClient(singularity.NewConfig().
+		SetHost(c.Host).
+		SetPort(c.Port).
+		SetRetry(c.Retry).
+		Build())
+}
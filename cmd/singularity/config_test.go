@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProfile = `current: staging
+clusters:
+  staging:
+    host: staging.example.com
+    port: 8080
+    retry: 2
+  production:
+    host: production.example.com
+    port: 443
+`
+
+func writeProfile(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "profile.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestResolve(t *testing.T) {
+	path, cleanup := writeProfile(t, testProfile)
+	defer cleanup()
+
+	var data = []struct {
+		name     string
+		opts     options
+		env      map[string]string
+		expected cluster
+		output   string
+	}{
+		{"current cluster", options{Config: path}, nil, cluster{Host: "staging.example.com", Port: 8080, Retry: 2}, "table"},
+		{"cluster flag", options{Config: path, Cluster: "production"}, nil, cluster{Host: "production.example.com", Port: 443}, "table"},
+		{"cluster env", options{}, map[string]string{"SINGULARITY_CONFIG": path, "SINGULARITY_CLUSTER": "production"}, cluster{Host: "production.example.com", Port: 443}, "table"},
+		{"env overrides profile", options{Config: path}, map[string]string{"SINGULARITY_PORT": "81", "SINGULARITY_OUTPUT": "json"}, cluster{Host: "staging.example.com", Port: 81, Retry: 2}, "json"},
+		{"flag overrides env", options{Config: path, Port: 82, Output: "yaml"}, map[string]string{"SINGULARITY_PORT": "81"}, cluster{Host: "staging.example.com", Port: 82, Retry: 2}, "yaml"},
+		{"host without profile", options{Config: filepath.Join(path, "missing")}, map[string]string{"SINGULARITY_HOST": "localhost"}, cluster{Host: "localhost"}, "table"},
+	}
+
+	for _, tt := range data {
+		o, c, err := resolve(tt.opts, func(k string) string { return tt.env[k] })
+		if err != nil {
+			t.Errorf("resolve(%s): unexpected error %v", tt.name, err)
+			continue
+		}
+		if c != tt.expected {
+			t.Errorf("resolve(%s): expected %+v, got %+v", tt.name, tt.expected, c)
+		}
+		if o.Output != tt.output {
+			t.Errorf("resolve(%s): expected output %s, got %s", tt.name, tt.output, o.Output)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	path, cleanup := writeProfile(t, testProfile)
+	defer cleanup()
+	bad, cleanupBad := writeProfile(t, "clusters:\n  a:\n    hots: x\n")
+	defer cleanupBad()
+
+	var data = []struct {
+		name          string
+		opts          options
+		env           map[string]string
+		expectedError string
+	}{
+		{"unknown cluster", options{Config: path, Cluster: "dev"}, nil, `cluster "dev" not found`},
+		{"missing explicit profile", options{Config: filepath.Join(path, "missing")}, nil, "read profile"},
+		{"invalid profile", options{Config: bad}, nil, "line 3: field hots not found"},
+		{"invalid port", options{Config: path}, map[string]string{"SINGULARITY_PORT": "http"}, "invalid SINGULARITY_PORT"},
+		{"no host", options{}, map[string]string{"HOME": filepath.Dir(path)}, "no Singularity host"},
+	}
+
+	for _, tt := range data {
+		_, _, err := resolve(tt.opts, func(k string) string { return tt.env[k] })
+		if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+			t.Errorf("resolve(%s): expected error containing %q, got %v", tt.name, tt.expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Command singularity manages Singularity requests, deploys and tasks from
+// the command line.
+//
+// The cluster to talk to is taken from the -host, -port and -retry flags,
+// then the SINGULARITY_HOST, SINGULARITY_PORT and SINGULARITY_RETRY
+// environment variables, then the cluster selected with -cluster (or
+// SINGULARITY_CLUSTER, or "current") in the profile file given by -config,
+// SINGULARITY_CONFIG or ~/.singularity.yaml.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+const usage = `Usage: singularity [flags] <command> [args]
+
+Commands:
+  requests list                 list requests
+  requests get ID               show a request
+  requests create -f FILE       create or update the request in a manifest
+  requests delete ID            delete a request
+  scale ID INSTANCES            scale a request
+  deploy -f FILE                deploy the deploy in a manifest
+  wait REQUEST_ID DEPLOY_ID     wait for a deploy to finish
+  rollback ID                   redeploy the deploy before the active one
+  promote -to CLUSTER ID        copy a request's active deploy to another cluster
+  schedule [-f FILE | ID]       show when a scheduled request runs next
+  pause ID                      pause a request
+  unpause ID                    unpause a request
+  bounce ID                     restart all tasks of a request
+  run ID [ARGS...]              run a request now
+  tasks list [REQUEST_ID]       list active tasks
+  tasks kill TASK_ID            kill a task
+  logs TASK_ID                  print a task's log
+
+Flags:
+`
+
+// env carries what a command needs to run.
+type env struct {
+	opts   options
+	out    *printer
+	stdout io.Writer
+	stderr io.Writer
+	// target is only used by commands which talk to Singularity, so that
+	// a missing host doesn't hide usage errors.
+	target    cluster
+	targetErr error
+}
+
+// client returns a Singularity client for the selected cluster.
+func (e *env) client() (*singularity.Client, error) {
+	if e.targetErr != nil {
+		return nil, e.targetErr
+	}
+	return e.target.client(), nil
+}
+
+// flags returns a flag set for a command.
+func (e *env) flags(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: singularity %s %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+type command func(e *env, args []string) error
+
+var commands = map[string]command{
+	"requests": requestsCmd,
+	"scale":    scaleCmd,
+	"deploy":   deployCmd,
+	"wait":     waitCmd,
+	"rollback": rollbackCmd,
+	"promote":  promoteCmd,
+	"schedule": scheduleCmd,
+	"pause":    pauseCmd,
+	"unpause":  unpauseCmd,
+	"bounce":   bounceCmd,
+	"run":      runCmd,
+	"tasks":    tasksCmd,
+	"logs":     logsCmd,
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr, os.Getenv); err != nil {
+		fmt.Fprintln(os.Stderr, "singularity:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer, getenv func(string) string) error {
+	fs := flag.NewFlagSet("singularity", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var o options
+	fs.StringVar(&o.Config, "config", "", "profile `file` listing clusters (default ~/.singularity.yaml)")
+	fs.StringVar(&o.Cluster, "cluster", "", "cluster `name` from the profile file")
+	fs.StringVar(&o.Host, "host", "", "Singularity `host`, including any path prefix")
+	fs.IntVar(&o.Port, "port", 0, "Singularity `port`")
+	fs.IntVar(&o.Retry, "retry", 0, "number of retries for failed HTTP requests")
+	fs.StringVar(&o.Output, "o", "", "output `format`: table, json or yaml (default table)")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no command given")
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		names := make([]string, 0, len(commands))
+		for n := range commands {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown command %q, expected one of %v", fs.Arg(0), names)
+	}
+
+	o, c, cerr := resolve(o, getenv)
+	p, err := newPrinter(stdout, o.Output)
+	if err != nil {
+		return err
+	}
+	e := &env{
+		opts:      o,
+		out:       p,
+		stdout:    stdout,
+		stderr:    stderr,
+		target:    c,
+		targetErr: cerr,
+	}
+	return cmd(e, fs.Args()[1:])
+}
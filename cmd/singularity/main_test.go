@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSingularity serves just enough of the Singularity API for the commands.
+func fakeSingularity(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/api/history/request/r1/deploy/d1_rb") {
+			w.Write([]byte(`{"deployResult":{"deployState":"SUCCEEDED"}}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/requests":
+			w.Write([]byte(`[{"request":{"id":"r1","requestType":"SERVICE","instances":2},"state":"ACTIVE",` +
+				`"requestDeployState":{"activeDeploy":{"deployId":"d1"}}}]`))
+		case "PUT /api/requests/request/r1/scale":
+			var body struct {
+				Instances      int   `json:"instances"`
+				DurationMillis int64 `json:"durationMillis"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Instances != 5 {
+				t.Errorf("scale: expected %d instances, got %d", 5, body.Instances)
+			}
+			if body.DurationMillis != 0 && body.DurationMillis != 3600000 {
+				t.Errorf("scale: expected no duration or 1h, got %dms", body.DurationMillis)
+			}
+			w.Write([]byte(`{"request":{"id":"r1","requestType":"SERVICE","instances":5},"state":"ACTIVE"}`))
+		case "POST /api/requests/request/r1/pause":
+			w.Write([]byte(`{"request":{"id":"r1","requestType":"SERVICE"},"state":"PAUSED"}`))
+		case "POST /api/deploys/":
+			var body struct {
+				Deploy struct {
+					RequestID string `json:"requestId"`
+				} `json:"deploy"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Deploy.RequestID == "busy" {
+				http.Error(w, "a deploy of busy is already pending", http.StatusConflict)
+				return
+			}
+			w.Write([]byte(`{"request":{"id":"r1","requestType":"SERVICE"},"state":"ACTIVE"}`))
+		case "GET /api/requests/request/r1":
+			w.Write([]byte(`{"request":{"id":"r1","requestType":"SERVICE"},"requestDeployState":{"activeDeploy":{"deployId":"d2"}}}`))
+		case "GET /api/requests/request/missing":
+			http.Error(w, "Couldn't find request with id missing", http.StatusNotFound)
+		case "GET /api/history/request/r1/deploys":
+			w.Write([]byte(`[{"deployMarker":{"deployId":"d2","timestamp":2},"deployResult":{"deployState":"SUCCEEDED"}},` +
+				`{"deployMarker":{"deployId":"d1","timestamp":1},"deployResult":{"deployState":"SUCCEEDED"}}]`))
+		case "GET /api/history/request/r1/deploy/d1":
+			w.Write([]byte(`{"deployMarker":{"deployId":"d1"},"deployResult":{"deployState":"SUCCEEDED"},` +
+				`"deploy":{"id":"d1","requestId":"r1","command":"./run"}}`))
+		case "GET /api/history/request/r1/deploy/d2":
+			w.Write([]byte(`{"deployResult":{"deployState":"SUCCEEDED"}}`))
+		case "GET /api/history/request/r1/deploy/d3":
+			w.Write([]byte(`{"deployResult":{"deployState":"FAILED","message":"unhealthy"}}`))
+		case "GET /api/sandbox/t1/read":
+			if r.URL.Query().Get("offset") == "" {
+				w.Write([]byte(`{"data":"","offset":11}`))
+				return
+			}
+			w.Write([]byte(`{"data":"world\n","offset":6}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func runCLI(ts *httptest.Server, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	getenv := func(k string) string {
+		if k == "SINGULARITY_HOST" {
+			return strings.TrimPrefix(ts.URL, "http://")
+		}
+		return ""
+	}
+	err := run(args, &stdout, &stderr, getenv)
+	return stdout.String(), err
+}
+
+func TestRun(t *testing.T) {
+	ts := fakeSingularity(t)
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	manifest := filepath.Join(dir, "r1.yaml")
+	ioutil.WriteFile(manifest, []byte("version: v1\nrequest:\n  id: r1\n  type: SERVICE\ndeploy:\n  id: d2\n  command: ./run\n"), 0644)
+	job := filepath.Join(dir, "job.yaml")
+	ioutil.WriteFile(job, []byte("version: v1\nrequest:\n  id: job\n  type: SCHEDULED\n  quartzSchedule: 0 30 9 ? * MON-FRI\n  scheduleTimeZone: Australia/Sydney\n"), 0644)
+
+	var data = []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"requests", "list"}, []string{"ID", "r1", "SERVICE", "ACTIVE", "d1"}},
+		{[]string{"-o", "json", "requests", "list"}, []string{`"id": "r1"`}},
+		{[]string{"-o", "yaml", "requests", "list"}, []string{"id: r1", "requestType: SERVICE"}},
+		{[]string{"scale", "r1", "5"}, []string{"r1", "5"}},
+		{[]string{"scale", "-duration", "1h", "r1", "5"}, []string{"r1", "5"}},
+		{[]string{"pause", "-kill", "r1"}, []string{"PAUSED"}},
+		{[]string{"deploy", "-f", manifest, "-wait", "-interval", "1ms"}, []string{"d2", "SUCCEEDED"}},
+		{[]string{"logs", "-n", "5", "t1"}, []string{"world"}},
+		{[]string{"rollback", "r1"}, []string{"d1_rb", "SUCCEEDED", "rollback to d1"}},
+		{[]string{"schedule", "-n", "3", "-f", job}, []string{"RUN", "T09:30:00+1"}},
+	}
+
+	for _, tt := range data {
+		out, err := runCLI(ts, tt.args...)
+		if err != nil {
+			t.Errorf("run(%v): unexpected error %v", tt.args, err)
+			continue
+		}
+		for _, e := range tt.expected {
+			if !strings.Contains(out, e) {
+				t.Errorf("run(%v): expected output containing %q, got %s", tt.args, e, out)
+			}
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	ts := fakeSingularity(t)
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	busy := filepath.Join(dir, "busy.yaml")
+	ioutil.WriteFile(busy, []byte("version: v1\nrequest:\n  id: busy\n  type: SERVICE\ndeploy:\n  id: d1\n  command: ./run\n"), 0644)
+
+	var data = []struct {
+		args          []string
+		expectedError string
+	}{
+		{[]string{}, "no command given"},
+		{[]string{"frobnicate"}, "unknown command"},
+		{[]string{"-o", "xml", "requests", "list"}, "unknown output format"},
+		{[]string{"requests"}, "expected list, get, create or delete"},
+		{[]string{"scale", "r1"}, "wrong number of arguments"},
+		{[]string{"scale", "r1", "many"}, "invalid instance count"},
+		{[]string{"deploy"}, "-f is required"},
+		{[]string{"deploy", "-f", busy}, "already pending"},
+		{[]string{"requests", "get", "missing"}, "request missing not found"},
+		{[]string{"schedule", "missing"}, "request missing not found"},
+		{[]string{"schedule"}, "expected either -f FILE or a request ID"},
+		{[]string{"wait", "-interval", "1ms", "r1", "d3"}, "finished as FAILED"},
+		{[]string{"rollback"}, "wrong number of arguments"},
+		{[]string{"promote", "r1"}, "-to is required"},
+	}
+
+	for _, tt := range data {
+		_, err := runCLI(ts, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+			t.Errorf("run(%v): expected error containing %q, got %v", tt.args, tt.expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// table is the tabular form of a command's result.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(cols ...string) {
+	t.rows = append(t.rows, cols)
+}
+
+// printer writes command results in the selected output format.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table", "json", "yaml":
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q: use table, json or yaml", format)
+}
+
+// print writes v as JSON or YAML, or t when the format is table.
+func (p *printer) print(v interface{}, t *table) error {
+	switch p.format {
+	case "json":
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(data))
+		return err
+	case "yaml":
+		// Go through JSON so the YAML keys match the API's field names.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		data, err = yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(data)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, r := range t.rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	return tw.Flush()
+}
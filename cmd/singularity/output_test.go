@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrinter(t *testing.T) {
+	v := []struct {
+		ID        string `json:"id"`
+		Instances int    `json:"instances"`
+	}{
+		{"a", 1},
+	}
+	tbl := &table{header: []string{"ID", "INSTANCES"}}
+	tbl.add("a", "1")
+
+	var data = []struct {
+		format   string
+		expected string
+	}{
+		{"table", "ID  INSTANCES\na   1\n"},
+		{"json", "[\n  {\n    \"id\": \"a\",\n    \"instances\": 1\n  }\n]\n"},
+		{"yaml", "- id: a\n  instances: 1\n"},
+	}
+
+	for _, tt := range data {
+		var buf bytes.Buffer
+		p, err := newPrinter(&buf, tt.format)
+		if err != nil {
+			t.Fatalf("newPrinter(%s): unexpected error %v", tt.format, err)
+		}
+		if err := p.print(v, tbl); err != nil {
+			t.Errorf("print(%s): unexpected error %v", tt.format, err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("print(%s): expected %q, got %q", tt.format, tt.expected, buf.String())
+		}
+	}
+
+	if _, err := newPrinter(&bytes.Buffer{}, "xml"); err == nil {
+		t.Errorf("newPrinter(xml): expected error")
+	}
+}
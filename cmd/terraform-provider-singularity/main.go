@@ -0,0 +1,14 @@
+// Command terraform-provider-singularity is the Terraform plugin for the
+// Singularity provider.
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/lenfree/go-mesos-singularity/provider"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		ProviderFunc: provider.Provider,
+	})
+}
@@ -0,0 +1,104 @@
+package singularity
+
+import "encoding/json"
+
+// NewDockerContainerInfo returns a DOCKER ContainerInfo which runs image.
+func NewDockerContainerInfo(image string) ContainerInfo {
+	return ContainerInfo{
+		Type:       "DOCKER",
+		DockerInfo: DockerInfo{Image: image},
+	}
+}
+
+// NewMesosContainerInfo returns a ContainerInfo for the Mesos containerizer.
+// image may be nil, to isolate the command without an image.
+func NewMesosContainerInfo(image *SingularityMesosImage) ContainerInfo {
+	c := ContainerInfo{Type: "MESOS"}
+	if image != nil {
+		c.Mesos = &SingularityMesosInfo{Image: image}
+	}
+	return c
+}
+
+// NewMesosDockerImage returns a Docker image for the Mesos containerizer.
+func NewMesosDockerImage(name string) *SingularityMesosImage {
+	return &SingularityMesosImage{
+		Type:   "DOCKER",
+		Docker: &SingularityDockerImage{Name: name},
+	}
+}
+
+// NewMesosAppcImage returns an AppC image for the Mesos containerizer. id is
+// optional.
+func NewMesosAppcImage(name, id string) *SingularityMesosImage {
+	return &SingularityMesosImage{
+		Type: "APPC",
+		Appc: &SingularityAppcImage{Name: name, ID: id},
+	}
+}
+
+// empty reports whether c describes no container at all.
+func (c ContainerInfo) empty() bool {
+	return c.Type == "" && len(c.Volumes) == 0 && c.Mesos == nil && c.DockerInfo.empty()
+}
+
+func (i DockerInfo) empty() bool {
+	return i.Image == "" && i.Network == "" && len(i.Parameters) == 0 &&
+		len(i.SingularityDockerParameters) == 0 && len(i.PortMappings) == 0 && !i.ForcePullImage && !i.Privileged
+}
+
+// MarshalJSON leaves out containerInfo for a deploy without a container, and
+// the docker settings of a container which doesn't use them, as Singularity
+// rejects a container without a type.
+func (d SingularityDeploy) MarshalJSON() ([]byte, error) {
+	// deploy has the fields of SingularityDeploy but not this method.
+	type deploy SingularityDeploy
+	data, err := json.Marshal(deploy(d))
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	c := d.ContainerInfo
+	if c.empty() {
+		delete(fields, "containerInfo")
+		return json.Marshal(fields)
+	}
+	container := struct {
+		Type    string                `json:"type"`
+		Docker  *DockerInfo           `json:"docker,omitempty"`
+		Volumes []SingularityVolume   `json:"volumes,omitempty"`
+		Mesos   *SingularityMesosInfo `json:"mesos,omitempty"`
+	}{
+		Type:    c.Type,
+		Volumes: c.Volumes,
+		Mesos:   c.Mesos,
+	}
+	if c.Type == "DOCKER" || !c.DockerInfo.empty() {
+		container.Docker = &c.DockerInfo
+	}
+	if fields["containerInfo"], err = json.Marshal(container); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// MarshalJSON encodes the deploy request. It is needed because
+// SingularityDeploy.MarshalJSON would otherwise be promoted to
+// SingularityDeployRequest, and encode the request as just its deploy.
+func (r SingularityDeployRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		UnpauseOnSuccessfulDeploy bool                `json:"unpauseOnSuccessfulDeploy,omitempty"`
+		Deploy                    SingularityDeploy   `json:"deploy"`
+		UpdatedRequest            *SingularityRequest `json:"updatedRequest,omitempty"`
+		Message                   string              `json:"message,omitempty"`
+	}{
+		UnpauseOnSuccessfulDeploy: r.UnpauseOnSuccessfulDeploy,
+		Deploy:                    r.SingularityDeploy,
+		UpdatedRequest:            r.SingularityRequest,
+		Message:                   r.Message,
+	})
+}
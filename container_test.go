@@ -0,0 +1,103 @@
+package singularity
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDeployMarshalJSON(t *testing.T) {
+	var data = []struct {
+		name     string
+		deploy   Deploy
+		expected interface{}
+	}{
+		{"no container", NewDeploy("d1").SetCommand("./run.sh"), nil},
+		{"docker", mustSetContainer(t, NewDeploy("d1"), NewDockerContainerInfo("golang:latest")),
+			map[string]interface{}{"type": "DOCKER", "docker": map[string]interface{}{"image": "golang:latest"}}},
+		{"mesos", mustSetContainer(t, NewDeploy("d1"), NewMesosContainerInfo(nil)),
+			map[string]interface{}{"type": "MESOS", "docker": nil}},
+		{"mesos docker image", mustSetContainer(t, NewDeploy("d1"), NewMesosContainerInfo(NewMesosDockerImage("golang:latest"))),
+			map[string]interface{}{"type": "MESOS", "mesos": map[string]interface{}{
+				"image": map[string]interface{}{"type": "DOCKER", "docker": map[string]interface{}{"name": "golang:latest"}},
+			}}},
+		{"mesos appc image", mustSetContainer(t, NewDeploy("d1"), NewMesosContainerInfo(NewMesosAppcImage("example.com/app", "sha512-1"))),
+			map[string]interface{}{"type": "MESOS", "mesos": map[string]interface{}{
+				"image": map[string]interface{}{"type": "APPC", "appc": map[string]interface{}{"name": "example.com/app", "id": "sha512-1"}},
+			}}},
+	}
+
+	for _, tt := range data {
+		// Deploys are posted inside a deploy request, so check both.
+		for _, v := range []interface{}{tt.deploy.Build(), NewDeployRequest().AttachDeploy(tt.deploy)} {
+			b, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("Marshal(%s): unexpected error %v", tt.name, err)
+			}
+			var got map[string]interface{}
+			json.Unmarshal(b, &got)
+			if d, ok := got["deploy"].(map[string]interface{}); ok {
+				got = d
+			}
+			if got["id"] != "d1" {
+				t.Errorf("Marshal(%s): expected deploy id d1, got %s", tt.name, b)
+			}
+			if !containsJSON(got["containerInfo"], tt.expected) {
+				t.Errorf("Marshal(%s): expected containerInfo %v, got %v", tt.name, tt.expected, got["containerInfo"])
+			}
+		}
+	}
+}
+
+func TestDeployRequestJSONRoundTrip(t *testing.T) {
+	d := mustSetContainer(t, NewDeploy("d1").SetRequestID("r1"), NewMesosContainerInfo(NewMesosDockerImage("golang:latest")))
+	r := NewDeployRequest().AttachDeploy(d).SetMessage("hello").Build()
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got SingularityDeployRequest
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(&got, r) {
+		t.Errorf("Unmarshal: expected %+v, got %+v", r, got)
+	}
+}
+
+func TestSetContainerInfoType(t *testing.T) {
+	if _, err := NewDeploy("d1").SetContainerInfo(ContainerInfo{Type: "RKT"}); err == nil {
+		t.Errorf("SetContainerInfo: expected error for unknown container type")
+	}
+	d := mustSetContainer(t, NewDeploy("d1"), NewDockerContainerInfo("golang:latest")).RemoveContainerInfo()
+	if d.Get().ContainerInfo.Type != "" {
+		t.Errorf("RemoveContainerInfo: expected no container, got %+v", d.Get().ContainerInfo)
+	}
+}
+
+func mustSetContainer(t *testing.T, d Deploy, c ContainerInfo) Deploy {
+	d, err := d.SetContainerInfo(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// containsJSON reports whether got has every field of expected, each decoded
+// from JSON.
+func containsJSON(got, expected interface{}) bool {
+	e, ok := expected.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(got, expected)
+	}
+	g, ok := got.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k, v := range e {
+		if !containsJSON(g[k], v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package singularity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-resty/resty"
+)
+
+// GetDeployHistory accepts a request id and a deploy id string and retrieves
+// that deploy with its result. DeployResult is nil while the deploy is pending.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apihistoryrequestrequestiddeploydeployid
+func (c *Client) GetDeployHistory(requestID, deployID string) (*resty.Response, SingularityDeployHistory, error) {
+	res, err := c.request().
+		Get("/api/history/request/" + requestID + "/deploy/" + deployID)
+	if err != nil {
+		return &resty.Response{}, SingularityDeployHistory{}, fmt.Errorf("Get Singularity deploy history error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return &resty.Response{}, SingularityDeployHistory{}, fmt.Errorf("Get Singularity deploy history error: %v", string(res.Body()))
+	}
+
+	var body SingularityDeployHistory
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, SingularityDeployHistory{}, fmt.Errorf("Parse Singularity deploy history error: %v", err)
+	}
+	return res, body, nil
+}
+
+// Succeeded returns true if this deploy finished successfully.
+func (r SingularityDeployResult) Succeeded() bool {
+	return r.DeployState == DeployStateSucceeded
+}
+
+// finished returns true once a deploy can no longer change state.
+func (r *SingularityDeployResult) finished() bool {
+	return r != nil && r.DeployState.Finished()
+}
+
+// WaitForDeploy accepts a request id and a deploy id string and polls the
+// deploy every interval until it finishes, then returns its result. Use
+// Succeeded on the result to check whether the deploy failed. A zero timeout
+// waits forever.
+func (c *Client) WaitForDeploy(requestID, deployID string, interval, timeout time.Duration) (SingularityDeployResult, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		_, h, err := c.GetDeployHistory(requestID, deployID)
+		if err != nil {
+			return SingularityDeployResult{}, err
+		}
+		if h.DeployResult.finished() {
+			return *h.DeployResult, nil
+		}
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return SingularityDeployResult{}, fmt.Errorf("Wait for Singularity deploy %s error: timed out after %v", deployID, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// UpdatePendingDeploy accepts a request id, a deploy id and an instance count
+// and sets the target instance count of the next step of that pending deploy.
+// This is how deploys with autoAdvanceDeploySteps false are advanced.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#put-apideploysupdate
+func (c *Client) UpdatePendingDeploy(requestID, deployID string, targetActiveInstances int) (HTTPResponse, error) {
+	res, err := c.request().
+		SetBody(SingularityUpdatePendingDeployRequest{
+			RequestID:             requestID,
+			DeployID:              deployID,
+			TargetActiveInstances: targetActiveInstances,
+		}).
+		Put("/api/deploys/update")
+	if err != nil {
+		return HTTPResponse{}, fmt.Errorf("Update Singularity pending deploy error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return HTTPResponse{}, fmt.Errorf("Update Singularity pending deploy error: %v", string(res.Body()))
+	}
+
+	var data SingularityRequestParent
+	err = c.Rest.JSONUnmarshal(res.Body(), &data)
+	if err != nil {
+		return HTTPResponse{}, fmt.Errorf("Parse Singularity pending deploy update error: %v", err)
+	}
+	return HTTPResponse{
+		RestyResponse: res,
+		RequestParent: data,
+	}, nil
+}
+
+// GetRequestParent accepts a request id and retrieves that request with the
+// state of its pending deploy, including the SingularityDeployProgress of the
+// current step.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apirequestsrequestrequestid
+func (c *Client) GetRequestParent(requestID string) (SingularityRequestParent, error) {
+	res, err := c.request().
+		Get("/api/requests/request/" + requestID)
+	if err != nil {
+		return SingularityRequestParent{}, fmt.Errorf("Get Singularity request error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return SingularityRequestParent{}, fmt.Errorf("Get Singularity request error: %v", string(res.Body()))
+	}
+
+	var data SingularityRequestParent
+	err = c.Rest.JSONUnmarshal(res.Body(), &data)
+	if err != nil {
+		return SingularityRequestParent{}, fmt.Errorf("Parse Singularity request error: %v", err)
+	}
+	return data, nil
+}
+
+// GetDeployHistories accepts a request id and retrieves the deploys of that
+// request with their results, newest first. Unlike GetDeployHistory, the
+// Deploy of each is not included.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apihistoryrequestrequestiddeploys
+func (c *Client) GetDeployHistories(requestID string) ([]SingularityDeployHistory, error) {
+	res, err := c.request().
+		Get("/api/history/request/" + requestID + "/deploys")
+	if err != nil {
+		return nil, fmt.Errorf("Get Singularity deploy histories error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return nil, fmt.Errorf("Get Singularity deploy histories error: %v", string(res.Body()))
+	}
+
+	var body []SingularityDeployHistory
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return nil, fmt.Errorf("Parse Singularity deploy histories error: %v", err)
+	}
+	sort.SliceStable(body, func(i, j int) bool {
+		return body[i].DeployMarker.Timestamp > body[j].DeployMarker.Timestamp
+	})
+	return body, nil
+}
+
+// GetPendingDeploys retrieves the deploys which have not finished, with
+// their current state and progress.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apideployspending
+func (c *Client) GetPendingDeploys() (*resty.Response, []SingularityPendingDeploy, error) {
+	res, err := c.request().
+		Get("/api/deploys/pending")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity pending deploys error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity pending deploys error: %v", string(res.Body()))
+	}
+
+	var body []SingularityPendingDeploy
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity pending deploys error: %v", err)
+	}
+	return res, body, nil
+}
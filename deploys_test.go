@@ -0,0 +1,65 @@
+package singularity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientWaitForDeploy(t *testing.T) {
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/history/request/r1/deploy/d1":
+			polls++
+			if polls < 3 {
+				w.Write([]byte(`{"deployMarker":{"requestId":"r1","deployId":"d1"}}`))
+				return
+			}
+			w.Write([]byte(`{"deployMarker":{"requestId":"r1","deployId":"d1"},"deployResult":{"deployState":"FAILED","message":"boom"}}`))
+		case "/api/history/request/r1/deploy/pending":
+			w.Write([]byte(`{"deployMarker":{"requestId":"r1","deployId":"pending"},"deployResult":{"deployState":"WAITING"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`not found`))
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	res, err := c.WaitForDeploy("r1", "d1", time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("WaitForDeploy: unexpected error %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("WaitForDeploy: expected %d polls, got %d", 3, polls)
+	}
+	if res.Succeeded() || res.DeployState != "FAILED" || res.Message != "boom" {
+		t.Errorf("WaitForDeploy: got %+v", res)
+	}
+
+	if _, err := c.WaitForDeploy("r1", "pending", time.Millisecond, 20*time.Millisecond); err == nil {
+		t.Errorf("WaitForDeploy: expected timeout error")
+	}
+
+	if _, err := c.WaitForDeploy("r1", "missing", time.Millisecond, 0); err == nil {
+		t.Errorf("WaitForDeploy: expected error for missing deploy")
+	}
+}
+
+func TestClientGetPendingDeploys(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/deploys/pending" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"currentDeployState":"WAITING","deployMarker":{"requestId":"r1","deployId":"d2"}}]`))
+	}))
+	defer ts.Close()
+
+	_, deploys, err := newTestClient(ts).GetPendingDeploys()
+	if err != nil || len(deploys) != 1 || deploys[0].CurrentDeployState != "WAITING" || deploys[0].SingularityDeployMarker.DeployID != "d2" {
+		t.Errorf("GetPendingDeploys: got %+v, %v", deploys, err)
+	}
+}
@@ -0,0 +1,153 @@
+package singularity
+
+// DockerNetwork is the networking mode of a Docker container.
+type DockerNetwork string
+
+// Docker networking modes.
+const (
+	DockerNetworkBridge DockerNetwork = "BRIDGE"
+	DockerNetworkHost   DockerNetwork = "HOST"
+	DockerNetworkNone   DockerNetwork = "NONE"
+)
+
+// PortType says how the port of a DockerPortMapping is given.
+type PortType string
+
+// Port types. A LITERAL port is a port number, and a FROM_OFFER port is an
+// index into the ports offered to the task, of which the deploy asks for
+// NumPorts.
+const (
+	PortLiteral   PortType = "LITERAL"
+	PortFromOffer PortType = "FROM_OFFER"
+)
+
+// VolumeMode says whether a volume is mounted read-only or read-write.
+type VolumeMode string
+
+// Volume modes.
+const (
+	VolumeRO VolumeMode = "RO"
+	VolumeRW VolumeMode = "RW"
+)
+
+// OfferPortMapping maps the offered host port at index offerIndex to the
+// literal containerPort.
+func OfferPortMapping(offerIndex, containerPort int) DockerPortMapping {
+	return DockerPortMapping{
+		HostPort:          offerIndex,
+		HostPortType:      string(PortFromOffer),
+		ContainerPort:     containerPort,
+		ContainerPortType: string(PortLiteral),
+		Protocol:          "tcp",
+	}
+}
+
+// LiteralPortMapping maps the literal hostPort to the literal containerPort.
+func LiteralPortMapping(hostPort, containerPort int) DockerPortMapping {
+	return DockerPortMapping{
+		HostPort:          hostPort,
+		HostPortType:      string(PortLiteral),
+		ContainerPort:     containerPort,
+		ContainerPortType: string(PortLiteral),
+		Protocol:          "tcp",
+	}
+}
+
+// UDP returns a copy of p which maps a UDP port rather than TCP.
+func (p DockerPortMapping) UDP() DockerPortMapping {
+	p.Protocol = "udp"
+	return p
+}
+
+// NewVolume returns a volume mounting hostPath at containerPath.
+func NewVolume(hostPath, containerPath string, mode VolumeMode) SingularityVolume {
+	return SingularityVolume{
+		HostPath:      hostPath,
+		ContainerPath: containerPath,
+		Mode:          string(mode),
+	}
+}
+
+// DockerBuilder builds the ContainerInfo of a Docker container.
+type DockerBuilder interface {
+	SetNetwork(DockerNetwork) DockerBuilder
+	SetForcePullImage(bool) DockerBuilder
+	SetPrivileged(bool) DockerBuilder
+	AddParameter(key, value string) DockerBuilder
+	AddPortMapping(...DockerPortMapping) DockerBuilder
+	AddVolume(...SingularityVolume) DockerBuilder
+	Build() ContainerInfo
+}
+
+type dockerBuilder struct {
+	info ContainerInfo
+}
+
+// NewDocker returns a DockerBuilder for a container running image.
+func NewDocker(image string) DockerBuilder {
+	return &dockerBuilder{info: NewDockerContainerInfo(image)}
+}
+
+// SetNetwork sets the networking mode. Port mappings need BRIDGE.
+func (b *dockerBuilder) SetNetwork(n DockerNetwork) DockerBuilder {
+	b.info.DockerInfo.Network = string(n)
+	return b
+}
+
+// SetForcePullImage pulls the image even if it is cached on the agent.
+func (b *dockerBuilder) SetForcePullImage(f bool) DockerBuilder {
+	b.info.DockerInfo.ForcePullImage = f
+	return b
+}
+
+// SetPrivileged runs the container in privileged mode.
+func (b *dockerBuilder) SetPrivileged(p bool) DockerBuilder {
+	b.info.DockerInfo.Privileged = p
+	return b
+}
+
+// AddParameter adds a docker run option, such as ("env-file", "/etc/app").
+// Parameters are kept as dockerParameters, rather than the older parameters
+// map, so a key may be given more than once.
+func (b *dockerBuilder) AddParameter(key, value string) DockerBuilder {
+	b.info.DockerInfo.SingularityDockerParameters = append(b.info.DockerInfo.SingularityDockerParameters,
+		SingularityDockerParameter{Key: key, Value: value})
+	return b
+}
+
+// AddPortMapping adds port mappings, such as from OfferPortMapping and
+// LiteralPortMapping.
+func (b *dockerBuilder) AddPortMapping(p ...DockerPortMapping) DockerBuilder {
+	b.info.DockerInfo.PortMappings = append(b.info.DockerInfo.PortMappings, p...)
+	return b
+}
+
+// AddVolume adds volumes, such as from NewVolume.
+func (b *dockerBuilder) AddVolume(v ...SingularityVolume) DockerBuilder {
+	b.info.Volumes = append(b.info.Volumes, v...)
+	return b
+}
+
+// Build returns the ContainerInfo. Use SingularityDeploy.SetDocker to check
+// it against the deploy's resources too.
+func (b *dockerBuilder) Build() ContainerInfo {
+	info := b.info
+	info.DockerInfo.PortMappings = append([]DockerPortMapping(nil), info.DockerInfo.PortMappings...)
+	info.DockerInfo.SingularityDockerParameters = append([]SingularityDockerParameter(nil), info.DockerInfo.SingularityDockerParameters...)
+	info.Volumes = append([]SingularityVolume(nil), info.Volumes...)
+	return info
+}
+
+// SetDocker accepts a DockerBuilder and runs this deploy in the container it
+// builds. The container is validated, and FROM_OFFER port mappings must use
+// indexes below the deploy's NumPorts, so set resources first.
+func (d *SingularityDeploy) SetDocker(b DockerBuilder) (Deploy, error) {
+	c := b.Build()
+	var e ValidationError
+	e.validateContainer(c, d.SingularityDeployResources.NumPorts)
+	if err := e.err(); err != nil {
+		return nil, err
+	}
+	d.ContainerInfo = c
+	return d, nil
+}
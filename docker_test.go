@@ -0,0 +1,78 @@
+package singularity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDockerBuilder(t *testing.T) {
+	c := NewDocker("golang:latest").
+		SetNetwork(DockerNetworkBridge).
+		SetForcePullImage(true).
+		AddParameter("env", "A=1").
+		AddParameter("env", "B=2").
+		AddPortMapping(OfferPortMapping(0, 8080), LiteralPortMapping(53, 53).UDP()).
+		AddVolume(NewVolume("/etc/app", "/etc/app", VolumeRO)).
+		Build()
+
+	expected := ContainerInfo{
+		Type: "DOCKER",
+		DockerInfo: DockerInfo{
+			Image:          "golang:latest",
+			Network:        "BRIDGE",
+			ForcePullImage: true,
+			SingularityDockerParameters: []SingularityDockerParameter{
+				{Key: "env", Value: "A=1"},
+				{Key: "env", Value: "B=2"},
+			},
+			PortMappings: []DockerPortMapping{
+				{HostPort: 0, HostPortType: "FROM_OFFER", ContainerPort: 8080, ContainerPortType: "LITERAL", Protocol: "tcp"},
+				{HostPort: 53, HostPortType: "LITERAL", ContainerPort: 53, ContainerPortType: "LITERAL", Protocol: "udp"},
+			},
+		},
+		Volumes: []SingularityVolume{{HostPath: "/etc/app", ContainerPath: "/etc/app", Mode: "RO"}},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("Build: expected %+v, got %+v", expected, c)
+	}
+}
+
+func TestDeploySetDocker(t *testing.T) {
+	b := NewDocker("golang:latest").
+		SetNetwork(DockerNetworkBridge).
+		AddPortMapping(OfferPortMapping(0, 8080), OfferPortMapping(1, 8081))
+
+	d := NewDeploy("d1").SetRequestID("r1").SetResources(SingularityDeployResources{NumPorts: 2})
+	if _, err := d.SetDocker(b); err != nil {
+		t.Fatalf("SetDocker: unexpected error %v", err)
+	}
+	if got := d.Get().ContainerInfo.DockerInfo.Image; got != "golang:latest" {
+		t.Errorf("SetDocker: expected image %s, got %s", "golang:latest", got)
+	}
+	if err := d.Validate(); err != nil {
+		t.Errorf("Validate: unexpected error %v", err)
+	}
+
+	// Index 1 is out of range when only one port is offered.
+	d = NewDeploy("d1").SetRequestID("r1").SetResources(SingularityDeployResources{NumPorts: 1})
+	_, err := d.SetDocker(b)
+	if got := fields(t, err); !reflect.DeepEqual(got, []string{"containerInfo.docker.portMappings[1].hostPort"}) {
+		t.Errorf("SetDocker: expected offer index error, got %v", err)
+	}
+	if d.Get().ContainerInfo.Type != "" {
+		t.Errorf("SetDocker: expected invalid container not to be set")
+	}
+
+	_, err = NewDeploy("d1").SetDocker(NewDocker("golang:latest").
+		SetNetwork(DockerNetworkHost).
+		AddPortMapping(LiteralPortMapping(80, 80)))
+	if got := fields(t, err); !reflect.DeepEqual(got, []string{"containerInfo.docker.portMappings"}) {
+		t.Errorf("SetDocker: expected networking error, got %v", err)
+	}
+
+	_, err = NewDeploy("d1").SetDocker(NewDocker("").AddVolume(NewVolume("/a", "", VolumeRW)))
+	expected := []string{"containerInfo.volumes[0].containerPath", "containerInfo.docker.image"}
+	if got := fields(t, err); !reflect.DeepEqual(got, expected) {
+		t.Errorf("SetDocker: expected %v, got %v", expected, got)
+	}
+}
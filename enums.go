@@ -0,0 +1,234 @@
+package singularity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RequestType is the kind of a Singularity request.
+type RequestType string
+
+// Request types.
+const (
+	RequestTypeService   RequestType = "SERVICE"
+	RequestTypeWorker    RequestType = "WORKER"
+	RequestTypeScheduled RequestType = "SCHEDULED"
+	RequestTypeOnDemand  RequestType = "ON_DEMAND"
+	RequestTypeRunOnce   RequestType = "RUN_ONCE"
+)
+
+// Request types, as accepted by NewRequest before RequestType was added.
+//
+// Deprecated: use the RequestType constants.
+const (
+	ON_DEMAND = RequestTypeOnDemand
+	SERVICE   = RequestTypeService
+	SCHEDULED = RequestTypeScheduled
+	RUN_ONCE  = RequestTypeRunOnce
+	WORKER    = RequestTypeWorker
+)
+
+// RequestState is the state of a Singularity request.
+type RequestState string
+
+// Request states.
+const (
+	RequestStateActive             RequestState = "ACTIVE"
+	RequestStateDeleting           RequestState = "DELETING"
+	RequestStateDeleted            RequestState = "DELETED"
+	RequestStatePaused             RequestState = "PAUSED"
+	RequestStateSystemCooldown     RequestState = "SYSTEM_COOLDOWN"
+	RequestStateFinished           RequestState = "FINISHED"
+	RequestStateDeployingToUnpause RequestState = "DEPLOYING_TO_UNPAUSE"
+)
+
+// DeployState is the state of a pending or finished Singularity deploy.
+type DeployState string
+
+// Deploy states.
+const (
+	DeployStateSucceeded           DeployState = "SUCCEEDED"
+	DeployStateFailedInternalState DeployState = "FAILED_INTERNAL_STATE"
+	DeployStateCanceling           DeployState = "CANCELING"
+	DeployStateWaiting             DeployState = "WAITING"
+	DeployStateOverdue             DeployState = "OVERDUE"
+	DeployStateFailed              DeployState = "FAILED"
+	DeployStateCanceled            DeployState = "CANCELED"
+)
+
+// SlavePlacement is the strategy Singularity uses to pick the agents tasks of
+// a request run on.
+type SlavePlacement string
+
+// Slave placements.
+const (
+	SlavePlacementSeparate          SlavePlacement = "SEPARATE"
+	SlavePlacementOptimistic        SlavePlacement = "OPTIMISTIC"
+	SlavePlacementGreedy            SlavePlacement = "GREEDY"
+	SlavePlacementSeparateByDeploy  SlavePlacement = "SEPARATE_BY_DEPLOY"
+	SlavePlacementSeparateByRequest SlavePlacement = "SEPARATE_BY_REQUEST"
+	SlavePlacementSpreadAllSlaves   SlavePlacement = "SPREAD_ALL_SLAVES"
+)
+
+// LoadBalancerState is the state of a load balancer update.
+type LoadBalancerState string
+
+// Load balancer states.
+const (
+	LoadBalancerStateUnknown            LoadBalancerState = "UNKNOWN"
+	LoadBalancerStateFailed             LoadBalancerState = "FAILED"
+	LoadBalancerStateWaiting            LoadBalancerState = "WAITING"
+	LoadBalancerStateSuccess            LoadBalancerState = "SUCCESS"
+	LoadBalancerStateCanceling          LoadBalancerState = "CANCELING"
+	LoadBalancerStateCanceled           LoadBalancerState = "CANCELED"
+	LoadBalancerStateInvalidRequestNoop LoadBalancerState = "INVALID_REQUEST_NOOP"
+)
+
+// enum is the name and values of one of the enum types, and implements
+// their methods. Values Singularity adds after this client was written are
+// kept when decoding and encoded as they are, so that a newer Singularity
+// can still be read and written; only Validate rejects them.
+type enum struct {
+	name   string
+	values []string
+}
+
+var (
+	requestTypeEnum = enum{"request type", []string{
+		"SERVICE", "WORKER", "SCHEDULED", "ON_DEMAND", "RUN_ONCE"}}
+	requestStateEnum = enum{"request state", []string{
+		"ACTIVE", "DELETING", "DELETED", "PAUSED", "SYSTEM_COOLDOWN", "FINISHED", "DEPLOYING_TO_UNPAUSE"}}
+	deployStateEnum = enum{"deploy state", []string{
+		"SUCCEEDED", "FAILED_INTERNAL_STATE", "CANCELING", "WAITING", "OVERDUE", "FAILED", "CANCELED"}}
+	slavePlacementEnum = enum{"slave placement", []string{
+		"SEPARATE", "OPTIMISTIC", "GREEDY", "SEPARATE_BY_DEPLOY", "SEPARATE_BY_REQUEST", "SPREAD_ALL_SLAVES"}}
+	loadBalancerStateEnum = enum{"load balancer state", []string{
+		"UNKNOWN", "FAILED", "WAITING", "SUCCESS", "CANCELING", "CANCELED", "INVALID_REQUEST_NOOP"}}
+)
+
+func (e enum) valid(s string) bool {
+	return oneOf(s, e.values)
+}
+
+// parse returns the value matching s, ignoring case and surrounding spaces.
+func (e enum) parse(s string) (string, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	if !e.valid(v) {
+		return "", fmt.Errorf("unknown %s %q, must be one of %s", e.name, s, strings.Join(e.values, ", "))
+	}
+	return v, nil
+}
+
+func (e enum) unmarshal(data []byte) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("%s must be a string, got %s", e.name, data)
+	}
+	return s, nil
+}
+
+// ParseRequestType returns the RequestType named s, such as SERVICE or
+// on_demand.
+func ParseRequestType(s string) (RequestType, error) {
+	v, err := requestTypeEnum.parse(s)
+	return RequestType(v), err
+}
+
+func (t RequestType) String() string { return string(t) }
+
+// Valid reports whether t is one of the RequestType constants.
+func (t RequestType) Valid() bool { return requestTypeEnum.valid(string(t)) }
+
+// UnmarshalJSON decodes a RequestType, keeping types it doesn't know.
+func (t *RequestType) UnmarshalJSON(data []byte) error {
+	s, err := requestTypeEnum.unmarshal(data)
+	*t = RequestType(s)
+	return err
+}
+
+// ParseRequestState returns the RequestState named s, such as ACTIVE.
+func ParseRequestState(s string) (RequestState, error) {
+	v, err := requestStateEnum.parse(s)
+	return RequestState(v), err
+}
+
+func (s RequestState) String() string { return string(s) }
+
+// Valid reports whether s is one of the RequestState constants.
+func (s RequestState) Valid() bool { return requestStateEnum.valid(string(s)) }
+
+// UnmarshalJSON decodes a RequestState, keeping states it doesn't know.
+func (s *RequestState) UnmarshalJSON(data []byte) error {
+	v, err := requestStateEnum.unmarshal(data)
+	*s = RequestState(v)
+	return err
+}
+
+// ParseDeployState returns the DeployState named s, such as SUCCEEDED.
+func ParseDeployState(s string) (DeployState, error) {
+	v, err := deployStateEnum.parse(s)
+	return DeployState(v), err
+}
+
+func (s DeployState) String() string { return string(s) }
+
+// Valid reports whether s is one of the DeployState constants.
+func (s DeployState) Valid() bool { return deployStateEnum.valid(string(s)) }
+
+// Finished reports whether a deploy in state s is over, successfully or not.
+// States this client doesn't know are not finished.
+func (s DeployState) Finished() bool {
+	switch s {
+	case DeployStateSucceeded, DeployStateFailedInternalState, DeployStateOverdue, DeployStateFailed, DeployStateCanceled:
+		return true
+	}
+	return false
+}
+
+// UnmarshalJSON decodes a DeployState, keeping states it doesn't know.
+func (s *DeployState) UnmarshalJSON(data []byte) error {
+	v, err := deployStateEnum.unmarshal(data)
+	*s = DeployState(v)
+	return err
+}
+
+// ParseSlavePlacement returns the SlavePlacement named s, such as
+// SEPARATE_BY_REQUEST.
+func ParseSlavePlacement(s string) (SlavePlacement, error) {
+	v, err := slavePlacementEnum.parse(s)
+	return SlavePlacement(v), err
+}
+
+func (p SlavePlacement) String() string { return string(p) }
+
+// Valid reports whether p is one of the SlavePlacement constants.
+func (p SlavePlacement) Valid() bool { return slavePlacementEnum.valid(string(p)) }
+
+// UnmarshalJSON decodes a SlavePlacement, keeping placements it doesn't
+// know.
+func (p *SlavePlacement) UnmarshalJSON(data []byte) error {
+	v, err := slavePlacementEnum.unmarshal(data)
+	*p = SlavePlacement(v)
+	return err
+}
+
+// ParseLoadBalancerState returns the LoadBalancerState named s, such as
+// SUCCESS.
+func ParseLoadBalancerState(s string) (LoadBalancerState, error) {
+	v, err := loadBalancerStateEnum.parse(s)
+	return LoadBalancerState(v), err
+}
+
+func (s LoadBalancerState) String() string { return string(s) }
+
+// Valid reports whether s is one of the LoadBalancerState constants.
+func (s LoadBalancerState) Valid() bool { return loadBalancerStateEnum.valid(string(s)) }
+
+// UnmarshalJSON decodes a LoadBalancerState, keeping states it doesn't
+// know.
+func (s *LoadBalancerState) UnmarshalJSON(data []byte) error {
+	v, err := loadBalancerStateEnum.unmarshal(data)
+	*s = LoadBalancerState(v)
+	return err
+}
@@ -0,0 +1,117 @@
+package singularity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseEnums(t *testing.T) {
+	var data = []struct {
+		actual   string
+		parse    func(string) (string, error)
+		expected string
+		err      bool
+	}{
+		{"SERVICE", func(s string) (string, error) { v, err := ParseRequestType(s); return v.String(), err }, "SERVICE", false},
+		{" on_demand ", func(s string) (string, error) { v, err := ParseRequestType(s); return v.String(), err }, "ON_DEMAND", false},
+		{"BATCH", func(s string) (string, error) { v, err := ParseRequestType(s); return v.String(), err }, "", true},
+		{"paused", func(s string) (string, error) { v, err := ParseRequestState(s); return v.String(), err }, "PAUSED", false},
+		{"Succeeded", func(s string) (string, error) { v, err := ParseDeployState(s); return v.String(), err }, "SUCCEEDED", false},
+		{"spread_all_slaves", func(s string) (string, error) { v, err := ParseSlavePlacement(s); return v.String(), err }, "SPREAD_ALL_SLAVES", false},
+		{"EVERYWHERE", func(s string) (string, error) { v, err := ParseSlavePlacement(s); return v.String(), err }, "", true},
+		{"success", func(s string) (string, error) { v, err := ParseLoadBalancerState(s); return v.String(), err }, "SUCCESS", false},
+		{"", func(s string) (string, error) { v, err := ParseLoadBalancerState(s); return v.String(), err }, "", true},
+	}
+
+	for _, tt := range data {
+		got, err := tt.parse(tt.actual)
+		if (err != nil) != tt.err {
+			t.Errorf("Parse(%q): expected error %v, got %v", tt.actual, tt.err, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Parse(%q): expected %q, got %q", tt.actual, tt.expected, got)
+		}
+	}
+}
+
+func TestEnumsValid(t *testing.T) {
+	var data = []struct {
+		value    interface{ Valid() bool }
+		expected bool
+	}{
+		{RequestTypeRunOnce, true},
+		{RequestType("run_once"), false},
+		{RequestStateDeployingToUnpause, true},
+		{RequestState(""), false},
+		{DeployStateFailedInternalState, true},
+		{SlavePlacementSeparateByDeploy, true},
+		{SlavePlacement("EVERYWHERE"), false},
+		{LoadBalancerStateInvalidRequestNoop, true},
+	}
+
+	for _, tt := range data {
+		if got := tt.value.Valid(); got != tt.expected {
+			t.Errorf("%q.Valid(): expected %v, got %v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestEnumsJSON(t *testing.T) {
+	var data = []struct {
+		value      SingularityRequest
+		expected   string
+		marshalErr bool
+	}{
+		{SingularityRequest{ID: "s", RequestType: RequestTypeService}, `"requestType":"SERVICE"`, false},
+		{SingularityRequest{ID: "s", RequestType: RequestType("BATCH")}, `"requestType":"BATCH"`, false},
+		{SingularityRequest{ID: "s"}, `"requestType":""`, false},
+	}
+
+	for _, tt := range data {
+		b, err := json.Marshal(tt.value)
+		if (err != nil) != tt.marshalErr {
+			t.Errorf("Marshal(%q): expected error %v, got %v", tt.value.RequestType, tt.marshalErr, err)
+			continue
+		}
+		if err == nil && !strings.Contains(string(b), tt.expected) {
+			t.Errorf("Marshal(%q): expected %s in %s", tt.value.RequestType, tt.expected, b)
+		}
+	}
+
+	// Values added by a newer Singularity are kept.
+	var r Request
+	if err := json.Unmarshal([]byte(`{"state": "HIBERNATING", "request": {"requestType": "SERVICE"}}`), &r); err != nil {
+		t.Fatalf("Unmarshal: unexpected error %v", err)
+	}
+	if r.State != "HIBERNATING" || r.State.Valid() || r.RequestType != RequestTypeService {
+		t.Errorf("Unmarshal: unexpected result %+v", r)
+	}
+
+	if err := json.Unmarshal([]byte(`{"state": 1}`), &r); err == nil {
+		t.Errorf("Unmarshal(state 1): expected error, got nil")
+	}
+}
+
+func TestDeployStateFinished(t *testing.T) {
+	var data = []struct {
+		state    DeployState
+		expected bool
+	}{
+		{"", false},
+		{DeployStateWaiting, false},
+		{DeployStateCanceling, false},
+		{DeployStateSucceeded, true},
+		{DeployStateFailed, true},
+		{DeployStateOverdue, true},
+		{DeployStateCanceled, true},
+		{DeployStateFailedInternalState, true},
+		{"HIBERNATING", false},
+	}
+
+	for _, tt := range data {
+		if got := tt.state.Finished(); got != tt.expected {
+			t.Errorf("%q.Finished(): expected %v, got %v", tt.state, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package singularity
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LogrotateFrequency is how often the Singularity executor rotates task logs.
+type LogrotateFrequency string
+
+// Logrotate frequencies.
+const (
+	LogrotateHourly  LogrotateFrequency = "HOURLY"
+	LogrotateDaily   LogrotateFrequency = "DAILY"
+	LogrotateWeekly  LogrotateFrequency = "WEEKLY"
+	LogrotateMonthly LogrotateFrequency = "MONTHLY"
+)
+
+var validLogrotateFrequencies = []string{"HOURLY", "DAILY", "WEEKLY", "MONTHLY"}
+
+// maxExitCode is the largest exit status a process can return.
+const maxExitCode = 255
+
+// ExecutorDataBuilder builds the ExecutorData read by the Singularity
+// executor, which runs Cmd inside the task sandbox.
+type ExecutorDataBuilder interface {
+	SetExtraCmdLineArgs(...string) ExecutorDataBuilder
+	SetUser(string) ExecutorDataBuilder
+	SetSuccessfulExitCodes(...int) ExecutorDataBuilder
+	SetRunningSentinel(string) ExecutorDataBuilder
+	SetLogrotateFrequency(LogrotateFrequency) ExecutorDataBuilder
+	SetSkipLogrotateAndCompress(bool) ExecutorDataBuilder
+	SetLoggingTag(string) ExecutorDataBuilder
+	SetLoggingExtraFields(map[string]string) ExecutorDataBuilder
+	SetSigKillProcessesAfter(time.Duration) ExecutorDataBuilder
+	SetMaxOpenFiles(int) ExecutorDataBuilder
+	SetMaxTaskThreads(int) ExecutorDataBuilder
+	SetPreserveTaskSandboxAfterFinish(bool) ExecutorDataBuilder
+	AddEmbeddedArtifact(...EmbeddedArtifact) ExecutorDataBuilder
+	AddExternalArtifact(...ExternalArtifact) ExecutorDataBuilder
+	AddS3Artifact(...S3Artifact) ExecutorDataBuilder
+	AddS3ArtifactSignature(...S3ArtifactSignature) ExecutorDataBuilder
+	Build() ExecutorData
+}
+
+type executorDataBuilder struct {
+	data ExecutorData
+}
+
+// NewExecutorData returns an ExecutorDataBuilder for an executor running cmd.
+func NewExecutorData(cmd string) ExecutorDataBuilder {
+	return &executorDataBuilder{data: ExecutorData{Cmd: cmd}}
+}
+
+// SetExtraCmdLineArgs sets arguments passed to cmd.
+func (b *executorDataBuilder) SetExtraCmdLineArgs(args ...string) ExecutorDataBuilder {
+	b.data.ExtraCmdLineArgs = args
+	return b
+}
+
+// SetUser runs the task process as user.
+func (b *executorDataBuilder) SetUser(user string) ExecutorDataBuilder {
+	b.data.User = user
+	return b
+}
+
+// SetSuccessfulExitCodes sets the exit codes for which a task is FINISHED
+// rather than FAILED.
+func (b *executorDataBuilder) SetSuccessfulExitCodes(codes ...int) ExecutorDataBuilder {
+	b.data.SuccessfulExitCodes = codes
+	return b
+}
+
+// SetRunningSentinel sets a file, relative to the sandbox, whose creation
+// marks the task as running.
+func (b *executorDataBuilder) SetRunningSentinel(path string) ExecutorDataBuilder {
+	b.data.RunningSentinel = path
+	return b
+}
+
+// SetLogrotateFrequency sets how often task logs are rotated.
+func (b *executorDataBuilder) SetLogrotateFrequency(f LogrotateFrequency) ExecutorDataBuilder {
+	b.data.LogrotateFrequency = string(f)
+	return b
+}
+
+// SetSkipLogrotateAndCompress turns off log rotation and compression.
+func (b *executorDataBuilder) SetSkipLogrotateAndCompress(s bool) ExecutorDataBuilder {
+	b.data.SkipLogrotateAndCompress = s
+	return b
+}
+
+// SetLoggingTag sets the tag of the task's log lines.
+func (b *executorDataBuilder) SetLoggingTag(tag string) ExecutorDataBuilder {
+	b.data.LoggingTag = tag
+	return b
+}
+
+// SetLoggingExtraFields sets extra fields added to the task's log lines.
+func (b *executorDataBuilder) SetLoggingExtraFields(f map[string]string) ExecutorDataBuilder {
+	b.data.LoggingExtraFields = f
+	return b
+}
+
+// SetSigKillProcessesAfter sets how long the executor waits after sending
+// SIGTERM before it sends SIGKILL. It is sent to Singularity in milliseconds.
+func (b *executorDataBuilder) SetSigKillProcessesAfter(d time.Duration) ExecutorDataBuilder {
+	b.data.SigKillProcessesAfterMillis = MillisOf(d)
+	return b
+}
+
+// SetMaxOpenFiles limits the number of files the task process may open.
+func (b *executorDataBuilder) SetMaxOpenFiles(n int) ExecutorDataBuilder {
+	b.data.MaxOpenFiles = n
+	return b
+}
+
+// SetMaxTaskThreads limits the number of threads the task may use.
+func (b *executorDataBuilder) SetMaxTaskThreads(n int) ExecutorDataBuilder {
+	b.data.MaxTaskThreads = n
+	return b
+}
+
+// SetPreserveTaskSandboxAfterFinish keeps the sandbox once the task ends.
+func (b *executorDataBuilder) SetPreserveTaskSandboxAfterFinish(p bool) ExecutorDataBuilder {
+	b.data.PreserveTaskSandboxAfterFinish = p
+	return b
+}
+
+// AddEmbeddedArtifact adds artifacts, such as from NewEmbeddedArtifact, whose
+// content is sent with the deploy.
+func (b *executorDataBuilder) AddEmbeddedArtifact(a ...EmbeddedArtifact) ExecutorDataBuilder {
+	b.data.EmbeddedArtifacts = append(b.data.EmbeddedArtifacts, a...)
+	return b
+}
+
+// AddExternalArtifact adds artifacts the executor downloads over HTTP.
+func (b *executorDataBuilder) AddExternalArtifact(a ...ExternalArtifact) ExecutorDataBuilder {
+	b.data.ExternalArtifacts = append(b.data.ExternalArtifacts, a...)
+	return b
+}
+
+// AddS3Artifact adds artifacts the executor downloads from S3.
+func (b *executorDataBuilder) AddS3Artifact(a ...S3Artifact) ExecutorDataBuilder {
+	b.data.S3Artifacts = append(b.data.S3Artifacts, a...)
+	return b
+}
+
+// AddS3ArtifactSignature adds signatures the executor uses to verify S3
+// artifacts.
+func (b *executorDataBuilder) AddS3ArtifactSignature(s ...S3ArtifactSignature) ExecutorDataBuilder {
+	b.data.S3ArtifactSignatures = append(b.data.S3ArtifactSignatures, s...)
+	return b
+}
+
+// Build returns the ExecutorData. Use SingularityDeploy.SetExecutorData to
+// validate it too.
+func (b *executorDataBuilder) Build() ExecutorData {
+	data := b.data
+	data.EmbeddedArtifacts = append([]EmbeddedArtifact(nil), data.EmbeddedArtifacts...)
+	data.ExternalArtifacts = append([]ExternalArtifact(nil), data.ExternalArtifacts...)
+	data.S3Artifacts = append([]S3Artifact(nil), data.S3Artifacts...)
+	data.S3ArtifactSignatures = append([]S3ArtifactSignature(nil), data.S3ArtifactSignatures...)
+	return data
+}
+
+// SetExecutorData accepts an ExecutorDataBuilder and runs this deploy with
+// the Singularity executor, started by SetCustomExecutorCmd. The executor
+// data is validated first.
+func (d *SingularityDeploy) SetExecutorData(b ExecutorDataBuilder) (Deploy, error) {
+	data := b.Build()
+	var e ValidationError
+	e.validateExecutorData(&data)
+	if err := e.err(); err != nil {
+		return nil, err
+	}
+	d.ExecutorData = &data
+	return d, nil
+}
+
+// FileChecksum returns the hex encoded md5sum and size in bytes of the file
+// at localPath, as used by the md5sum and filesize fields of artifacts.
+func FileChecksum(localPath string) (string, int64, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("Checksum %s artifact error: %v", localPath, err)
+	}
+	defer f.Close()
+
+	h := md5.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("Checksum %s artifact error: %v", localPath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// NewEmbeddedArtifact reads the local file at localPath into an artifact named
+// name, which the executor writes to targetFolder in the task sandbox. The
+// md5sum is computed from the content.
+func NewEmbeddedArtifact(name, localPath, targetFolder string) (EmbeddedArtifact, error) {
+	content, err := ioutil.ReadFile(localPath)
+	if err != nil {
+		return EmbeddedArtifact{}, fmt.Errorf("Embed %s artifact error: %v", localPath, err)
+	}
+	sum := md5.Sum(content)
+	return EmbeddedArtifact{
+		Name:                       name,
+		Filename:                   filepath.Base(localPath),
+		TargetFolderRelativeToTask: targetFolder,
+		Md5sum:                     hex.EncodeToString(sum[:]),
+		Content:                    content,
+	}, nil
+}
+
+// NewExternalArtifact returns an artifact the executor downloads from rawURL,
+// saved under the last element of its path. When localPath is not empty it is
+// a copy of the file at rawURL, and it is used to set the md5sum and filesize
+// the executor checks the download against.
+func NewExternalArtifact(name, rawURL, localPath string) (ExternalArtifact, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ExternalArtifact{}, fmt.Errorf("Parse Singularity artifact URL error: %v", err)
+	}
+	a := ExternalArtifact{
+		Name:     name,
+		URL:      rawURL,
+		Filename: path.Base(u.Path),
+	}
+	if localPath == "" {
+		return a, nil
+	}
+	a.Md5sum, a.Filesize, err = FileChecksum(localPath)
+	return a, err
+}
+
+// NewS3Artifact returns an artifact the executor downloads from key in
+// bucket. As with NewExternalArtifact, a non-empty localPath is used to set
+// the md5sum and filesize.
+func NewS3Artifact(name, bucket, key, localPath string) (S3Artifact, error) {
+	a := S3Artifact{
+		Name:        name,
+		S3Bucket:    bucket,
+		S3ObjectKey: key,
+		Filename:    path.Base(key),
+	}
+	if localPath == "" {
+		return a, nil
+	}
+	var err error
+	a.Md5sum, a.Filesize, err = FileChecksum(localPath)
+	return a, err
+}
+
+func (e *ValidationError) validateExecutorData(data *ExecutorData) {
+	if data.Cmd == "" {
+		e.add("executorData.cmd", "is required")
+	}
+	for i, c := range data.SuccessfulExitCodes {
+		if c < 0 || c > maxExitCode {
+			e.add(fmt.Sprintf("executorData.successfulExitCodes[%d]", i), "must be between 0 and %d", maxExitCode)
+		}
+	}
+	if data.LogrotateFrequency != "" && !oneOf(data.LogrotateFrequency, validLogrotateFrequencies) {
+		e.add("executorData.logrotateFrequency", "must be one of %s", strings.Join(validLogrotateFrequencies, ", "))
+	}
+	if data.SigKillProcessesAfterMillis < 0 {
+		e.add("executorData.sigKillProcessesAfterMillis", "must not be negative")
+	}
+	if data.MaxOpenFiles < 0 {
+		e.add("executorData.maxOpenFiles", "must not be negative")
+	}
+	if data.MaxTaskThreads < 0 {
+		e.add("executorData.maxTaskThreads", "must not be negative")
+	}
+
+	for i, a := range data.EmbeddedArtifacts {
+		field := fmt.Sprintf("executorData.embeddedArtifacts[%d]", i)
+		e.checkArtifact(field, a.Name, a.Filename)
+		if a.Md5sum != "" {
+			if sum := md5.Sum(a.Content); hex.EncodeToString(sum[:]) != strings.ToLower(a.Md5sum) {
+				e.add(field+".md5sum", "does not match the content")
+			}
+		}
+	}
+	for i, a := range data.ExternalArtifacts {
+		field := fmt.Sprintf("executorData.externalArtifacts[%d]", i)
+		e.checkArtifact(field, a.Name, a.Filename)
+		if a.URL == "" {
+			e.add(field+".url", "is required")
+		}
+	}
+	for i, a := range data.S3Artifacts {
+		field := fmt.Sprintf("executorData.s3Artifacts[%d]", i)
+		e.checkArtifact(field, a.Name, a.Filename)
+		if a.S3Bucket == "" {
+			e.add(field+".s3Bucket", "is required")
+		}
+		if a.S3ObjectKey == "" {
+			e.add(field+".s3ObjectKey", "is required")
+		}
+	}
+}
+
+func (e *ValidationError) checkArtifact(field, name, filename string) {
+	if name == "" {
+		e.add(field+".name", "is required")
+	}
+	if filename == "" {
+		e.add(field+".filename", "is required")
+	}
+}
@@ -0,0 +1,151 @@
+package singularity
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExecutorDataBuilder(t *testing.T) {
+	data := NewExecutorData("./run.sh").
+		SetExtraCmdLineArgs("-v").
+		SetUser("app").
+		SetSuccessfulExitCodes(0, 3).
+		SetLogrotateFrequency(LogrotateHourly).
+		SetLoggingTag("app").
+		SetSigKillProcessesAfter(90 * time.Second).
+		SetMaxOpenFiles(4096).
+		SetMaxTaskThreads(200).
+		AddExternalArtifact(ExternalArtifact{Name: "app", Filename: "app.tgz", URL: "http://example.com/app.tgz"}).
+		Build()
+
+	expected := ExecutorData{
+		Cmd:                         "./run.sh",
+		ExtraCmdLineArgs:            []string{"-v"},
+		User:                        "app",
+		SuccessfulExitCodes:         []int{0, 3},
+		LogrotateFrequency:          "HOURLY",
+		LoggingTag:                  "app",
+		SigKillProcessesAfterMillis: 90000,
+		MaxOpenFiles:                4096,
+		MaxTaskThreads:              200,
+		ExternalArtifacts:           []ExternalArtifact{{Name: "app", Filename: "app.tgz", URL: "http://example.com/app.tgz"}},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Build: expected %+v, got %+v", expected, data)
+	}
+}
+
+func TestExecutorDataMarshal(t *testing.T) {
+	data := NewExecutorData("./run.sh").
+		SetUser("app").
+		AddExternalArtifact(ExternalArtifact{Name: "app", URL: "http://example.com/app.tgz"}).
+		Build()
+	b, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Marshal: unexpected error %v", err)
+	}
+	expected := `{"externalArtifacts":[{"url":"http://example.com/app.tgz","name":"app"}],"user":"app","cmd":"./run.sh"}`
+	if string(b) != expected {
+		t.Errorf("Marshal: expected %s, got %s", expected, b)
+	}
+}
+
+func TestNewArtifactsFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "artifact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yml")
+	if err := ioutil.WriteFile(path, []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	const sum = "b1946ac92492d2347c6235b4d2611184"
+
+	e, err := NewEmbeddedArtifact("config", path, "conf")
+	if err != nil {
+		t.Fatalf("NewEmbeddedArtifact: unexpected error %v", err)
+	}
+	if e.Md5sum != sum || e.Filename != "config.yml" || string(e.Content) != "hello\n" {
+		t.Errorf("NewEmbeddedArtifact(%s): expected md5sum %s, got %+v", path, sum, e)
+	}
+
+	x, err := NewExternalArtifact("config", "http://example.com/config.yml", path)
+	if err != nil {
+		t.Fatalf("NewExternalArtifact: unexpected error %v", err)
+	}
+	if x.Md5sum != sum || x.Filesize != 6 || x.Filename != "config.yml" {
+		t.Errorf("NewExternalArtifact(%s): expected md5sum %s and filesize %d, got %+v", path, sum, 6, x)
+	}
+
+	s, err := NewS3Artifact("config", "bucket", "apps/config.yml", path)
+	if err != nil {
+		t.Fatalf("NewS3Artifact: unexpected error %v", err)
+	}
+	if s.Md5sum != sum || s.Filesize != 6 || s.Filename != "config.yml" {
+		t.Errorf("NewS3Artifact(%s): expected md5sum %s and filesize %d, got %+v", path, sum, 6, s)
+	}
+
+	// The filename comes from the URL's path, without its query string.
+	var data = []struct {
+		url      string
+		expected string
+	}{
+		{"http://example.com/app.tar.gz", "app.tar.gz"},
+		{"https://bucket.s3.amazonaws.com/builds/app.tar.gz?X-Amz-Signature=abc%2Fdef&X-Amz-Expires=60", "app.tar.gz"},
+		{"http://example.com/a%20b.zip#top", "a b.zip"},
+	}
+	for _, tt := range data {
+		x, err := NewExternalArtifact("app", tt.url, "")
+		if err != nil {
+			t.Errorf("NewExternalArtifact(%s): unexpected error %v", tt.url, err)
+			continue
+		}
+		if x.Filename != tt.expected || x.URL != tt.url {
+			t.Errorf("NewExternalArtifact(%s): expected filename %s, got %+v", tt.url, tt.expected, x)
+		}
+	}
+	if _, err := NewExternalArtifact("app", "http://example.com/%zz", ""); err == nil {
+		t.Errorf("NewExternalArtifact: expected error for an unparseable URL")
+	}
+
+	if _, err := NewEmbeddedArtifact("missing", filepath.Join(dir, "missing"), ""); err == nil {
+		t.Errorf("NewEmbeddedArtifact: expected error for a missing file")
+	}
+}
+
+func TestDeploySetExecutorData(t *testing.T) {
+	d := NewDeploy("d1").SetRequestID("r1").SetCustomExecutorCmd("/usr/local/bin/singularity-executor")
+	if _, err := d.SetExecutorData(NewExecutorData("./run.sh")); err != nil {
+		t.Fatalf("SetExecutorData: unexpected error %v", err)
+	}
+	if err := d.Validate(); err != nil {
+		t.Errorf("Validate: unexpected error %v", err)
+	}
+
+	var data = []struct {
+		builder  ExecutorDataBuilder
+		expected []string
+	}{
+		{NewExecutorData(""), []string{"executorData.cmd"}},
+		{NewExecutorData("./run.sh").SetSuccessfulExitCodes(0, 256), []string{"executorData.successfulExitCodes[1]"}},
+		{NewExecutorData("./run.sh").SetLogrotateFrequency("YEARLY"), []string{"executorData.logrotateFrequency"}},
+		{NewExecutorData("./run.sh").SetMaxOpenFiles(-1).SetSigKillProcessesAfter(-time.Second),
+			[]string{"executorData.sigKillProcessesAfterMillis", "executorData.maxOpenFiles"}},
+		{NewExecutorData("./run.sh").AddEmbeddedArtifact(EmbeddedArtifact{Name: "a", Filename: "a", Md5sum: "00", Content: []byte("a")}),
+			[]string{"executorData.embeddedArtifacts[0].md5sum"}},
+		{NewExecutorData("./run.sh").AddS3Artifact(S3Artifact{Name: "a", Filename: "a"}),
+			[]string{"executorData.s3Artifacts[0].s3Bucket", "executorData.s3Artifacts[0].s3ObjectKey"}},
+	}
+	for _, tt := range data {
+		_, err := NewDeploy("d1").SetRequestID("r1").SetExecutorData(tt.builder)
+		if got := fields(t, err); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("SetExecutorData(%+v): expected errors for %v, got %v", tt.builder.Build(), tt.expected, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package singularity
+
+import (
+	"fmt"
+
+	"github.com/go-resty/resty"
+)
+
+// NewRequestGroup accepts a group id and the ids of its requests, and returns
+// a request group to pass to SaveRequestGroup.
+func NewRequestGroup(id string, requestIDs ...string) SingularityRequestGroup {
+	return SingularityRequestGroup{
+		ID:         id,
+		RequestIDs: requestIDs,
+	}
+}
+
+// SetMetadata sets the value of a metadata key of this group.
+func (g *SingularityRequestGroup) SetMetadata(key, value string) *SingularityRequestGroup {
+	if g.Metadata == nil {
+		g.Metadata = make(map[string]string)
+	}
+	g.Metadata[key] = value
+	return g
+}
+
+// Validate checks that a group and its request ids are valid Singularity
+// ids, and returns a ValidationError listing every violation.
+func (g *SingularityRequestGroup) Validate() error {
+	var e ValidationError
+	e.checkID("id", g.ID, maxRequestIDLength, requestIDPattern)
+	for i, id := range g.RequestIDs {
+		e.checkID(fmt.Sprintf("requestIds[%d]", i), id, maxRequestIDLength, requestIDPattern)
+	}
+	return e.err()
+}
+
+// GetRequestGroups retrieves every request group.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apigroups
+func (c *Client) GetRequestGroups() (*resty.Response, []SingularityRequestGroup, error) {
+	res, err := c.request().
+		Get("/api/groups")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity request groups error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return res, nil, fmt.Errorf("Get Singularity request groups error: %v", string(res.Body()))
+	}
+
+	var body []SingularityRequestGroup
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return res, nil, fmt.Errorf("Parse Singularity request groups error: %v", err)
+	}
+	return res, body, nil
+}
+
+// GetRequestGroup accepts a group id and retrieves that request group.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apigroupsgrouprequestgroupid
+func (c *Client) GetRequestGroup(id string) (*resty.Response, SingularityRequestGroup, error) {
+	res, err := c.request().
+		Get("/api/groups/group/" + id)
+	if err != nil {
+		return &resty.Response{}, SingularityRequestGroup{}, fmt.Errorf("Get Singularity request group error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return res, SingularityRequestGroup{}, fmt.Errorf("Get Singularity request group error: %v", string(res.Body()))
+	}
+
+	var body SingularityRequestGroup
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return res, SingularityRequestGroup{}, fmt.Errorf("Parse Singularity request group error: %v", err)
+	}
+	return res, body, nil
+}
+
+// SaveRequestGroup accepts a request group and creates it, or replaces the
+// group with the same id. The group is validated first.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apigroups
+func (c *Client) SaveRequestGroup(g SingularityRequestGroup) (*resty.Response, SingularityRequestGroup, error) {
+	if err := g.Validate(); err != nil {
+		return &resty.Response{}, SingularityRequestGroup{}, err
+	}
+	res, err := c.request().
+		SetHeader("Content-Type", "application/json").
+		SetBody(g).
+		Post("/api/groups")
+	if err != nil {
+		return &resty.Response{}, SingularityRequestGroup{}, fmt.Errorf("Save Singularity request group error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return res, SingularityRequestGroup{}, fmt.Errorf("Save Singularity request group error: %v", string(res.Body()))
+	}
+
+	var body SingularityRequestGroup
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return res, SingularityRequestGroup{}, fmt.Errorf("Parse Singularity request group error: %v", err)
+	}
+	return res, body, nil
+}
+
+// DeleteRequestGroup accepts a group id and deletes that request group. Its
+// requests are not changed.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#delete-apigroupsgrouprequestgroupid
+func (c *Client) DeleteRequestGroup(id string) (*resty.Response, error) {
+	res, err := c.request().
+		Delete("/api/groups/group/" + id)
+	if err != nil {
+		return &resty.Response{}, fmt.Errorf("Delete Singularity request group error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return res, fmt.Errorf("Delete Singularity request group error: %v", string(res.Body()))
+	}
+	return res, nil
+}
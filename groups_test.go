@@ -0,0 +1,87 @@
+package singularity
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClientRequestGroups(t *testing.T) {
+	var (
+		saved   SingularityRequestGroup
+		deleted string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/groups":
+			w.Write([]byte(`[{"id":"web","requestIds":["frontend","api"],"metadata":{"team":"web"}}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/groups/group/web":
+			w.Write([]byte(`{"id":"web","requestIds":["frontend","api"],"metadata":{"team":"web"}}`))
+		case r.Method == "POST" && r.URL.Path == "/api/groups":
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &saved)
+			w.Write(body)
+		case r.Method == "DELETE" && r.URL.Path == "/api/groups/group/web":
+			deleted = "web"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`not found`))
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	expected := SingularityRequestGroup{ID: "web", RequestIDs: []string{"frontend", "api"}, Metadata: map[string]string{"team": "web"}}
+	_, groups, err := c.GetRequestGroups()
+	if err != nil || len(groups) != 1 || !reflect.DeepEqual(groups[0], expected) {
+		t.Errorf("GetRequestGroups: got %+v, %v", groups, err)
+	}
+	_, group, err := c.GetRequestGroup("web")
+	if err != nil || !reflect.DeepEqual(group, expected) {
+		t.Errorf("GetRequestGroup(web): got %+v, %v", group, err)
+	}
+	if res, _, err := c.GetRequestGroup("missing"); err == nil || res.StatusCode() != http.StatusNotFound {
+		t.Errorf("GetRequestGroup(missing): expected an error and status %d, got %d, %v", http.StatusNotFound, res.StatusCode(), err)
+	}
+
+	g := NewRequestGroup("batch", "nightly", "hourly")
+	g.SetMetadata("owner", "data").SetMetadata("pager", "data-oncall")
+	_, group, err = c.SaveRequestGroup(g)
+	if err != nil {
+		t.Fatalf("SaveRequestGroup: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(saved, g) || !reflect.DeepEqual(group, g) {
+		t.Errorf("SaveRequestGroup: expected %+v posted and returned, got %+v and %+v", g, saved, group)
+	}
+	if _, _, err := c.SaveRequestGroup(NewRequestGroup("bad/id")); err == nil {
+		t.Errorf("SaveRequestGroup: expected a validation error for bad/id")
+	}
+
+	if _, err := c.DeleteRequestGroup("web"); err != nil || deleted != "web" {
+		t.Errorf("DeleteRequestGroup(web): expected web deleted, got %q, %v", deleted, err)
+	}
+	if res, err := c.DeleteRequestGroup("missing"); err == nil || res.StatusCode() != http.StatusNotFound {
+		t.Errorf("DeleteRequestGroup(missing): expected an error and status %d, got %d, %v", http.StatusNotFound, res.StatusCode(), err)
+	}
+}
+
+func TestRequestGroupValidate(t *testing.T) {
+	var data = []struct {
+		name     string
+		group    SingularityRequestGroup
+		expected []string
+	}{
+		{"valid", NewRequestGroup("web", "frontend", "api"), nil},
+		{"empty", SingularityRequestGroup{}, []string{"id"}},
+		{"bad request ids", NewRequestGroup("web", "frontend", "", "my/api"), []string{"requestIds[1]", "requestIds[2]"}},
+	}
+	for _, tt := range data {
+		got := fields(t, tt.group.Validate())
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Validate(%s): expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package singularity
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EventType says how a cached object changed.
+type EventType int
+
+// Event types. A resync delivers every cached object again as Updated, with
+// Old and New the same.
+const (
+	Added EventType = iota
+	Updated
+	Deleted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case Added:
+		return "ADDED"
+	case Updated:
+		return "UPDATED"
+	case Deleted:
+		return "DELETED"
+	}
+	return "UNKNOWN"
+}
+
+// RequestEvent is a change to a cached request. Old is empty for Added, and
+// New is empty for Deleted.
+type RequestEvent struct {
+	Type EventType
+	Old  Request
+	New  Request
+}
+
+// TaskEvent is a change to a cached active task. Old is empty for Added, and
+// New is empty for Deleted.
+type TaskEvent struct {
+	Type EventType
+	Old  SingularityTask
+	New  SingularityTask
+}
+
+// defaultMaxBackoff caps the wait between failed polls unless SetMaxBackoff
+// is used.
+const defaultMaxBackoff = 5 * time.Minute
+
+// Informer keeps a local cache of requests and active tasks, by listing them
+// every interval, and tells its handlers what changed. It is safe to read the
+// cache from several goroutines while Run is polling.
+type Informer struct {
+	client     *Client
+	interval   time.Duration
+	resync     time.Duration
+	maxBackoff time.Duration
+
+	requestHandlers []func(RequestEvent)
+	taskHandlers    []func(TaskEvent)
+	errorHandlers   []func(error)
+
+	mu         sync.RWMutex
+	synced     bool
+	lastResync time.Time
+	requests   map[string]Request
+	byOwner    map[string][]string
+	byState    map[RequestState][]string
+	tasks      map[string]SingularityTask
+	byRequest  map[string][]string
+}
+
+// NewInformer returns an Informer which lists requests and tasks through c
+// every interval. An interval of zero or less polls every five seconds.
+func NewInformer(c *Client, interval time.Duration) *Informer {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &Informer{
+		client:     c,
+		interval:   interval,
+		maxBackoff: defaultMaxBackoff,
+		requests:   map[string]Request{},
+		tasks:      map[string]SingularityTask{},
+	}
+}
+
+// SetResync delivers every cached object to the handlers again as Updated
+// this often, so that handlers which missed an event catch up. Zero, the
+// default, turns resyncs off.
+func (i *Informer) SetResync(d time.Duration) *Informer {
+	i.resync = d
+	return i
+}
+
+// SetMaxBackoff caps the wait between polls after errors. Each failed poll
+// doubles the wait, starting from the interval.
+func (i *Informer) SetMaxBackoff(d time.Duration) *Informer {
+	i.maxBackoff = d
+	return i
+}
+
+// OnRequest registers a handler for request events. Handlers are called in
+// turn from the polling goroutine, so they should not block.
+func (i *Informer) OnRequest(f func(RequestEvent)) *Informer {
+	i.requestHandlers = append(i.requestHandlers, f)
+	return i
+}
+
+// OnTask registers a handler for active task events.
+func (i *Informer) OnTask(f func(TaskEvent)) *Informer {
+	i.taskHandlers = append(i.taskHandlers, f)
+	return i
+}
+
+// OnError registers a handler for errors from polling.
+func (i *Informer) OnError(f func(error)) *Informer {
+	i.errorHandlers = append(i.errorHandlers, f)
+	return i
+}
+
+// Run polls until ctx is done. Handlers should be registered before it is
+// called.
+func (i *Informer) Run(ctx context.Context) {
+	failures := 0
+	for {
+		if err := i.Sync(); err != nil {
+			failures++
+			for _, f := range i.errorHandlers {
+				f(err)
+			}
+		} else {
+			failures = 0
+		}
+
+		t := time.NewTimer(backoff(i.interval, i.maxBackoff, failures))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// backoff returns how long to wait after failures failed polls in a row.
+func backoff(interval, max time.Duration, failures int) time.Duration {
+	d := interval
+	for n := 0; n < failures && d < max; n++ {
+		d *= 2
+	}
+	if failures > 0 && d > max {
+		d = max
+	}
+	return d
+}
+
+// Sync lists requests and active tasks once, updates the cache and calls the
+// handlers. If either list fails the cache is left as it was.
+func (i *Informer) Sync() error {
+	_, reqs, err := i.client.GetRequests()
+	if err != nil {
+		return err
+	}
+	_, tasks, err := i.client.GetActiveTasks()
+	if err != nil {
+		return err
+	}
+
+	newRequests := make(map[string]Request, len(reqs))
+	for _, r := range reqs {
+		newRequests[r.ID] = r
+	}
+	newTasks := make(map[string]SingularityTask, len(tasks))
+	for _, t := range tasks {
+		newTasks[t.TaskID.ID] = t
+	}
+
+	i.mu.Lock()
+	oldRequests, oldTasks := i.requests, i.tasks
+	resync := i.resync > 0 && i.synced && time.Since(i.lastResync) >= i.resync
+	if resync || !i.synced {
+		i.lastResync = time.Now()
+	}
+	i.requests, i.tasks = newRequests, newTasks
+	i.byOwner, i.byState, i.byRequest = map[string][]string{}, map[RequestState][]string{}, map[string][]string{}
+	for id, r := range newRequests {
+		for _, o := range r.Owners {
+			i.byOwner[o] = append(i.byOwner[o], id)
+		}
+		i.byState[r.State] = append(i.byState[r.State], id)
+	}
+	for id, t := range newTasks {
+		i.byRequest[t.TaskID.RequestID] = append(i.byRequest[t.TaskID.RequestID], id)
+	}
+	i.synced = true
+	i.mu.Unlock()
+
+	for _, id := range unionKeys(requestKeys(oldRequests), requestKeys(newRequests)) {
+		old, hadOld := oldRequests[id]
+		cur, hasNew := newRequests[id]
+		e := RequestEvent{Old: old, New: cur}
+		switch {
+		case !hadOld:
+			e.Type = Added
+		case !hasNew:
+			e.Type = Deleted
+		case resync || !reflect.DeepEqual(old, cur):
+			e.Type = Updated
+		default:
+			continue
+		}
+		for _, f := range i.requestHandlers {
+			f(e)
+		}
+	}
+	for _, id := range unionKeys(taskKeys(oldTasks), taskKeys(newTasks)) {
+		old, hadOld := oldTasks[id]
+		cur, hasNew := newTasks[id]
+		e := TaskEvent{Old: old, New: cur}
+		switch {
+		case !hadOld:
+			e.Type = Added
+		case !hasNew:
+			e.Type = Deleted
+		case resync || !reflect.DeepEqual(old, cur):
+			e.Type = Updated
+		default:
+			continue
+		}
+		for _, f := range i.taskHandlers {
+			f(e)
+		}
+	}
+	return nil
+}
+
+// HasSynced returns true once the cache has been filled.
+func (i *Informer) HasSynced() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.synced
+}
+
+// GetRequest returns a cached request, and false if there is none.
+func (i *Informer) GetRequest(id string) (Request, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	r, ok := i.requests[id]
+	return r, ok
+}
+
+// ListRequests returns every cached request, sorted by ID.
+func (i *Informer) ListRequests() []Request {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.requestsByID(requestKeys(i.requests))
+}
+
+// RequestsByOwner returns the cached requests owned by owner, sorted by ID.
+func (i *Informer) RequestsByOwner(owner string) []Request {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.requestsByID(i.byOwner[owner])
+}
+
+// RequestsByState returns the cached requests in state, such as ACTIVE or
+// PAUSED, sorted by ID.
+func (i *Informer) RequestsByState(state RequestState) []Request {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.requestsByID(i.byState[state])
+}
+
+// GetTask returns a cached active task, and false if there is none.
+func (i *Informer) GetTask(id string) (SingularityTask, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	t, ok := i.tasks[id]
+	return t, ok
+}
+
+// TasksByRequest returns the cached active tasks of a request, sorted by ID.
+func (i *Informer) TasksByRequest(requestID string) []SingularityTask {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	ids := append([]string(nil), i.byRequest[requestID]...)
+	sort.Strings(ids)
+	tasks := make([]SingularityTask, 0, len(ids))
+	for _, id := range ids {
+		tasks = append(tasks, i.tasks[id])
+	}
+	return tasks
+}
+
+// requestsByID returns the cached requests with ids, sorted by ID. The
+// caller must hold i.mu.
+func (i *Informer) requestsByID(ids []string) []Request {
+	ids = append([]string(nil), ids...)
+	sort.Strings(ids)
+	reqs := make([]Request, 0, len(ids))
+	for _, id := range ids {
+		reqs = append(reqs, i.requests[id])
+	}
+	return reqs
+}
+
+func requestKeys(m map[string]Request) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func taskKeys(m map[string]SingularityTask) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// unionKeys returns the keys in a or b, sorted, without duplicates.
+func unionKeys(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, k := range append(a, b...) {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,162 @@
+package singularity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInformerSync(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests = `[{"request":{"id":"r1","owners":["alice"]},"state":"ACTIVE"},{"request":{"id":"r2","owners":["bob"]},"state":"PAUSED"}]`
+		tasks    = `[{"taskId":{"id":"r1-t1","requestId":"r1"}}]`
+		fail     bool
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`boom`))
+			return
+		}
+		switch r.URL.Path {
+		case "/api/requests":
+			w.Write([]byte(requests))
+		case "/api/tasks/active":
+			w.Write([]byte(tasks))
+		}
+	}))
+	defer ts.Close()
+
+	var events []string
+	i := NewInformer(newTestClient(ts), time.Minute).
+		OnRequest(func(e RequestEvent) {
+			id := e.New.ID
+			if e.Type == Deleted {
+				id = e.Old.ID
+			}
+			events = append(events, e.Type.String()+" "+id)
+		}).
+		OnTask(func(e TaskEvent) {
+			id := e.New.TaskID.ID
+			if e.Type == Deleted {
+				id = e.Old.TaskID.ID
+			}
+			events = append(events, e.Type.String()+" "+id)
+		})
+
+	if i.HasSynced() {
+		t.Errorf("HasSynced: expected false before Sync")
+	}
+	if err := i.Sync(); err != nil {
+		t.Fatalf("Sync: expected no error, got %v", err)
+	}
+	expected := []string{"ADDED r1", "ADDED r2", "ADDED r1-t1"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Sync: expected events %v, got %v", expected, events)
+	}
+	if r := i.RequestsByOwner("alice"); len(r) != 1 || r[0].ID != "r1" {
+		t.Errorf("RequestsByOwner(alice): expected r1, got %+v", r)
+	}
+	if r := i.RequestsByState("PAUSED"); len(r) != 1 || r[0].ID != "r2" {
+		t.Errorf("RequestsByState(PAUSED): expected r2, got %+v", r)
+	}
+	if tk := i.TasksByRequest("r1"); len(tk) != 1 || tk[0].TaskID.ID != "r1-t1" {
+		t.Errorf("TasksByRequest(r1): expected r1-t1, got %+v", tk)
+	}
+
+	// An unchanged poll sends no events.
+	events = nil
+	if err := i.Sync(); err != nil || len(events) != 0 {
+		t.Errorf("Sync: expected no events, got %v, %v", events, err)
+	}
+
+	mu.Lock()
+	requests = `[{"request":{"id":"r1","owners":["alice"]},"state":"PAUSED"},{"request":{"id":"r3"},"state":"ACTIVE"}]`
+	tasks = `[]`
+	mu.Unlock()
+	if err := i.Sync(); err != nil {
+		t.Fatalf("Sync: expected no error, got %v", err)
+	}
+	expected = []string{"UPDATED r1", "DELETED r2", "ADDED r3", "DELETED r1-t1"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Sync: expected events %v, got %v", expected, events)
+	}
+	if r := i.RequestsByState("PAUSED"); len(r) != 1 || r[0].ID != "r1" {
+		t.Errorf("RequestsByState(PAUSED): expected r1, got %+v", r)
+	}
+	if _, ok := i.GetRequest("r2"); ok {
+		t.Errorf("GetRequest(r2): expected deleted request to be gone")
+	}
+
+	// A failed poll leaves the cache alone.
+	mu.Lock()
+	fail = true
+	mu.Unlock()
+	events = nil
+	if err := i.Sync(); err == nil {
+		t.Errorf("Sync: expected an error, got nil")
+	}
+	if r := i.ListRequests(); len(r) != 2 || len(events) != 0 {
+		t.Errorf("Sync: expected cache kept and no events, got %+v, %v", r, events)
+	}
+}
+
+func TestInformerResync(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/requests":
+			w.Write([]byte(`[{"request":{"id":"r1"}}]`))
+		case "/api/tasks/active":
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer ts.Close()
+
+	var events []string
+	i := NewInformer(newTestClient(ts), time.Minute).
+		SetResync(time.Nanosecond).
+		OnRequest(func(e RequestEvent) {
+			events = append(events, e.Type.String()+" "+e.New.ID)
+		})
+	i.Sync()
+	time.Sleep(time.Millisecond)
+	i.Sync()
+	expected := []string{"ADDED r1", "UPDATED r1"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Sync: expected events %v, got %v", expected, events)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{10, time.Minute},
+	}
+	for _, tt := range tests {
+		if d := backoff(time.Second, time.Minute, tt.failures); d != tt.expected {
+			t.Errorf("backoff(%d): expected %v, got %v", tt.failures, tt.expected, d)
+		}
+	}
+}
+
+func TestNewInformerInterval(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		if i := NewInformer(nil, d); i.interval != defaultPollInterval {
+			t.Errorf("NewInformer(%v): expected interval %v, got %v", d, defaultPollInterval, i.interval)
+		}
+	}
+	if i := NewInformer(nil, time.Minute); i.interval != time.Minute {
+		t.Errorf("NewInformer(%v): expected interval %v, got %v", time.Minute, time.Minute, i.interval)
+	}
+}
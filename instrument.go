@@ -0,0 +1,287 @@
+package singularity
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty"
+)
+
+// Operation describes a single HTTP attempt made by a Client.
+type Operation struct {
+	// Name is the name of the API call, such as GetRequest or ScaleRequest.
+	Name   string
+	Method string
+	// Endpoint is the path template of the call, such as
+	// /api/requests/request/{requestId}, so that it can be used as a
+	// metric label without a series per request.
+	Endpoint string
+	// Retry is 0 for the first attempt of a call, and counts up for each
+	// retry made because of the Retry setting of the Client.
+	Retry int
+}
+
+// Call is a finished HTTP attempt. StatusCode is 0 and Response is nil if no
+// response was received, and Err is set instead.
+type Call struct {
+	Operation
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+
+	// Request and Response are the HTTP request sent and the response
+	// received. Their bodies have already been consumed.
+	Request  *http.Request
+	Response *http.Response
+	// RequestBody and ResponseBody are only set if a BodyHook asks for
+	// them, and hold at most the first maxCapturedBody bytes.
+	RequestBody  []byte
+	ResponseBody []byte
+}
+
+// Hook is told about every HTTP attempt a Client makes. Before is called
+// when it starts, with the context of the call and the request about to be
+// sent, whose headers it may set, such as to propagate a trace context. The
+// context it returns is passed to the next hook, the request is sent with
+// the last hook's, and each hook's own is passed to its After when the
+// response body has been read, so that hooks such as tracers can carry state
+// from one to the other.
+//
+// The context of a call is the one given to Client.WithContext, or
+// context.Background.
+type Hook interface {
+	Before(ctx context.Context, op Operation, r *http.Request) context.Context
+	After(ctx context.Context, call Call)
+}
+
+// BodyHook is a Hook which may need the bodies of each attempt. If
+// CaptureBodies returns true when an attempt starts, the Call given to every
+// hook has RequestBody and ResponseBody set.
+type BodyHook interface {
+	Hook
+	CaptureBodies() bool
+}
+
+// maxCapturedBody limits the bodies kept for a BodyHook.
+const maxCapturedBody = 64 << 10
+
+// attemptHeader counts the attempts of a call between the resty middleware
+// and the transport. It is removed before the request is sent.
+const attemptHeader = "X-Singularity-Client-Attempt"
+
+// AddHook adds a hook which is told about every HTTP attempt c makes. Hooks
+// should be added before c is used.
+//
+// The first hook wraps the transport of c.Rest with one which calls the
+// hooks. Use Client.SetTransport rather than c.Rest.SetTransport to change
+// the transport afterwards.
+func (c *Client) AddHook(h Hook) *Client {
+	if c.hooks == nil {
+		base := c.Rest.GetClient().Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		c.hooks = &hookTransport{base: base}
+		c.Rest.SetTransport(c.hooks)
+		c.Rest.OnBeforeRequest(countAttempt)
+	}
+	c.hooks.hooks = append(c.hooks.hooks, h)
+	return c
+}
+
+// SetTransport sets the transport HTTP requests are sent with, keeping any
+// hooks added with AddHook.
+func (c *Client) SetTransport(rt http.RoundTripper) *Client {
+	if c.hooks == nil {
+		c.Rest.SetTransport(rt)
+		return c
+	}
+	c.hooks.base = rt
+	return c
+}
+
+func countAttempt(_ *resty.Client, r *resty.Request) error {
+	n, _ := strconv.Atoi(r.Header.Get(attemptHeader))
+	r.Header.Set(attemptHeader, strconv.Itoa(n+1))
+	return nil
+}
+
+type hookTransport struct {
+	base  http.RoundTripper
+	hooks []Hook
+}
+
+func (t *hookTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	attempt, _ := strconv.Atoi(r.Header.Get(attemptHeader))
+	op := operationFor(r.Method, r.URL.Path)
+	if attempt > 1 {
+		op.Retry = attempt - 1
+	}
+
+	// The request must not be modified, so the header is dropped from, and
+	// hooks add theirs to, a copy.
+	out := r.WithContext(r.Context())
+	out.Header = make(http.Header, len(r.Header))
+	for k, v := range r.Header {
+		if k != attemptHeader {
+			out.Header[k] = v
+		}
+	}
+
+	ctx := r.Context()
+	ctxs := make([]context.Context, len(t.hooks))
+	capture := false
+	for i, h := range t.hooks {
+		ctx = h.Before(ctx, op, out)
+		ctxs[i] = ctx
+		if b, ok := h.(BodyHook); ok && b.CaptureBodies() {
+			capture = true
+		}
+	}
+	out = out.WithContext(ctx)
+
+	call := Call{Operation: op, Request: out}
+	if capture && r.GetBody != nil {
+		if body, err := r.GetBody(); err == nil {
+			call.RequestBody, _ = ioutil.ReadAll(io.LimitReader(body, maxCapturedBody))
+			body.Close()
+		}
+	}
+	after := func(err error, start time.Time) {
+		call.Err = err
+		call.Duration = time.Since(start)
+		for i, h := range t.hooks {
+			h.After(ctxs[i], call)
+		}
+	}
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(out)
+	if err != nil {
+		after(err, start)
+		return res, err
+	}
+	call.StatusCode = res.StatusCode
+	call.Response = res
+	b := &hookBody{ReadCloser: res.Body}
+	if capture {
+		b.captured = &bytes.Buffer{}
+	}
+	b.done = func(err error) {
+		if b.captured != nil {
+			call.ResponseBody = b.captured.Bytes()
+		}
+		after(err, start)
+	}
+	res.Body = b
+	return res, nil
+}
+
+// hookBody calls done once, when the body is closed or fails to be read,
+// and keeps what was read in captured if it is not nil.
+type hookBody struct {
+	io.ReadCloser
+	once     sync.Once
+	done     func(err error)
+	captured *bytes.Buffer
+}
+
+func (b *hookBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.captured != nil && b.captured.Len() < maxCapturedBody {
+		room := maxCapturedBody - b.captured.Len()
+		if room > n {
+			room = n
+		}
+		b.captured.Write(p[:room])
+	}
+	if err != nil && err != io.EOF {
+		b.once.Do(func() { b.done(err) })
+	}
+	return n, err
+}
+
+func (b *hookBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.done(nil) })
+	return err
+}
+
+type route struct {
+	method, endpoint, name string
+}
+
+// routes are the endpoints the Client calls. Segments in braces match any
+// single path segment.
+var routes = []route{
+	{"GET", "/api/requests", "GetRequests"},
+	{"POST", "/api/requests", "CreateRequest"},
+	{"GET", "/api/requests/request/{requestId}", "GetRequest"},
+	{"DELETE", "/api/requests/request/{requestId}", "DeleteRequest"},
+	{"PUT", "/api/requests/request/{requestId}/scale", "ScaleRequest"},
+	{"DELETE", "/api/requests/request/{requestId}/scale", "CancelExpiringScale"},
+	{"POST", "/api/requests/request/{requestId}/pause", "PauseRequest"},
+	{"POST", "/api/requests/request/{requestId}/unpause", "UnpauseRequest"},
+	{"POST", "/api/requests/request/{requestId}/bounce", "BounceRequest"},
+	{"POST", "/api/requests/request/{requestId}/run", "RunRequest"},
+	{"POST", "/api/deploys", "CreateDeploy"},
+	{"PUT", "/api/deploys/update", "UpdatePendingDeploy"},
+	{"GET", "/api/deploys/pending", "GetPendingDeploys"},
+	{"DELETE", "/api/deploys/deploy/{deployId}/request/{requestId}", "DeleteDeploy"},
+	{"GET", "/api/history/request/{requestId}/deploys", "GetDeployHistories"},
+	{"GET", "/api/history/request/{requestId}/deploy/{deployId}", "GetDeployHistory"},
+	{"GET", "/api/history/request/{requestId}/tasks/active", "GetActiveTasksByRequestID"},
+	{"GET", "/api/history/request/{requestId}/requests", "GetRequestHistory"},
+	{"GET", "/api/tasks/active", "GetActiveTasks"},
+	{"GET", "/api/tasks/scheduled/ids", "GetScheduledTaskIDs"},
+	{"GET", "/api/tasks/cleaning", "GetCleaningTasks"},
+	{"DELETE", "/api/tasks/task/{taskId}", "KillTask"},
+	{"GET", "/api/sandbox/{taskId}/read", "ReadSandboxFile"},
+	{"GET", "/api/slaves", "GetSlaves"},
+	{"GET", "/api/racks", "GetRacks"},
+	{"GET", "/api/webhooks", "GetWebhooks"},
+	{"POST", "/api/webhooks", "CreateWebhook"},
+	{"DELETE", "/api/webhooks", "DeleteWebhook"},
+	{"GET", "/api/webhooks/request", "GetQueuedRequestUpdates"},
+	{"GET", "/api/webhooks/deploy", "GetQueuedDeployUpdates"},
+	{"GET", "/api/webhooks/task", "GetQueuedTaskUpdates"},
+	{"GET", "/api/groups", "GetRequestGroups"},
+	{"POST", "/api/groups", "SaveRequestGroup"},
+	{"GET", "/api/groups/group/{requestGroupId}", "GetRequestGroup"},
+	{"DELETE", "/api/groups/group/{requestGroupId}", "DeleteRequestGroup"},
+}
+
+// operationFor returns the operation of a request path, which may start with
+// the path prefix of the host. Unknown paths are named unknown, so that raw
+// IDs never end up in an Endpoint.
+func operationFor(method, path string) Operation {
+	if i := strings.Index(path, "/api/"); i >= 0 {
+		path = path[i:]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, r := range routes {
+		if r.method == method && matchEndpoint(strings.Split(strings.Trim(r.endpoint, "/"), "/"), segments) {
+			return Operation{Name: r.name, Method: method, Endpoint: r.endpoint}
+		}
+	}
+	return Operation{Name: "unknown", Method: method, Endpoint: "unknown"}
+}
+
+func matchEndpoint(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, t := range template {
+		if !strings.HasPrefix(t, "{") && t != segments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,210 @@
+package singularity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type hookKey struct{}
+
+type recordingHook struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (h *recordingHook) Before(ctx context.Context, op Operation, r *http.Request) context.Context {
+	return context.WithValue(ctx, hookKey{}, op.Name)
+}
+
+func (h *recordingHook) After(ctx context.Context, call Call) {
+	if ctx.Value(hookKey{}) != call.Name {
+		call.Name = "context lost"
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, call)
+}
+
+func TestClientHooks(t *testing.T) {
+	hits := 0
+	var sawHeader bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		sawHeader = sawHeader || r.Header.Get(attemptHeader) != ""
+		if hits == 1 {
+			// Drop the first attempt, so that it is retried.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{"request":{"id":"r1"}}`))
+	}))
+	defer ts.Close()
+
+	h := &recordingHook{}
+	c := NewClient(NewConfig().SetHost(strings.TrimPrefix(ts.URL, "http://")).SetRetry(2).Build()).AddHook(h)
+	if _, err := c.GetRequestByID("r1"); err != nil {
+		t.Fatalf("GetRequestByID: unexpected error %v", err)
+	}
+	if sawHeader {
+		t.Errorf("AddHook: expected the attempt header not to be sent")
+	}
+	if len(h.calls) != 2 {
+		t.Fatalf("AddHook: expected 2 calls, got %+v", h.calls)
+	}
+	first, second := h.calls[0], h.calls[1]
+	if first.Name != "GetRequest" || first.Endpoint != "/api/requests/request/{requestId}" || first.Retry != 0 || first.Err == nil || first.StatusCode != 0 {
+		t.Errorf("AddHook: expected a failed first attempt, got %+v", first)
+	}
+	if second.Name != "GetRequest" || second.Method != "GET" || second.Retry != 1 || second.Err != nil || second.StatusCode != 200 || second.Duration <= 0 {
+		t.Errorf("AddHook: expected a successful retry, got %+v", second)
+	}
+}
+
+type parentKey struct{}
+
+// headerHook passes the parent of each call on in a request header.
+type headerHook struct {
+	recordingHook
+}
+
+func (h *headerHook) Before(ctx context.Context, op Operation, r *http.Request) context.Context {
+	if parent, ok := ctx.Value(parentKey{}).(string); ok {
+		r.Header.Set("X-Parent", parent)
+	}
+	return h.recordingHook.Before(ctx, op, r)
+}
+
+// contextTransport records the hook value of the context each request is
+// sent with.
+type contextTransport struct {
+	names []interface{}
+}
+
+func (t *contextTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.names = append(t.names, r.Context().Value(hookKey{}))
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestClientHooksContext(t *testing.T) {
+	var parent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parent = r.Header.Get("X-Parent")
+		w.Write([]byte(`{"request":{"id":"r1"}}`))
+	}))
+	defer ts.Close()
+
+	rt := &contextTransport{}
+	h := &headerHook{}
+	c := newTestClient(ts).SetTransport(rt).AddHook(h)
+	ctx := context.WithValue(context.Background(), parentKey{}, "span-1")
+	if _, err := c.WithContext(ctx).GetRequestByID("r1"); err != nil {
+		t.Fatalf("GetRequestByID: unexpected error %v", err)
+	}
+	if parent != "span-1" {
+		t.Errorf("WithContext: expected the hook to send parent %s, got %q", "span-1", parent)
+	}
+	if len(rt.names) != 1 || rt.names[0] != "GetRequest" {
+		t.Errorf("AddHook: expected the request to be sent with the hook's context, got %v", rt.names)
+	}
+	if _, err := c.API().WithContext(ctx).Do("GET", "/api/requests/request/r1", nil, nil, nil); err != nil || parent != "span-1" {
+		t.Errorf("API().WithContext: expected the hook to send parent %s, got %q and error %v", "span-1", parent, err)
+	}
+
+	// The client itself is left without a context.
+	if _, err := c.GetRequestByID("r1"); err != nil || parent != "" {
+		t.Errorf("GetRequestByID: expected no parent, got %q and error %v", parent, err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.WithContext(canceled).GetRequestByID("r1"); err == nil {
+		t.Errorf("WithContext: expected error for a canceled context")
+	}
+}
+
+type countingTransport struct {
+	n int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.n++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestClientHooksKeepTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"request":{"id":"r1"}}`))
+	}))
+	defer ts.Close()
+
+	rt := &countingTransport{}
+	c := newTestClient(ts)
+	c.Rest.SetTransport(rt)
+	h := &recordingHook{}
+	c.AddHook(h)
+	if _, err := c.GetRequestByID("r1"); err != nil {
+		t.Fatalf("GetRequestByID: unexpected error %v", err)
+	}
+	if rt.n != 1 || len(h.calls) != 1 {
+		t.Errorf("AddHook: expected the existing transport to be wrapped, got %d round trips and %d calls", rt.n, len(h.calls))
+	}
+}
+
+func TestOperationFor(t *testing.T) {
+	var data = []struct {
+		method, path string
+		name         string
+		endpoint     string
+	}{
+		{"GET", "/api/requests", "GetRequests", "/api/requests"},
+		{"POST", "/singularity/api/requests", "CreateRequest", "/api/requests"},
+		{"PUT", "/api/requests/request/my-service/scale", "ScaleRequest", "/api/requests/request/{requestId}/scale"},
+		{"DELETE", "/api/requests/request/my-service/scale", "CancelExpiringScale", "/api/requests/request/{requestId}/scale"},
+		{"POST", "/api/deploys/", "CreateDeploy", "/api/deploys"},
+		{"DELETE", "/api/deploys/deploy/d1/request/r1", "DeleteDeploy", "/api/deploys/deploy/{deployId}/request/{requestId}"},
+		{"GET", "/api/history/request/r1/deploy/d1", "GetDeployHistory", "/api/history/request/{requestId}/deploy/{deployId}"},
+		{"GET", "/api/groups/group/web", "GetRequestGroup", "/api/groups/group/{requestGroupId}"},
+		{"GET", "/api/requests/request/r1/secret", "unknown", "unknown"},
+		{"GET", "/healthcheck", "unknown", "unknown"},
+	}
+	for _, tt := range data {
+		op := operationFor(tt.method, tt.path)
+		if op.Name != tt.name || op.Endpoint != tt.endpoint || op.Method != tt.method {
+			t.Errorf("operationFor(%s %s): expected %s %s, got %+v", tt.method, tt.path, tt.name, tt.endpoint, op)
+		}
+	}
+}
+
+type bodyHook struct {
+	recordingHook
+}
+
+func (h *bodyHook) CaptureBodies() bool { return true }
+
+func TestClientBodyHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"created":true}`))
+	}))
+	defer ts.Close()
+
+	h := &bodyHook{}
+	c := newTestClient(ts).AddHook(h)
+	if _, err := c.CreateWebhook(SingularityWebhook{URI: "http://hooks.example.com", Type: "TASK"}); err != nil {
+		t.Fatalf("CreateWebhook: unexpected error %v", err)
+	}
+	if len(h.calls) != 1 {
+		t.Fatalf("AddHook: expected 1 call, got %+v", h.calls)
+	}
+	call := h.calls[0]
+	if !strings.Contains(string(call.RequestBody), `"uri":"http://hooks.example.com"`) || string(call.ResponseBody) != `{"created":true}` {
+		t.Errorf("AddHook: expected captured bodies, got %s and %s", call.RequestBody, call.ResponseBody)
+	}
+	if call.Request == nil || call.Request.URL.Path != "/api/webhooks" || call.Response == nil {
+		t.Errorf("AddHook: expected the request and response, got %+v", call)
+	}
+}
@@ -0,0 +1,186 @@
+// Package logging logs the HTTP calls of a singularity.Client to a
+// structured logger, such as a *slog.Logger, with secrets redacted.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// Logger is the part of *slog.Logger which is used. Args are alternating
+// keys and values.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// Redacted replaces the values which are hidden.
+const Redacted = "REDACTED"
+
+// DefaultHeaders are the headers redacted unless SetRedactHeaders is used.
+var DefaultHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultFields are the JSON fields whose values are redacted unless
+// SetRedactFields is used: the environment variables and metadata of
+// deploys, and the environment given to run-now requests and single tasks,
+// wherever they appear in a body.
+var DefaultFields = []string{"env", "metadata", "envOverrides", "taskEnv"}
+
+// Hook logs every HTTP attempt of a Client: its operation, method, path,
+// status and duration at info level, or at warn level if it failed. With
+// SetLogBodies, the headers and bodies are also logged at debug level.
+type Hook struct {
+	logger  Logger
+	bodies  bool
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+var _ singularity.BodyHook = (*Hook)(nil)
+
+// NewHook returns a Hook which logs to l. Add it to a Client with AddHook.
+func NewHook(l Logger) *Hook {
+	return (&Hook{logger: l}).
+		SetRedactHeaders(DefaultHeaders...).
+		SetRedactFields(DefaultFields...)
+}
+
+// SetLogBodies logs the headers and bodies of each attempt at debug level.
+func (h *Hook) SetLogBodies(b bool) *Hook {
+	h.bodies = b
+	return h
+}
+
+// SetRedactHeaders sets the headers whose values are hidden, in place of
+// DefaultHeaders.
+func (h *Hook) SetRedactHeaders(names ...string) *Hook {
+	h.headers = map[string]bool{}
+	for _, n := range names {
+		h.headers[http.CanonicalHeaderKey(n)] = true
+	}
+	return h
+}
+
+// SetRedactFields sets the JSON object fields, matched case-insensitively,
+// whose values are hidden in logged bodies, in place of DefaultFields. If
+// the value is an object, each of its values is hidden and its keys kept.
+func (h *Hook) SetRedactFields(names ...string) *Hook {
+	h.fields = map[string]bool{}
+	for _, n := range names {
+		h.fields[strings.ToLower(n)] = true
+	}
+	return h
+}
+
+// CaptureBodies returns true if bodies are logged.
+func (h *Hook) CaptureBodies() bool {
+	return h.bodies
+}
+
+// Before does nothing; attempts are logged when they finish.
+func (h *Hook) Before(ctx context.Context, op singularity.Operation, r *http.Request) context.Context {
+	return ctx
+}
+
+// After logs a finished attempt.
+func (h *Hook) After(ctx context.Context, call singularity.Call) {
+	args := []interface{}{
+		"operation", call.Name,
+		"method", call.Method,
+		"path", call.Request.URL.Path,
+		"status", call.StatusCode,
+		"duration", call.Duration,
+	}
+	if call.Retry > 0 {
+		args = append(args, "retry", call.Retry)
+	}
+	switch {
+	case call.Err != nil:
+		h.logger.WarnContext(ctx, "singularity request failed", append(args, "error", call.Err.Error())...)
+	case call.StatusCode >= 400:
+		h.logger.WarnContext(ctx, "singularity request", args...)
+	default:
+		h.logger.InfoContext(ctx, "singularity request", args...)
+	}
+
+	if !h.bodies {
+		return
+	}
+	debug := []interface{}{
+		"operation", call.Name,
+		"request_headers", h.redactHeader(call.Request.Header),
+		"request_body", h.redactBody(call.RequestBody),
+	}
+	if call.Response != nil {
+		debug = append(debug,
+			"response_headers", h.redactHeader(call.Response.Header),
+			"response_body", h.redactBody(call.ResponseBody))
+	}
+	h.logger.DebugContext(ctx, "singularity request body", debug...)
+}
+
+func (h *Hook) redactHeader(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for k, v := range header {
+		if h.headers[http.CanonicalHeaderKey(k)] {
+			out[k] = Redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// redactBody hides the values of redacted fields in a JSON body. A body
+// which is not valid JSON, including one cut short by the capture limit,
+// can't be redacted, so only its size is logged.
+func (h *Hook) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return notRedacted(body)
+	}
+	data, err := json.Marshal(h.redact(v))
+	if err != nil {
+		return notRedacted(body)
+	}
+	return string(data)
+}
+
+func notRedacted(body []byte) string {
+	return fmt.Sprintf("<%d bytes, not redacted>", len(body))
+}
+
+func (h *Hook) redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if !h.fields[strings.ToLower(k)] {
+				v[k] = h.redact(e)
+				continue
+			}
+			if m, ok := e.(map[string]interface{}); ok {
+				for mk := range m {
+					m[mk] = Redacted
+				}
+				continue
+			}
+			if e != nil {
+				v[k] = Redacted
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = h.redact(e)
+		}
+	}
+	return v
+}
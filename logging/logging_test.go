@@ -0,0 +1,153 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+type record struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+type fakeLogger struct {
+	records []record
+}
+
+func (l *fakeLogger) log(level, msg string, args []interface{}) {
+	r := record{level: level, msg: msg, attrs: map[string]interface{}{}}
+	for i := 0; i+1 < len(args); i += 2 {
+		r.attrs[args[i].(string)] = args[i+1]
+	}
+	l.records = append(l.records, r)
+}
+
+func (l *fakeLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("debug", msg, args)
+}
+func (l *fakeLogger) InfoContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("info", msg, args)
+}
+func (l *fakeLogger) WarnContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("warn", msg, args)
+}
+
+func call(status int, err error) singularity.Call {
+	req := &http.Request{
+		URL:    &url.URL{Path: "/api/deploys"},
+		Header: http.Header{"Authorization": {"Bearer secret"}, "Content-Type": {"application/json"}},
+	}
+	c := singularity.Call{
+		Operation:   singularity.Operation{Name: "CreateDeploy", Method: "POST", Endpoint: "/api/deploys"},
+		StatusCode:  status,
+		Err:         err,
+		Duration:    20 * time.Millisecond,
+		Request:     req,
+		RequestBody: []byte(`{"deploy":{"id":"d1","env":{"DB_PASSWORD":"hunter2","LOG_LEVEL":"info"},"metadata":{"owner":"alice"}}}`),
+	}
+	if err == nil {
+		c.Response = &http.Response{StatusCode: status, Header: http.Header{"Set-Cookie": {"session=abc"}}}
+		c.ResponseBody = []byte(`[{"env":{"TOKEN":"x"}}]`)
+	}
+	return c
+}
+
+func TestHook(t *testing.T) {
+	l := &fakeLogger{}
+	h := NewHook(l)
+	h.After(context.Background(), call(200, nil))
+	h.After(context.Background(), call(0, errors.New("connection refused")))
+
+	if len(l.records) != 2 {
+		t.Fatalf("After: expected 2 records without bodies, got %+v", l.records)
+	}
+	ok := l.records[0]
+	if ok.level != "info" || ok.attrs["path"] != "/api/deploys" || ok.attrs["status"] != 200 || ok.attrs["duration"] != 20*time.Millisecond {
+		t.Errorf("After: expected an info record, got %+v", ok)
+	}
+	if failed := l.records[1]; failed.level != "warn" || failed.attrs["error"] != "connection refused" {
+		t.Errorf("After: expected a warn record, got %+v", failed)
+	}
+}
+
+func TestHookBodies(t *testing.T) {
+	l := &fakeLogger{}
+	h := NewHook(l).SetLogBodies(true)
+	if !h.CaptureBodies() {
+		t.Errorf("CaptureBodies: expected true")
+	}
+	h.After(context.Background(), call(200, nil))
+	if len(l.records) != 2 || l.records[1].level != "debug" {
+		t.Fatalf("After: expected a debug record, got %+v", l.records)
+	}
+	d := l.records[1].attrs
+	all := fmt.Sprint(d)
+	for _, secret := range []string{"hunter2", "Bearer secret", "session=abc", `"TOKEN":"x"`, "alice"} {
+		if strings.Contains(all, secret) {
+			t.Errorf("After: expected %q redacted, got %v", secret, all)
+		}
+	}
+	expected := `{"deploy":{"env":{"DB_PASSWORD":"REDACTED","LOG_LEVEL":"REDACTED"},"id":"d1","metadata":{"owner":"REDACTED"}}}`
+	if d["request_body"] != expected {
+		t.Errorf("After: expected request body %s, got %s", expected, d["request_body"])
+	}
+	if h := d["request_headers"].(map[string]string); h["Content-Type"] != "application/json" || h["Authorization"] != Redacted {
+		t.Errorf("After: expected Authorization redacted, got %v", h)
+	}
+
+	l.records = nil
+	NewHook(l).SetLogBodies(true).SetRedactHeaders().SetRedactFields("metadata").After(context.Background(), call(200, nil))
+	d = l.records[1].attrs
+	if !strings.Contains(d["request_body"].(string), "hunter2") || strings.Contains(d["request_body"].(string), "alice") {
+		t.Errorf("SetRedactFields(metadata): expected only metadata redacted, got %s", d["request_body"])
+	}
+	if d["request_headers"].(map[string]string)["Authorization"] != "Bearer secret" {
+		t.Errorf("SetRedactHeaders(): expected no headers redacted, got %v", d["request_headers"])
+	}
+}
+
+func TestHookBodiesNotRedacted(t *testing.T) {
+	l := &fakeLogger{}
+	h := NewHook(l).SetLogBodies(true)
+	c := call(200, nil)
+	c.RequestBody = []byte(`{"runId":"r1","envOverrides":{"TOKEN":"x"},"taskEnv":{"0":{"TOKEN":"y"}}}`)
+	c.ResponseBody = []byte("TOKEN=z")
+	h.After(context.Background(), c)
+	d := l.records[1].attrs
+	if expected := `{"envOverrides":{"TOKEN":"REDACTED"},"runId":"r1","taskEnv":{"0":"REDACTED"}}`; d["request_body"] != expected {
+		t.Errorf("After: expected request body %s, got %s", expected, d["request_body"])
+	}
+	if d["response_body"] != "<7 bytes, not redacted>" {
+		t.Errorf("After: expected a placeholder for a body which is not JSON, got %s", d["response_body"])
+	}
+
+	// A listing larger than the capture limit is cut short, and so is not
+	// valid JSON.
+	var listing []string
+	for len(strings.Join(listing, ",")) <= 64<<10 {
+		listing = append(listing, fmt.Sprintf(`{"request":{"id":"r%d"},"activeDeploy":{"env":{"DB_PASSWORD":"hunter2"}}}`, len(listing)))
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[" + strings.Join(listing, ",") + "]"))
+	}))
+	defer ts.Close()
+	l.records = nil
+	client := singularity.NewClient(singularity.NewConfig().SetHost(strings.TrimPrefix(ts.URL, "http://")).Build()).AddHook(h)
+	if _, _, err := client.GetRequests(); err != nil {
+		t.Fatalf("GetRequests: unexpected error %v", err)
+	}
+	body := l.records[1].attrs["response_body"].(string)
+	if strings.Contains(body, "hunter2") || body != fmt.Sprintf("<%d bytes, not redacted>", 64<<10) {
+		t.Errorf("After: expected a placeholder for a truncated body, got %.100s", body)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build go1.21
+// +build go1.21
+
+package logging
+
+import "log/slog"
+
+// *slog.Logger must keep satisfying Logger.
+var _ Logger = slog.Default()
@@ -0,0 +1,561 @@
+package singularity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ManifestVersion is the manifest schema version understood by this package.
+const ManifestVersion = "v1"
+
+// Manifest describes a Singularity request and, optionally, the deploy
+// attached to it. It can be written in either YAML or JSON.
+type Manifest struct {
+	Version string          `json:"version" yaml:"version"`
+	Request ManifestRequest `json:"request" yaml:"request"`
+	Deploy  *ManifestDeploy `json:"deploy,omitempty" yaml:"deploy,omitempty"`
+}
+
+// ManifestRequest contains the fields of a Singularity request which can be
+// set from a manifest.
+type ManifestRequest struct {
+	ID                  string   `json:"id" yaml:"id"`
+	Type                string   `json:"type" yaml:"type"` // SERVICE, WORKER, SCHEDULED, ON_DEMAND or RUN_ONCE.
+	Instances           int64    `json:"instances,omitempty" yaml:"instances,omitempty"`
+	Schedule            string   `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	ScheduleType        string   `json:"scheduleType,omitempty" yaml:"scheduleType,omitempty"` // CRON or QUARTZ.
+	QuartzSchedule      string   `json:"quartzSchedule,omitempty" yaml:"quartzSchedule,omitempty"`
+	ScheduleTimeZone    string   `json:"scheduleTimeZone,omitempty" yaml:"scheduleTimeZone,omitempty"`
+	NumRetriesOnFailure int64    `json:"numRetriesOnFailure,omitempty" yaml:"numRetriesOnFailure,omitempty"`
+	MaxTasksPerOffer    int      `json:"maxTasksPerOffer,omitempty" yaml:"maxTasksPerOffer,omitempty"`
+	SlavePlacement      string   `json:"slavePlacement,omitempty" yaml:"slavePlacement,omitempty"`
+	Owners              []string `json:"owners,omitempty" yaml:"owners,omitempty"`
+	RequiredRole        string   `json:"requiredRole,omitempty" yaml:"requiredRole,omitempty"`
+	RackSensitive       bool     `json:"rackSensitive,omitempty" yaml:"rackSensitive,omitempty"`
+	LoadBalanced        bool     `json:"loadBalanced,omitempty" yaml:"loadBalanced,omitempty"`
+	SkipHealthchecks    bool     `json:"skipHealthchecks,omitempty" yaml:"skipHealthchecks,omitempty"`
+}
+
+// ManifestDeploy contains the fields of a Singularity deploy which can be
+// set from a manifest. The request ID is taken from the enclosing manifest.
+type ManifestDeploy struct {
+	ID                                    string             `json:"id" yaml:"id"`
+	Version                               string             `json:"version,omitempty" yaml:"version,omitempty"`
+	Command                               string             `json:"command,omitempty" yaml:"command,omitempty"`
+	Arguments                             []string           `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+	Env                                   map[string]string  `json:"env,omitempty" yaml:"env,omitempty"`
+	Metadata                              map[string]string  `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Labels                                map[string]string  `json:"labels,omitempty" yaml:"labels,omitempty"`
+	User                                  string             `json:"user,omitempty" yaml:"user,omitempty"`
+	Resources                             *ManifestResources `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Container                             *ManifestContainer `json:"container,omitempty" yaml:"container,omitempty"`
+	URIs                                  []ManifestArtifact `json:"uris,omitempty" yaml:"uris,omitempty"`
+	ServiceBasePath                       string             `json:"serviceBasePath,omitempty" yaml:"serviceBasePath,omitempty"`
+	CustomExecutorID                      string             `json:"customExecutorId,omitempty" yaml:"customExecutorId,omitempty"`
+	CustomExecutorSource                  string             `json:"customExecutorSource,omitempty" yaml:"customExecutorSource,omitempty"`
+	AutoAdvanceDeploySteps                bool               `json:"autoAdvanceDeploySteps,omitempty" yaml:"autoAdvanceDeploySteps,omitempty"`
+	DeployInstanceCountPerStep            int                `json:"deployInstanceCountPerStep,omitempty" yaml:"deployInstanceCountPerStep,omitempty"`
+	DeployStepWaitTimeMs                  int                `json:"deployStepWaitTimeMs,omitempty" yaml:"deployStepWaitTimeMs,omitempty"`
+	MaxTaskRetries                        int                `json:"maxTaskRetries,omitempty" yaml:"maxTaskRetries,omitempty"`
+	DeployHealthTimeoutSeconds            int64              `json:"deployHealthTimeoutSeconds,omitempty" yaml:"deployHealthTimeoutSeconds,omitempty"`
+	ConsiderHealthyAfterRunningForSeconds int64              `json:"considerHealthyAfterRunningForSeconds,omitempty" yaml:"considerHealthyAfterRunningForSeconds,omitempty"`
+	SkipHealthchecksOnDeploy              bool               `json:"skipHealthchecksOnDeploy,omitempty" yaml:"skipHealthchecksOnDeploy,omitempty"`
+}
+
+// ManifestResources contains the resources requested by a deploy.
+type ManifestResources struct {
+	Cpus     float64 `json:"cpus,omitempty" yaml:"cpus,omitempty"`
+	MemoryMb float64 `json:"memoryMb,omitempty" yaml:"memoryMb,omitempty"`
+	DiskMb   float64 `json:"diskMb,omitempty" yaml:"diskMb,omitempty"`
+	NumPorts int64   `json:"numPorts,omitempty" yaml:"numPorts,omitempty"`
+}
+
+// ManifestContainer describes the container a deploy runs in.
+type ManifestContainer struct {
+	Type    string           `json:"type" yaml:"type"` // DOCKER or MESOS.
+	Docker  *ManifestDocker  `json:"docker,omitempty" yaml:"docker,omitempty"`
+	Mesos   *ManifestMesos   `json:"mesos,omitempty" yaml:"mesos,omitempty"`
+	Volumes []ManifestVolume `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+}
+
+// ManifestMesos contains Mesos containerizer settings.
+type ManifestMesos struct {
+	Image *ManifestMesosImage `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+// ManifestMesosImage is an image run by the Mesos containerizer. ID is only
+// used by APPC images.
+type ManifestMesosImage struct {
+	Type   string `json:"type" yaml:"type"` // DOCKER or APPC.
+	Name   string `json:"name" yaml:"name"`
+	ID     string `json:"id,omitempty" yaml:"id,omitempty"`
+	Cached bool   `json:"cached,omitempty" yaml:"cached,omitempty"`
+}
+
+// ManifestDocker contains Docker specific container settings.
+type ManifestDocker struct {
+	Image          string                `json:"image" yaml:"image"`
+	Network        string                `json:"network,omitempty" yaml:"network,omitempty"`
+	ForcePullImage bool                  `json:"forcePullImage,omitempty" yaml:"forcePullImage,omitempty"`
+	Privileged     bool                  `json:"privileged,omitempty" yaml:"privileged,omitempty"`
+	Parameters     map[string]string     `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	PortMappings   []ManifestPortMapping `json:"portMappings,omitempty" yaml:"portMappings,omitempty"`
+}
+
+// ManifestPortMapping maps a container port to a host port.
+type ManifestPortMapping struct {
+	ContainerPort     int    `json:"containerPort" yaml:"containerPort"`
+	ContainerPortType string `json:"containerPortType,omitempty" yaml:"containerPortType,omitempty"`
+	HostPort          int    `json:"hostPort" yaml:"hostPort"`
+	HostPortType      string `json:"hostPortType,omitempty" yaml:"hostPortType,omitempty"`
+	Protocol          string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+}
+
+// ManifestVolume mounts a host path into the container.
+type ManifestVolume struct {
+	HostPath      string `json:"hostPath" yaml:"hostPath"`
+	ContainerPath string `json:"containerPath" yaml:"containerPath"`
+	Mode          string `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// ManifestArtifact is a URI to download before executing the deploy command.
+type ManifestArtifact struct {
+	URI        string `json:"uri" yaml:"uri"`
+	Cache      bool   `json:"cache,omitempty" yaml:"cache,omitempty"`
+	Extract    bool   `json:"extract,omitempty" yaml:"extract,omitempty"`
+	Executable bool   `json:"executable,omitempty" yaml:"executable,omitempty"`
+}
+
+// LoadManifest reads a YAML or JSON manifest from path. See ParseManifest.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Read Singularity manifest error: %v", err)
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return m, nil
+}
+
+// ParseManifest accepts a YAML or JSON manifest and returns a Manifest.
+// Unknown fields are an error which includes the line number of the field.
+// ${VAR} and ${VAR:-default} references in string values are then replaced
+// with the value of environment variables, and $$ is a literal $. Values are
+// replaced after parsing, so they can't change the structure of the
+// manifest, and references in comments are ignored.
+func ParseManifest(data []byte) (*Manifest, error) {
+	// JSON is a subset of YAML, so a single strict YAML decoder handles both
+	// formats and reports line numbers for either.
+	var m Manifest
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return nil, fmt.Errorf("Parse Singularity manifest error: %v", err)
+	}
+	if err := interpolateFields(reflect.ValueOf(&m), "", os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("Parse Singularity manifest error: unsupported version %q, expected %q", m.Version, ManifestVersion)
+	}
+	return &m, nil
+}
+
+// interpolateFields interpolates every string in v, which is reached by the
+// manifest field path, and names that field in errors. Map keys are kept.
+func interpolateFields(v reflect.Value, path string, lookup func(string) (string, bool)) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return interpolateFields(v.Elem(), path, lookup)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+			if path != "" {
+				name = path + "." + name
+			}
+			if err := interpolateFields(v.Field(i), name, lookup); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := interpolateFields(v.Index(i), fmt.Sprintf("%s[%d]", path, i), lookup); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			if err := interpolateFields(e, path+"."+k.String(), lookup); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+	case reflect.String:
+		s, err := interpolate(v.String(), lookup)
+		if err != nil {
+			return fmt.Errorf("Parse Singularity manifest error: %s: %v", path, err)
+		}
+		v.SetString(s)
+	}
+	return nil
+}
+
+// interpolate replaces ${VAR} and ${VAR:-default} in s using lookup.
+func interpolate(s string, lookup func(string) (string, bool)) (string, error) {
+	var b bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference %q", s[i:])
+			}
+			name := s[i+2 : i+end]
+			def, hasDef := "", false
+			if j := strings.Index(name, ":-"); j >= 0 {
+				name, def, hasDef = name[:j], name[j+2:], true
+			}
+			v, ok := lookup(name)
+			switch {
+			case ok && (v != "" || !hasDef):
+				b.WriteString(v)
+			case hasDef:
+				b.WriteString(def)
+			default:
+				return "", fmt.Errorf("environment variable %s is not set", name)
+			}
+			i += end
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// BuildRequest returns a ServiceRequest built from this manifest's request.
+func (m *Manifest) BuildRequest() (ServiceRequest, error) {
+	mr := m.Request
+	t, err := ParseRequestType(mr.Type)
+	if err != nil {
+		return nil, fmt.Errorf("Build Singularity request error: %v", err)
+	}
+
+	r := NewRequest(t, mr.ID)
+	if mr.ScheduleType != "" {
+		if r, err = r.SetScheduleType(mr.ScheduleType); err != nil {
+			return nil, err
+		}
+	}
+	if mr.Schedule != "" {
+		if r, err = r.SetSchedule(mr.Schedule); err != nil {
+			return nil, err
+		}
+	}
+	if mr.QuartzSchedule != "" {
+		if r, err = r.SetQuartzSchedule(mr.QuartzSchedule); err != nil {
+			return nil, err
+		}
+	}
+	if mr.ScheduleTimeZone != "" {
+		if r, err = r.SetScheduleTimeZone(mr.ScheduleTimeZone); err != nil {
+			return nil, err
+		}
+	}
+	if mr.Instances != 0 {
+		r.SetInstances(mr.Instances)
+	}
+	if mr.SlavePlacement != "" {
+		p, err := ParseSlavePlacement(mr.SlavePlacement)
+		if err != nil {
+			return nil, fmt.Errorf("Build Singularity request error: %v", err)
+		}
+		r.SetSlavePlacement(p)
+	}
+	r.SetNumRetriesOnFailures(mr.NumRetriesOnFailure).
+		SetMaxTasksPerOffer(mr.MaxTasksPerOffer)
+
+	req := r.(*SingularityRequest)
+	req.Owners = mr.Owners
+	req.RequiredRole = mr.RequiredRole
+	req.RackSensitive = mr.RackSensitive
+	req.LoadBalanced = mr.LoadBalanced
+	req.SkipHealthchecks = mr.SkipHealthchecks
+	return r, nil
+}
+
+// BuildDeploy returns a Deploy built from this manifest's deploy, attached to
+// this manifest's request.
+func (m *Manifest) BuildDeploy() (Deploy, error) {
+	md := m.Deploy
+	if md == nil {
+		return nil, fmt.Errorf("Build Singularity deploy error: manifest for request %s has no deploy", m.Request.ID)
+	}
+
+	d := NewDeploy(md.ID).
+		SetRequestID(m.Request.ID).
+		SetVersion(md.Version).
+		SetCommand(md.Command).
+		SetArgs(md.Arguments...).
+		SetUser(md.User).
+		SetServiceBasePath(md.ServiceBasePath).
+		SetCustomExecutorID(md.CustomExecutorID).
+		SetCustomExecutorSource(md.CustomExecutorSource).
+		SetAutoAdvanceDeploySteps(md.AutoAdvanceDeploySteps).
+		SetDeployInstanceCountPerStep(md.DeployInstanceCountPerStep).
+		SetDeployStepWaitTimeMs(md.DeployStepWaitTimeMs).
+		SetMaxTaskRetries(md.MaxTaskRetries).
+		SetDeployHealthTimeoutSeconds(md.DeployHealthTimeoutSeconds).
+		SetConsiderHealthyAfterRunningForSeconds(md.ConsiderHealthyAfterRunningForSeconds).
+		SetSkipHealthchecksOnDeploy(md.SkipHealthchecksOnDeploy)
+
+	if md.Env != nil {
+		d.SetEnv(md.Env)
+	}
+	if md.Metadata != nil {
+		d.SetMetadata(md.Metadata)
+	}
+	if md.Labels != nil {
+		d.SetLabels(md.Labels)
+	}
+	if md.Resources != nil {
+		d.SetResources(SingularityDeployResources{
+			Cpus:     md.Resources.Cpus,
+			MemoryMb: md.Resources.MemoryMb,
+			DiskMb:   md.Resources.DiskMb,
+			NumPorts: md.Resources.NumPorts,
+		})
+	}
+	if len(md.URIs) > 0 {
+		uris := make([]SingularityMesosArtifact, 0, len(md.URIs))
+		for _, u := range md.URIs {
+			uris = append(uris, SingularityMesosArtifact{
+				URI:        u.URI,
+				Cache:      u.Cache,
+				Extract:    u.Extract,
+				Executable: u.Executable,
+			})
+		}
+		d.SetURIs(uris)
+	}
+	if md.Container != nil {
+		if _, err := d.SetContainerInfo(md.Container.containerInfo()); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (c *ManifestContainer) containerInfo() ContainerInfo {
+	info := ContainerInfo{
+		Type: strings.ToUpper(c.Type),
+	}
+	for _, v := range c.Volumes {
+		info.Volumes = append(info.Volumes, SingularityVolume{
+			HostPath:      v.HostPath,
+			ContainerPath: v.ContainerPath,
+			Mode:          v.Mode,
+		})
+	}
+	if c.Docker != nil {
+		info.DockerInfo = DockerInfo{
+			Image:          c.Docker.Image,
+			Network:        c.Docker.Network,
+			ForcePullImage: c.Docker.ForcePullImage,
+			Privileged:     c.Docker.Privileged,
+			Parameters:     c.Docker.Parameters,
+		}
+		for _, p := range c.Docker.PortMappings {
+			info.DockerInfo.PortMappings = append(info.DockerInfo.PortMappings, DockerPortMapping{
+				ContainerPort:     p.ContainerPort,
+				ContainerPortType: p.ContainerPortType,
+				HostPort:          p.HostPort,
+				HostPortType:      p.HostPortType,
+				Protocol:          p.Protocol,
+			})
+		}
+	}
+	if c.Mesos != nil {
+		info.Mesos = &SingularityMesosInfo{}
+		if i := c.Mesos.Image; i != nil {
+			switch strings.ToUpper(i.Type) {
+			case "APPC":
+				info.Mesos.Image = NewMesosAppcImage(i.Name, i.ID)
+			case "DOCKER":
+				info.Mesos.Image = NewMesosDockerImage(i.Name)
+			default:
+				info.Mesos.Image = &SingularityMesosImage{Type: strings.ToUpper(i.Type)}
+			}
+			info.Mesos.Image.Cached = i.Cached
+		}
+	}
+	return info
+}
+
+// NewManifest returns a Manifest describing an existing Singularity request
+// and its active deploy, if it has one.
+func NewManifest(r Request) *Manifest {
+	m := &Manifest{
+		Version: ManifestVersion,
+		Request: ManifestRequest{
+			ID:                  r.SingularityRequest.ID,
+			Type:                r.RequestType.String(),
+			Instances:           r.Instances,
+			Schedule:            r.Schedule,
+			ScheduleType:        r.ScheduleType,
+			QuartzSchedule:      r.QuartzSchedule,
+			ScheduleTimeZone:    r.ScheduleTimeZone,
+			NumRetriesOnFailure: r.NumRetriesOnFailure,
+			MaxTasksPerOffer:    r.MaxTasksPerOffer,
+			Owners:              r.Owners,
+			RequiredRole:        r.RequiredRole,
+			RackSensitive:       r.RackSensitive,
+			LoadBalanced:        r.LoadBalanced,
+			SkipHealthchecks:    r.SkipHealthchecks,
+		},
+	}
+	if r.SlavePlacement != nil {
+		m.Request.SlavePlacement = r.SlavePlacement.String()
+	}
+
+	a := r.ActiveDeploy
+	if a.ID == "" {
+		return m
+	}
+	md := &ManifestDeploy{
+		ID:                                    a.ID,
+		Version:                               a.Version,
+		Command:                               a.Command,
+		Arguments:                             a.Arguments,
+		Env:                                   a.Env,
+		Metadata:                              a.Metadata,
+		Labels:                                a.Labels,
+		User:                                  a.User,
+		ServiceBasePath:                       a.ServiceBasePath,
+		CustomExecutorID:                      a.CustomExecutorID,
+		CustomExecutorSource:                  a.CustomExecutorSource,
+		AutoAdvanceDeploySteps:                a.AutoAdvanceDeploySteps,
+		DeployInstanceCountPerStep:            a.DeployInstanceCountPerStep,
+		DeployStepWaitTimeMs:                  int(a.DeployStepWaitTimeMs),
+		MaxTaskRetries:                        a.MaxTaskRetries,
+		DeployHealthTimeoutSeconds:            a.DeployHealthTimeoutSeconds,
+		ConsiderHealthyAfterRunningForSeconds: a.ConsiderHealthyAfterRunningForSeconds,
+		SkipHealthchecksOnDeploy:              a.SkipHealthchecksOnDeploy,
+	}
+	if a.SingularityDeployResources != (SingularityDeployResources{}) {
+		md.Resources = &ManifestResources{
+			Cpus:     a.Cpus,
+			MemoryMb: a.MemoryMb,
+			DiskMb:   a.DiskMb,
+			NumPorts: a.NumPorts,
+		}
+	}
+	for _, u := range a.Uris {
+		md.URIs = append(md.URIs, ManifestArtifact{
+			URI:        u.URI,
+			Cache:      u.Cache,
+			Extract:    u.Extract,
+			Executable: u.Executable,
+		})
+	}
+	if a.ContainerInfo.Type != "" {
+		md.Container = newManifestContainer(a.ContainerInfo)
+	}
+	m.Deploy = md
+	return m
+}
+
+func newManifestContainer(c ContainerInfo) *ManifestContainer {
+	mc := &ManifestContainer{
+		Type: c.Type,
+	}
+	for _, v := range c.Volumes {
+		mc.Volumes = append(mc.Volumes, ManifestVolume{
+			HostPath:      v.HostPath,
+			ContainerPath: v.ContainerPath,
+			Mode:          v.Mode,
+		})
+	}
+	if c.Mesos != nil {
+		mc.Mesos = &ManifestMesos{}
+		if i := c.Mesos.Image; i != nil {
+			mc.Mesos.Image = &ManifestMesosImage{
+				Type:   i.Type,
+				Cached: i.Cached,
+			}
+			if i.Docker != nil {
+				mc.Mesos.Image.Name = i.Docker.Name
+			}
+			if i.Appc != nil {
+				mc.Mesos.Image.Name = i.Appc.Name
+				mc.Mesos.Image.ID = i.Appc.ID
+			}
+		}
+	}
+	d := c.DockerInfo
+	if d.Image == "" {
+		return mc
+	}
+	mc.Docker = &ManifestDocker{
+		Image:          d.Image,
+		Network:        d.Network,
+		ForcePullImage: d.ForcePullImage,
+		Privileged:     d.Privileged,
+		Parameters:     d.Parameters,
+	}
+	for _, p := range d.PortMappings {
+		mc.Docker.PortMappings = append(mc.Docker.PortMappings, ManifestPortMapping{
+			ContainerPort:     p.ContainerPort,
+			ContainerPortType: p.ContainerPortType,
+			HostPort:          p.HostPort,
+			HostPortType:      p.HostPortType,
+			Protocol:          p.Protocol,
+		})
+	}
+	return mc
+}
+
+// ExportManifest retrieves a Singularity request by ID and returns it, with
+// its active deploy, as a Manifest.
+func (c *Client) ExportManifest(id string) (*Manifest, error) {
+	res, err := c.GetRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if res.RestyResponse.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("Export Singularity manifest error: request %s not found", id)
+	}
+	return NewManifest(res.Body), nil
+}
+
+// YAML returns this manifest encoded as YAML.
+func (m *Manifest) YAML() ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+// JSON returns this manifest encoded as indented JSON.
+func (m *Manifest) JSON() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
@@ -0,0 +1,440 @@
+package singularity
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testManifestYAML = `version: v1
+request:
+  id: my-service
+  type: SERVICE
+  instances: 3
+  owners: [team@example.com]
+deploy:
+  id: ${DEPLOY_ID}
+  command: ./run.sh
+  env:
+    LOG_LEVEL: ${LOG_LEVEL:-info}
+  resources:
+    cpus: 0.5
+    memoryMb: 128
+  container:
+    type: DOCKER
+    docker:
+      image: golang:latest
+      network: BRIDGE
+`
+
+const testManifestJSON = `{
+	"version": "v1",
+	"request": {
+		"id": "my-job",
+		"type": "SCHEDULED",
+		"schedule": "*/5 * * * *"
+	}
+}`
+
+func TestParseManifest(t *testing.T) {
+	os.Setenv("DEPLOY_ID", "d1")
+	defer os.Unsetenv("DEPLOY_ID")
+
+	m, err := ParseManifest([]byte(testManifestYAML))
+	if err != nil {
+		t.Fatalf("ParseManifest: unexpected error %v", err)
+	}
+	if m.Request.ID != "my-service" {
+		t.Errorf("ParseManifest: expected %s, got %s", "my-service", m.Request.ID)
+	}
+	if m.Deploy.ID != "d1" {
+		t.Errorf("ParseManifest: expected %s, got %s", "d1", m.Deploy.ID)
+	}
+	if m.Deploy.Env["LOG_LEVEL"] != "info" {
+		t.Errorf("ParseManifest: expected %s, got %s", "info", m.Deploy.Env["LOG_LEVEL"])
+	}
+
+	m, err = ParseManifest([]byte(testManifestJSON))
+	if err != nil {
+		t.Fatalf("ParseManifest: unexpected error %v", err)
+	}
+	if m.Request.Schedule != "*/5 * * * *" {
+		t.Errorf("ParseManifest: expected %s, got %s", "*/5 * * * *", m.Request.Schedule)
+	}
+	if m.Deploy != nil {
+		t.Errorf("ParseManifest: expected no deploy, got %v", m.Deploy)
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	os.Unsetenv("MISSING_VAR")
+
+	var data = []struct {
+		manifest      string
+		expectedError string
+	}{
+		{"version: v1\nrequest:\n  id: a\n  typo: SERVICE\n", "line 4: field typo not found"},
+		{"{\n\t\"version\": \"v1\",\n\t\"request\": {\n\t\t\"id\": \"a\",\n\t\t\"typo\": 1\n\t}\n}", "line 5: field typo not found"},
+		{"version: v2\nrequest:\n  id: a\n", "unsupported version"},
+		{"request:\n  id: a\n", "unsupported version"},
+		{"version: v1\nrequest:\n  id: ${MISSING_VAR}\n", "request.id: environment variable MISSING_VAR is not set"},
+		{"version: v1\nrequest:\n  id: ${MISSING_VAR\n", "request.id: unterminated variable reference"},
+		{"version: v1\nrequest:\n  id: a\ndeploy:\n  id: d\n  env:\n    A: ${MISSING_VAR}\n", "deploy.env.A: environment variable MISSING_VAR is not set"},
+		{"version: v1\nrequest:\n  id: a\n  owners: [ok, '${MISSING_VAR}']\n", "request.owners[1]: environment"},
+	}
+
+	for _, tt := range data {
+		_, err := ParseManifest([]byte(tt.manifest))
+		if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+			t.Errorf("ParseManifest(%q): expected error containing %q, got %v", tt.manifest, tt.expectedError, err)
+		}
+	}
+}
+
+func TestParseManifestInterpolation(t *testing.T) {
+	os.Setenv("INJECT", "x\n  type: WORKER\n  instances: 9 # comment")
+	defer os.Unsetenv("INJECT")
+	os.Unsetenv("MISSING_VAR")
+
+	m, err := ParseManifest([]byte("version: v1\n# ${MISSING_VAR} in a comment\nrequest:\n  id: ${INJECT}\n  type: SERVICE\n"))
+	if err != nil {
+		t.Fatalf("ParseManifest: unexpected error %v", err)
+	}
+	if m.Request.ID != os.Getenv("INJECT") || m.Request.Type != "SERVICE" || m.Request.Instances != 0 {
+		t.Errorf("ParseManifest: expected the variable to stay in request.id, got %+v", m.Request)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{
+		"SET":   "value",
+		"EMPTY": "",
+	}
+	lookup := func(k string) (string, bool) {
+		v, ok := env[k]
+		return v, ok
+	}
+
+	var data = []struct {
+		in       string
+		expected string
+	}{
+		{"plain", "plain"},
+		{"${SET}", "value"},
+		{"a-${SET}-b", "a-value-b"},
+		{"${UNSET:-default}", "default"},
+		{"${EMPTY:-default}", "default"},
+		{"${EMPTY}", ""},
+		{"$$HOME", "$HOME"},
+		{"cost $5", "cost $5"},
+		{"trailing $", "trailing $"},
+	}
+
+	for _, tt := range data {
+		got, err := interpolate(tt.in, lookup)
+		if err != nil {
+			t.Errorf("interpolate(%s): unexpected error %v", tt.in, err)
+		}
+		if got != tt.expected {
+			t.Errorf("interpolate(%s): expected %s, got %s", tt.in, tt.expected, got)
+		}
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "request.json")
+	if err := ioutil.WriteFile(path, []byte(testManifestJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest(%s): unexpected error %v", path, err)
+	}
+	if m.Request.ID != "my-job" {
+		t.Errorf("LoadManifest(%s): expected %s, got %s", path, "my-job", m.Request.ID)
+	}
+
+	if _, err := LoadManifest(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("LoadManifest: expected error for missing file")
+	}
+}
+
+func TestManifestBuildRequest(t *testing.T) {
+	m, err := ParseManifest([]byte(testManifestJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := m.BuildRequest()
+	if err != nil {
+		t.Fatalf("BuildRequest: unexpected error %v", err)
+	}
+	req := r.Get()
+	if req.RequestType != "SCHEDULED" {
+		t.Errorf("BuildRequest: expected %s, got %s", "SCHEDULED", req.RequestType)
+	}
+	if req.Schedule != "*/5 * * * *" {
+		t.Errorf("BuildRequest: expected %s, got %s", "*/5 * * * *", req.Schedule)
+	}
+
+	m.Request.Type = "BATCH"
+	if _, err := m.BuildRequest(); err == nil {
+		t.Errorf("BuildRequest: expected error for unknown request type")
+	}
+
+	m.Request.Type = "SCHEDULED"
+	m.Request.Schedule = "* * * * * * *"
+	if _, err := m.BuildRequest(); err == nil {
+		t.Errorf("BuildRequest: expected error for invalid schedule")
+	}
+}
+
+func TestManifestBuildDeploy(t *testing.T) {
+	os.Setenv("DEPLOY_ID", "d1")
+	defer os.Unsetenv("DEPLOY_ID")
+
+	m, err := ParseManifest([]byte(testManifestYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := m.BuildDeploy()
+	if err != nil {
+		t.Fatalf("BuildDeploy: unexpected error %v", err)
+	}
+	dep := d.Get()
+	if dep.RequestID != "my-service" {
+		t.Errorf("BuildDeploy: expected %s, got %s", "my-service", dep.RequestID)
+	}
+	if dep.Command != "./run.sh" {
+		t.Errorf("BuildDeploy: expected %s, got %s", "./run.sh", dep.Command)
+	}
+	if dep.ContainerInfo.DockerInfo.Image != "golang:latest" {
+		t.Errorf("BuildDeploy: expected %s, got %s", "golang:latest", dep.ContainerInfo.DockerInfo.Image)
+	}
+	if dep.SingularityDeployResources.MemoryMb != 128 {
+		t.Errorf("BuildDeploy: expected %v, got %v", 128, dep.SingularityDeployResources.MemoryMb)
+	}
+
+	m.Deploy = nil
+	if _, err := m.BuildDeploy(); err == nil {
+		t.Errorf("BuildDeploy: expected error for manifest without deploy")
+	}
+}
+
+func TestNewManifest(t *testing.T) {
+	placement := SlavePlacementGreedy
+	var r Request
+	r.SingularityRequest = SingularityRequest{
+		ID:             "my-service",
+		RequestType:    "SERVICE",
+		Instances:      2,
+		SlavePlacement: &placement,
+	}
+	r.ActiveDeploy.ID = "d1"
+	r.ActiveDeploy.Command = "./run.sh"
+	r.ActiveDeploy.ContainerInfo = ContainerInfo{
+		Type: "DOCKER",
+		DockerInfo: DockerInfo{
+			Image: "golang:latest",
+		},
+	}
+
+	m := NewManifest(r)
+	expected := &Manifest{
+		Version: ManifestVersion,
+		Request: ManifestRequest{
+			ID:             "my-service",
+			Type:           "SERVICE",
+			Instances:      2,
+			SlavePlacement: "GREEDY",
+		},
+		Deploy: &ManifestDeploy{
+			ID:      "d1",
+			Command: "./run.sh",
+			Container: &ManifestContainer{
+				Type: "DOCKER",
+				Docker: &ManifestDocker{
+					Image: "golang:latest",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("NewManifest: expected %+v, got %+v", expected, m)
+	}
+
+	// An exported manifest must load back into the same manifest.
+	data, err := m.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ParseManifest(data)
+	if err != nil {
+		t.Fatalf("ParseManifest: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("ParseManifest: expected %+v, got %+v", expected, loaded)
+	}
+
+	data, err = m.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = ParseManifest(data)
+	if err != nil {
+		t.Fatalf("ParseManifest: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("ParseManifest: expected %+v, got %+v", expected, loaded)
+	}
+}
+
+// TestNewManifestRoundTrip checks that exporting a request and building it
+// from the manifest again keeps every field a manifest can hold.
+func TestNewManifestRoundTrip(t *testing.T) {
+	placement := SlavePlacementSeparateByRequest
+	var r Request
+	r.SingularityRequest = SingularityRequest{
+		ID:                  "my-service",
+		RequestType:         RequestTypeService,
+		Instances:           3,
+		NumRetriesOnFailure: 2,
+		MaxTasksPerOffer:    1,
+		SlavePlacement:      &placement,
+		Owners:              []string{"team@example.com"},
+		RequiredRole:        "ops",
+		RackSensitive:       true,
+		LoadBalanced:        true,
+		SkipHealthchecks:    true,
+	}
+	r.ActiveDeploy = SingularityDeploy{
+		ID:                                    "d1",
+		RequestID:                             "my-service",
+		Version:                               "1.2.3",
+		Command:                               "./run.sh",
+		Arguments:                             []string{"-v"},
+		Env:                                   map[string]string{"LOG_LEVEL": "debug"},
+		Metadata:                              map[string]string{"commit": "abc123"},
+		Labels:                                map[string]string{"team": "web"},
+		User:                                  "app",
+		ServiceBasePath:                       "/my-service",
+		CustomExecutorID:                      "executor",
+		CustomExecutorSource:                  "source",
+		AutoAdvanceDeploySteps:                true,
+		DeployInstanceCountPerStep:            1,
+		DeployStepWaitTimeMs:                  30000,
+		MaxTaskRetries:                        2,
+		DeployHealthTimeoutSeconds:            120,
+		ConsiderHealthyAfterRunningForSeconds: 10,
+		SkipHealthchecksOnDeploy:              true,
+		SingularityDeployResources:            SingularityDeployResources{Cpus: 0.5, MemoryMb: 128, DiskMb: 512, NumPorts: 1},
+		Uris:                                  []SingularityMesosArtifact{{URI: "https://example.com/app.tgz", Extract: true}},
+	}
+
+	data, err := NewManifest(r).YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		t.Fatalf("ParseManifest: unexpected error %v", err)
+	}
+	req, err := m.BuildRequest()
+	if err != nil {
+		t.Fatalf("BuildRequest: unexpected error %v", err)
+	}
+	if got := req.Get(); !reflect.DeepEqual(got, r.SingularityRequest) {
+		t.Errorf("BuildRequest: expected %+v, got %+v", r.SingularityRequest, got)
+	}
+	d, err := m.BuildDeploy()
+	if err != nil {
+		t.Fatalf("BuildDeploy: unexpected error %v", err)
+	}
+	if got := d.Get(); !reflect.DeepEqual(got, r.ActiveDeploy) {
+		t.Errorf("BuildDeploy: expected %+v, got %+v", r.ActiveDeploy, got)
+	}
+}
+
+func TestClientExportManifest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/requests/request/my-service":
+		case "/api/requests/request/broken":
+			// A proxy's error page which happens to be JSON.
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"error":"bad gateway"}`))
+			return
+		default:
+			http.Error(w, "Couldn't find request with id missing", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"request":{"id":"my-service","requestType":"SERVICE","instances":1},"state":"ACTIVE",` +
+			`"activeDeploy":{"id":"d1","requestId":"my-service","command":"./run.sh"}}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts)
+	m, err := client.ExportManifest("my-service")
+	if err != nil {
+		t.Fatalf("ExportManifest: unexpected error %v", err)
+	}
+	if m.Request.ID != "my-service" || m.Deploy == nil || m.Deploy.Command != "./run.sh" {
+		t.Errorf("ExportManifest: got %+v", m)
+	}
+
+	if _, err := client.ExportManifest("missing"); err == nil || !strings.Contains(err.Error(), "request missing not found") {
+		t.Errorf("ExportManifest: expected not found error for missing request, got %v", err)
+	}
+	if _, err := client.ExportManifest("broken"); err == nil || !strings.Contains(err.Error(), "bad gateway") {
+		t.Errorf("ExportManifest: expected the 502 to be an error, got %v", err)
+	}
+}
+
+func TestManifestMesosContainer(t *testing.T) {
+	m, err := ParseManifest([]byte(`version: v1
+request:
+  id: my-service
+  type: SERVICE
+deploy:
+  id: d1
+  command: ./run.sh
+  container:
+    type: MESOS
+    mesos:
+      image:
+        type: APPC
+        name: example.com/app
+        id: sha512-1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := m.BuildDeploy()
+	if err != nil {
+		t.Fatalf("BuildDeploy: unexpected error %v", err)
+	}
+	c := d.Get().ContainerInfo
+	expected := NewMesosContainerInfo(NewMesosAppcImage("example.com/app", "sha512-1"))
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("BuildDeploy: expected %+v, got %+v", expected, c)
+	}
+
+	var r Request
+	r.SingularityRequest = SingularityRequest{ID: "my-service", RequestType: "SERVICE"}
+	r.ActiveDeploy.ID = "d1"
+	r.ActiveDeploy.ContainerInfo = c
+	if got := NewManifest(r).Deploy.Container; !reflect.DeepEqual(got, m.Deploy.Container) {
+		t.Errorf("NewManifest: expected %+v, got %+v", m.Deploy.Container, got)
+	}
+}
@@ -0,0 +1,169 @@
+// Package metrics counts the HTTP calls of a singularity.Client and serves
+// them in the Prometheus text format, without depending on the Prometheus
+// client library.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the duration histogram
+// unless others are given to NewHook.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Hook counts HTTP attempts by operation, endpoint and status, and times
+// them. It is safe for concurrent use, and serves its metrics over HTTP.
+//
+// The metrics are:
+//
+//	singularity_client_requests_total{operation,method,endpoint,code}
+//	singularity_client_request_duration_seconds{operation,method,endpoint}
+//	singularity_client_retries_total{operation,method,endpoint}
+//
+// code is the HTTP status, or "error" when no response was received.
+type Hook struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[string]float64
+	retries   map[string]float64
+	durations map[string]*histogram
+}
+
+var _ singularity.Hook = (*Hook)(nil)
+
+type histogram struct {
+	counts []float64
+	count  float64
+	sum    float64
+}
+
+// NewHook returns a Hook with the given histogram buckets, in seconds, or
+// DefaultBuckets if there are none. Add it to a Client with AddHook.
+func NewHook(buckets ...float64) *Hook {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Hook{
+		buckets:   buckets,
+		requests:  map[string]float64{},
+		retries:   map[string]float64{},
+		durations: map[string]*histogram{},
+	}
+}
+
+// Before does nothing; attempts are recorded when they finish.
+func (h *Hook) Before(ctx context.Context, op singularity.Operation, r *http.Request) context.Context {
+	return ctx
+}
+
+// After records a finished attempt.
+func (h *Hook) After(ctx context.Context, call singularity.Call) {
+	code := "error"
+	if call.Err == nil {
+		code = strconv.Itoa(call.StatusCode)
+	}
+	op := formatLabels("operation", call.Name, "method", call.Method, "endpoint", call.Endpoint)
+	seconds := call.Duration.Seconds()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.requests[formatLabels("operation", call.Name, "method", call.Method, "endpoint", call.Endpoint, "code", code)]++
+	if call.Retry > 0 {
+		h.retries[op]++
+	}
+	d, ok := h.durations[op]
+	if !ok {
+		d = &histogram{counts: make([]float64, len(h.buckets))}
+		h.durations[op] = d
+	}
+	for i, b := range h.buckets {
+		if seconds <= b {
+			d.counts[i]++
+		}
+	}
+	d.count++
+	d.sum += seconds
+}
+
+// WriteTo writes the metrics to w in the Prometheus text format.
+func (h *Hook) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	h.mu.Lock()
+	writeCounter(&b, "singularity_client_requests_total", "Number of HTTP attempts made to Singularity.", h.requests)
+	writeCounter(&b, "singularity_client_retries_total", "Number of HTTP attempts which were retries.", h.retries)
+
+	name := "singularity_client_request_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s How long HTTP attempts to Singularity took.\n# TYPE %s histogram\n", name, name)
+	for _, l := range sortedKeys(h.durations) {
+		d := h.durations[l]
+		for i, bound := range h.buckets {
+			fmt.Fprintf(&b, "%s_bucket%s %s\n", name, withLabel(l, "le", formatFloat(bound)), formatFloat(d.counts[i]))
+		}
+		fmt.Fprintf(&b, "%s_bucket%s %s\n", name, withLabel(l, "le", "+Inf"), formatFloat(d.count))
+		fmt.Fprintf(&b, "%s_sum%s %s\n", name, l, formatFloat(d.sum))
+		fmt.Fprintf(&b, "%s_count%s %s\n", name, l, formatFloat(d.count))
+	}
+	h.mu.Unlock()
+	return b.WriteTo(w)
+}
+
+// ServeHTTP serves the metrics, so that a Hook can be mounted on /metrics or
+// alongside other metrics of the program.
+func (h *Hook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	h.WriteTo(w)
+}
+
+func writeCounter(b *bytes.Buffer, name, help string, samples map[string]float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	labels := make([]string, 0, len(samples))
+	for l := range samples {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	for _, l := range labels {
+		fmt.Fprintf(b, "%s%s %s\n", name, l, formatFloat(samples[l]))
+	}
+}
+
+func sortedKeys(m map[string]*histogram) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels formats name and value pairs as a Prometheus label set.
+func formatLabels(labels ...string) string {
+	s := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		s = append(s, labels[i]+`="`+labelEscaper.Replace(labels[i+1])+`"`)
+	}
+	return "{" + strings.Join(s, ",") + "}"
+}
+
+// withLabel adds a label to a formatted label set.
+func withLabel(labels, name, value string) string {
+	return strings.TrimSuffix(labels, "}") + "," + formatLabels(name, value)[1:]
+}
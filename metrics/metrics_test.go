@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+func TestHook(t *testing.T) {
+	h := NewHook(0.1, 1)
+	op := singularity.Operation{Name: "GetRequest", Method: "GET", Endpoint: "/api/requests/request/{requestId}"}
+	h.After(h.Before(context.Background(), op, nil), singularity.Call{Operation: op, Err: errors.New("EOF"), Duration: 2 * time.Second})
+	op.Retry = 1
+	h.After(context.Background(), singularity.Call{Operation: op, StatusCode: 200, Duration: 50 * time.Millisecond})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	got := w.Body.String()
+
+	labels := `operation="GetRequest",method="GET",endpoint="/api/requests/request/{requestId}"`
+	for _, line := range []string{
+		"# TYPE singularity_client_requests_total counter",
+		`singularity_client_requests_total{` + labels + `,code="200"} 1`,
+		`singularity_client_requests_total{` + labels + `,code="error"} 1`,
+		`singularity_client_retries_total{` + labels + `} 1`,
+		"# TYPE singularity_client_request_duration_seconds histogram",
+		`singularity_client_request_duration_seconds_bucket{` + labels + `,le="0.1"} 1`,
+		`singularity_client_request_duration_seconds_bucket{` + labels + `,le="1"} 1`,
+		`singularity_client_request_duration_seconds_bucket{` + labels + `,le="+Inf"} 2`,
+		`singularity_client_request_duration_seconds_sum{` + labels + `} 2.05`,
+		`singularity_client_request_duration_seconds_count{` + labels + `} 2`,
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("ServeHTTP: expected %q in:\n%s", line, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package singularity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Millis is a duration which Singularity sends and receives as a number of
+// milliseconds, such as DurationMillis or DeployStepWaitTimeMs.
+type Millis int64
+
+// MillisOf returns d as Millis, truncated to the millisecond.
+func MillisOf(d time.Duration) Millis {
+	return Millis(d / time.Millisecond)
+}
+
+// Duration returns m as a time.Duration.
+func (m Millis) Duration() time.Duration {
+	return time.Duration(m) * time.Millisecond
+}
+
+// MarshalJSON encodes m as a number of milliseconds.
+func (m Millis) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(m))
+}
+
+// UnmarshalJSON decodes a number of milliseconds. A null leaves m as it was.
+func (m *Millis) UnmarshalJSON(data []byte) error {
+	v, ok, err := unmarshalMillis(data)
+	if err != nil {
+		return fmt.Errorf("duration must be a number of milliseconds, got %s", data)
+	}
+	if ok {
+		*m = Millis(v)
+	}
+	return nil
+}
+
+// EpochMillis is a point in time which Singularity sends and receives as
+// milliseconds since the Unix epoch, such as Timestamp or StartMillis. Zero
+// means the time isn't set.
+type EpochMillis int64
+
+// EpochMillisOf returns t as EpochMillis, truncated to the millisecond. The
+// zero time.Time is returned as 0.
+func EpochMillisOf(t time.Time) EpochMillis {
+	if t.IsZero() {
+		return 0
+	}
+	return EpochMillis(t.UnixNano() / int64(time.Millisecond))
+}
+
+// Time returns m as a local time.Time, or the zero time.Time if m is 0.
+func (m EpochMillis) Time() time.Time {
+	if m == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(m)*int64(time.Millisecond))
+}
+
+// MarshalJSON encodes m as milliseconds since the Unix epoch.
+func (m EpochMillis) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(m))
+}
+
+// UnmarshalJSON decodes milliseconds since the Unix epoch. A null leaves m as
+// it was.
+func (m *EpochMillis) UnmarshalJSON(data []byte) error {
+	v, ok, err := unmarshalMillis(data)
+	if err != nil {
+		return fmt.Errorf("time must be a number of milliseconds since the epoch, got %s", data)
+	}
+	if ok {
+		*m = EpochMillis(v)
+	}
+	return nil
+}
+
+// unmarshalMillis decodes a JSON number, truncating any fraction, and
+// reports false for null.
+func unmarshalMillis(data []byte) (int64, bool, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return 0, false, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return 0, false, err
+	}
+	if v, err := n.Int64(); err == nil {
+		return v, true, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return 0, false, err
+	}
+	return int64(f), true, nil
+}
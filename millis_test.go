@@ -0,0 +1,93 @@
+package singularity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMillisOf(t *testing.T) {
+	var data = []struct {
+		actual   time.Duration
+		expected Millis
+	}{
+		{0, 0},
+		{time.Second, 1000},
+		{1500 * time.Microsecond, 1},
+		{-time.Minute, -60000},
+	}
+
+	for _, tt := range data {
+		got := MillisOf(tt.actual)
+		if got != tt.expected {
+			t.Errorf("MillisOf(%v): expected %d, got %d", tt.actual, tt.expected, got)
+		}
+		if got.Duration() != tt.actual.Truncate(time.Millisecond) {
+			t.Errorf("MillisOf(%v).Duration(): expected %v, got %v", tt.actual, tt.actual.Truncate(time.Millisecond), got.Duration())
+		}
+	}
+}
+
+func TestEpochMillisOf(t *testing.T) {
+	var data = []struct {
+		actual   time.Time
+		expected EpochMillis
+	}{
+		{time.Time{}, 0},
+		{time.Unix(1503451301, 91000000), 1503451301091},
+		{time.Unix(1503451301, 91999999), 1503451301091},
+	}
+
+	for _, tt := range data {
+		got := EpochMillisOf(tt.actual)
+		if got != tt.expected {
+			t.Errorf("EpochMillisOf(%v): expected %d, got %d", tt.actual, tt.expected, got)
+		}
+		if !got.Time().Equal(tt.actual.Truncate(time.Millisecond)) {
+			t.Errorf("EpochMillisOf(%v).Time(): expected %v, got %v", tt.actual, tt.actual.Truncate(time.Millisecond), got.Time())
+		}
+	}
+	if !EpochMillis(0).Time().IsZero() {
+		t.Errorf("EpochMillis(0).Time(): expected zero time, got %v", EpochMillis(0).Time())
+	}
+}
+
+func TestMillisJSON(t *testing.T) {
+	var data = []struct {
+		actual   string
+		expected SingularityPauseRequest
+		err      bool
+	}{
+		{`{"durationMillis": 60000}`, SingularityPauseRequest{DurationMillis: 60000}, false},
+		{`{"durationMillis": 1.5e3}`, SingularityPauseRequest{DurationMillis: 1500}, false},
+		{`{"durationMillis": null}`, SingularityPauseRequest{}, false},
+		{`{"durationMillis": "soon"}`, SingularityPauseRequest{}, true},
+		{`{"durationMillis": true}`, SingularityPauseRequest{}, true},
+	}
+
+	for _, tt := range data {
+		var got SingularityPauseRequest
+		err := json.Unmarshal([]byte(tt.actual), &got)
+		if (err != nil) != tt.err {
+			t.Errorf("Unmarshal(%s): expected error %v, got %v", tt.actual, tt.err, err)
+		}
+		if err == nil && got != tt.expected {
+			t.Errorf("Unmarshal(%s): expected %+v, got %+v", tt.actual, tt.expected, got)
+		}
+	}
+
+	var m SingularityDeployMarker
+	if err := json.Unmarshal([]byte(`{"timestamp": 1503451301091}`), &m); err != nil {
+		t.Fatalf("Unmarshal: unexpected error %v", err)
+	}
+	if !m.Timestamp.Time().Equal(time.Unix(1503451301, 91000000)) {
+		t.Errorf("Unmarshal: expected timestamp 1503451301091, got %v", m.Timestamp.Time())
+	}
+	b, err := json.Marshal(NewPauseRequest("my-service", "", false).SetDuration(time.Hour).SingularityPauseRequest)
+	if err != nil {
+		t.Fatalf("Marshal: unexpected error %v", err)
+	}
+	if string(b) != `{"durationMillis":3600000}` {
+		t.Errorf("Marshal: expected {\"durationMillis\":3600000}, got %s", b)
+	}
+}
@@ -0,0 +1,179 @@
+package singularity
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultConcurrency is how many clusters a MultiClient calls at once unless
+// SetConcurrency is used.
+const defaultConcurrency = 4
+
+// MultiClient wraps the Clients of several named Singularity clusters, and
+// makes the same read call to each of them concurrently.
+type MultiClient struct {
+	clients     map[string]*Client
+	names       []string
+	concurrency int
+}
+
+// NewMultiClient accepts Clients keyed by cluster name and returns a
+// MultiClient for them.
+func NewMultiClient(clients map[string]*Client) *MultiClient {
+	m := &MultiClient{
+		clients:     make(map[string]*Client, len(clients)),
+		concurrency: defaultConcurrency,
+	}
+	for name, c := range clients {
+		m.clients[name] = c
+		m.names = append(m.names, name)
+	}
+	sort.Strings(m.names)
+	return m
+}
+
+// SetConcurrency sets how many clusters are called at once. Values below 1
+// are treated as 1.
+func (m *MultiClient) SetConcurrency(n int) *MultiClient {
+	if n < 1 {
+		n = 1
+	}
+	m.concurrency = n
+	return m
+}
+
+// Clusters returns the cluster names, sorted.
+func (m *MultiClient) Clusters() []string {
+	return append([]string(nil), m.names...)
+}
+
+// Client returns the Client of a cluster, or nil if there is none.
+func (m *MultiClient) Client(cluster string) *Client {
+	return m.clients[cluster]
+}
+
+// ClusterError is the error of a single cluster in a fan-out call.
+type ClusterError struct {
+	Cluster string
+	Err     error
+}
+
+func (e ClusterError) Error() string {
+	return e.Cluster + ": " + e.Err.Error()
+}
+
+// MultiError holds the errors of every cluster which failed a fan-out call.
+// The results of the other clusters are still returned alongside it.
+type MultiError []ClusterError
+
+func (e MultiError) Error() string {
+	s := make([]string, 0, len(e))
+	for _, c := range e {
+		s = append(s, c.Error())
+	}
+	return "Singularity clusters error: " + strings.Join(s, "; ")
+}
+
+// ClusterRequest is a request tagged with the cluster it came from.
+type ClusterRequest struct {
+	Cluster string `json:"cluster"`
+	Request
+}
+
+// ClusterTask is a task tagged with the cluster it came from.
+type ClusterTask struct {
+	Cluster string `json:"cluster"`
+	SingularityTask
+}
+
+// ClusterSlave is an agent tagged with the cluster it came from.
+type ClusterSlave struct {
+	Cluster string `json:"cluster"`
+	SingularitySlave
+}
+
+// GetRequests retrieves the requests of every cluster. Clusters which fail
+// are reported in a MultiError, and the requests of the rest are returned.
+func (m *MultiClient) GetRequests() ([]ClusterRequest, error) {
+	results := make([][]ClusterRequest, len(m.names))
+	err := m.each(func(i int, c *Client) error {
+		_, reqs, err := c.GetRequests()
+		for _, r := range reqs {
+			results[i] = append(results[i], ClusterRequest{Cluster: m.names[i], Request: r})
+		}
+		return err
+	})
+	var all []ClusterRequest
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	return all, err
+}
+
+// GetActiveTasks retrieves the active tasks of every cluster. Clusters which
+// fail are reported in a MultiError, and the tasks of the rest are returned.
+func (m *MultiClient) GetActiveTasks() ([]ClusterTask, error) {
+	results := make([][]ClusterTask, len(m.names))
+	err := m.each(func(i int, c *Client) error {
+		_, tasks, err := c.GetActiveTasks()
+		for _, t := range tasks {
+			results[i] = append(results[i], ClusterTask{Cluster: m.names[i], SingularityTask: t})
+		}
+		return err
+	})
+	var all []ClusterTask
+	for _, t := range results {
+		all = append(all, t...)
+	}
+	return all, err
+}
+
+// GetSlaves retrieves the agents of every cluster, as Client.GetSlaves does.
+// Clusters which fail are reported in a MultiError, and the agents of the
+// rest are returned.
+func (m *MultiClient) GetSlaves(state string) ([]ClusterSlave, error) {
+	results := make([][]ClusterSlave, len(m.names))
+	err := m.each(func(i int, c *Client) error {
+		_, slaves, err := c.GetSlaves(state)
+		for _, s := range slaves {
+			results[i] = append(results[i], ClusterSlave{Cluster: m.names[i], SingularitySlave: s})
+		}
+		return err
+	})
+	var all []ClusterSlave
+	for _, s := range results {
+		all = append(all, s...)
+	}
+	return all, err
+}
+
+// each calls f for every cluster, at most m.concurrency at a time, with the
+// index of its name. It returns a MultiError of the clusters where f failed,
+// or nil.
+func (m *MultiClient) each(f func(i int, c *Client) error) error {
+	errs := make([]error, len(m.names))
+	sem := make(chan struct{}, m.concurrency)
+	var wg sync.WaitGroup
+	for i, name := range m.names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, c *Client) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = f(i, c)
+		}(i, m.clients[name])
+	}
+	wg.Wait()
+
+	var e MultiError
+	for i, err := range errs {
+		if err != nil {
+			e = append(e, ClusterError{Cluster: m.names[i], Err: err})
+		}
+	}
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,83 @@
+package singularity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMultiClient(t *testing.T) {
+	var (
+		mu              sync.Mutex
+		active, maxSeen int
+	)
+	cluster := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			active++
+			if active > maxSeen {
+				maxSeen = active
+			}
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				active--
+				mu.Unlock()
+			}()
+			time.Sleep(5 * time.Millisecond)
+
+			switch r.URL.Path {
+			case "/api/requests":
+				w.Write([]byte(`[{"request":{"id":"` + name + `-r1"}}]`))
+			case "/api/tasks/active":
+				w.Write([]byte(`[{"taskId":{"id":"` + name + `-t1"}}]`))
+			case "/api/slaves":
+				w.Write([]byte(`[{"id":"` + name + `-s1"}]`))
+			}
+		}))
+	}
+	a, b, c := cluster("a"), cluster("b"), cluster("c")
+	defer a.Close()
+	defer b.Close()
+	defer c.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`boom`))
+	}))
+	defer broken.Close()
+
+	m := NewMultiClient(map[string]*Client{
+		"c":      newTestClient(c),
+		"a":      newTestClient(a),
+		"b":      newTestClient(b),
+		"broken": newTestClient(broken),
+	}).SetConcurrency(2)
+
+	reqs, err := m.GetRequests()
+	if len(reqs) != 3 || reqs[0].Cluster != "a" || reqs[0].ID != "a-r1" || reqs[2].Cluster != "c" {
+		t.Errorf("GetRequests: expected requests of a, b and c in order, got %+v", reqs)
+	}
+	e, ok := err.(MultiError)
+	if !ok || len(e) != 1 || e[0].Cluster != "broken" {
+		t.Errorf("GetRequests: expected an error for cluster broken only, got %v", err)
+	}
+	if maxSeen > 2 {
+		t.Errorf("GetRequests: expected at most %d concurrent calls, got %d", 2, maxSeen)
+	}
+
+	tasks, err := m.GetActiveTasks()
+	if len(tasks) != 3 || tasks[1].Cluster != "b" || tasks[1].TaskID.ID != "b-t1" || err == nil {
+		t.Errorf("GetActiveTasks: got %+v, %v", tasks, err)
+	}
+	slaves, err := m.GetSlaves("")
+	if len(slaves) != 3 || slaves[2].Cluster != "c" || slaves[2].ID != "c-s1" || err == nil {
+		t.Errorf("GetSlaves: got %+v, %v", slaves, err)
+	}
+
+	m = NewMultiClient(map[string]*Client{"a": newTestClient(a), "b": newTestClient(b)})
+	if _, err := m.GetRequests(); err != nil {
+		t.Errorf("GetRequests: unexpected error %v", err)
+	}
+}
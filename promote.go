@@ -0,0 +1,239 @@
+package singularity
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Promotion describes copying a request and its active deploy from one
+// cluster to another, such as from staging to production.
+type Promotion struct {
+	RequestID string
+	// DeployID is the ID of the new deploy. Empty generates one from the
+	// ID of the deploy being promoted.
+	DeployID string
+	// DryRun works out the request, deploy and diff without creating them.
+	DryRun bool
+
+	// Env and Labels are merged into those of the deploy.
+	Env    map[string]string
+	Labels map[string]string
+	// Instances, when not zero, replaces the instances of the request.
+	Instances int64
+	// Non-zero fields of Resources replace those of the deploy.
+	Resources SingularityDeployResources
+}
+
+// PromotionResult is the request and deploy a promotion creates, and how they
+// differ from what the target cluster runs now.
+type PromotionResult struct {
+	Request SingularityRequest
+	Deploy  SingularityDeploy
+	// Diff lists each changed field of the request and deploy by JSON path,
+	// such as "~ deploy.env.LOG_LEVEL: "debug" -> "info"". Lines start with
+	// + for a new field, - for a removed field and ~ for a changed one.
+	Diff []string
+	// Created is true once the request and deploy have been posted to the
+	// target cluster.
+	Created bool
+}
+
+// Promote reads a request and its active deploy from one cluster, applies
+// the overrides of p, and creates them with a new deploy ID on another
+// cluster. With p.DryRun nothing is created, and the result only shows what
+// would change.
+func Promote(from, to *Client, p Promotion) (PromotionResult, error) {
+	req, deploy, err := activeDeploy(from, p.RequestID)
+	if err != nil {
+		return PromotionResult{}, err
+	}
+	if deploy == nil {
+		return PromotionResult{}, fmt.Errorf("Promote Singularity request %s error: no active deploy", p.RequestID)
+	}
+
+	r := PromotionResult{Request: req, Deploy: *deploy}
+	if p.Instances != 0 {
+		r.Request.Instances = p.Instances
+	}
+	r.Deploy.ID = p.DeployID
+	if r.Deploy.ID == "" {
+		r.Deploy.ID = derivedDeployID(deploy.ID, "pr", time.Now())
+	}
+	r.Deploy.Timestamp = 0
+	r.Deploy.Env = mergeMap(deploy.Env, p.Env)
+	r.Deploy.Labels = mergeMap(deploy.Labels, p.Labels)
+	r.Deploy.SingularityDeployResources = mergeResources(deploy.SingularityDeployResources, p.Resources)
+	if err := r.Request.Validate(); err != nil {
+		return r, err
+	}
+	if err := r.Deploy.Validate(); err != nil {
+		return r, err
+	}
+
+	current, currentDeploy, err := activeDeploy(to, p.RequestID)
+	if err != nil {
+		return r, err
+	}
+	requestDiff, err := diffJSON("request", current, r.Request)
+	if err != nil {
+		return r, err
+	}
+	deployDiff, err := diffJSON("deploy", currentDeploy, r.Deploy)
+	if err != nil {
+		return r, err
+	}
+	r.Diff = append(requestDiff, deployDiff...)
+	if p.DryRun {
+		return r, nil
+	}
+
+	res, err := r.Request.Create(to)
+	if err != nil {
+		return r, err
+	}
+	if code := res.RestyResponse.StatusCode(); code < 200 || code > 299 {
+		return r, fmt.Errorf("Promote Singularity request %s error: %s", p.RequestID, res.RestyResponse.Body())
+	}
+	dr := &SingularityDeployRequest{
+		SingularityDeploy: r.Deploy,
+		Message:           fmt.Sprintf("Promotion of %s", deploy.ID),
+	}
+	// Create reports a 409, an already pending deploy, as success.
+	res, err = dr.Create(to)
+	if err != nil {
+		return r, err
+	}
+	if code := res.RestyResponse.StatusCode(); code < 200 || code > 299 {
+		return r, fmt.Errorf("Promote Singularity deploy %s error: %s", r.Deploy.ID, res.RestyResponse.Body())
+	}
+	r.Created = true
+	return r, nil
+}
+
+// activeDeploy returns a request and its active deploy. The request is empty
+// if it does not exist, and the deploy is nil if there is no active deploy.
+func activeDeploy(c *Client, requestID string) (SingularityRequest, *SingularityDeploy, error) {
+	res, err := c.GetRequestByID(requestID)
+	if err != nil {
+		return SingularityRequest{}, nil, err
+	}
+	req := res.Body.SingularityRequest
+	id := res.Body.RequestDeployState.ActiveDeploy.DeployID
+	if req.ID == "" || id == "" {
+		return req, nil, nil
+	}
+	_, h, err := c.GetDeployHistory(requestID, id)
+	if err != nil {
+		return req, nil, err
+	}
+	return req, h.Deploy, nil
+}
+
+func mergeMap(m, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return m
+	}
+	merged := make(map[string]string, len(m)+len(overrides))
+	for k, v := range m {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+func mergeResources(r, overrides SingularityDeployResources) SingularityDeployResources {
+	if overrides.Cpus != 0 {
+		r.Cpus = overrides.Cpus
+	}
+	if overrides.MemoryMb != 0 {
+		r.MemoryMb = overrides.MemoryMb
+	}
+	if overrides.DiskMb != 0 {
+		r.DiskMb = overrides.DiskMb
+	}
+	if overrides.NumPorts != 0 {
+		r.NumPorts = overrides.NumPorts
+	}
+	return r
+}
+
+// diffJSON compares the JSON encodings of a and b, field by field, and
+// returns a sorted line for each difference.
+func diffJSON(prefix string, a, b interface{}) ([]string, error) {
+	fa, fb := map[string]interface{}{}, map[string]interface{}{}
+	va, err := toJSONValue(a)
+	if err != nil {
+		return nil, err
+	}
+	vb, err := toJSONValue(b)
+	if err != nil {
+		return nil, err
+	}
+	flattenJSON(prefix, va, fa)
+	flattenJSON(prefix, vb, fb)
+
+	var diff []string
+	for k, vb := range fb {
+		va, ok := fa[k]
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("+ %s: %s", k, jsonString(vb)))
+		case !reflect.DeepEqual(va, vb):
+			diff = append(diff, fmt.Sprintf("~ %s: %s -> %s", k, jsonString(va), jsonString(vb)))
+		}
+	}
+	for k, va := range fa {
+		if _, ok := fb[k]; !ok {
+			diff = append(diff, fmt.Sprintf("- %s: %s", k, jsonString(va)))
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i][2:] < diff[j][2:]
+	})
+	return diff, nil
+}
+
+func toJSONValue(v interface{}) (interface{}, error) {
+	if reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("Diff Singularity %T error: %v", v, err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("Diff Singularity %T error: %v", v, err)
+	}
+	return out, nil
+}
+
+// flattenJSON adds each leaf of v to out, keyed by its path. Empty values
+// are left out, so that a missing field and a zero one compare equal.
+func flattenJSON(path string, v interface{}, out map[string]interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			flattenJSON(path+"."+k, e, out)
+		}
+	case []interface{}:
+		for i, e := range v {
+			flattenJSON(fmt.Sprintf("%s[%d]", path, i), e, out)
+		}
+	default:
+		if v != nil && v != false && v != "" && v != float64(0) {
+			out[path] = v
+		}
+	}
+}
+
+func jsonString(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,39 @@
+package singularity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	a := SingularityDeploy{ID: "d1", Env: map[string]string{"LOG": "debug", "OLD": "1"}}
+	b := SingularityDeploy{ID: "d2", Env: map[string]string{"LOG": "info", "NEW": "1"}}
+	b.SingularityDeployResources.Cpus = 2
+
+	expected := []string{
+		`~ deploy.env.LOG: "debug" -> "info"`,
+		`+ deploy.env.NEW: "1"`,
+		`- deploy.env.OLD: "1"`,
+		`~ deploy.id: "d1" -> "d2"`,
+		`+ deploy.resources.cpus: 2`,
+	}
+	if got, err := diffJSON("deploy", a, b); err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("diffJSON: expected %q, got %q", expected, got)
+	}
+
+	var missing *SingularityDeploy
+	if got, err := diffJSON("deploy", missing, SingularityDeploy{ID: "d1"}); err != nil || !reflect.DeepEqual(got, []string{`+ deploy.id: "d1"`}) {
+		t.Errorf("diffJSON(nil): expected every field to be added, got %q", got)
+	}
+	if _, err := diffJSON("deploy", a, func() {}); err == nil {
+		t.Errorf("diffJSON(func): expected a marshal error")
+	}
+}
+
+func TestMergeResources(t *testing.T) {
+	r := mergeResources(SingularityDeployResources{Cpus: 1, MemoryMb: 128, NumPorts: 1}, SingularityDeployResources{MemoryMb: 512})
+	expected := SingularityDeployResources{Cpus: 1, MemoryMb: 512, NumPorts: 1}
+	if r != expected {
+		t.Errorf("mergeResources: expected %+v, got %+v", expected, r)
+	}
+}
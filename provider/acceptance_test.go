@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lenfree/go-mesos-singularity/singularitytest"
+)
+
+// The acceptance tests drive a real Terraform binary against a fake
+// Singularity server. They only run when TF_ACC is set.
+
+func testProviderFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"singularity": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
+	}
+}
+
+func testAccConfig(host, deployID, command string) string {
+	return fmt.Sprintf(`
+provider "singularity" {
+  host = %q
+}
+
+resource "singularity_request" "service" {
+  request_id   = "my-service"
+  request_type = "SERVICE"
+  instances    = 2
+}
+
+resource "singularity_deploy" "service" {
+  request_id = singularity_request.service.request_id
+  deploy_id  = %q
+  command    = %q
+  cpus       = 0.5
+  memory_mb  = 128
+
+  docker {
+    image = "golang:latest"
+  }
+}
+
+data "singularity_request" "service" {
+  request_id = singularity_deploy.service.request_id
+}
+`, host, deployID, command)
+}
+
+func TestAccRequestAndDeploy(t *testing.T) {
+	ts := singularitytest.NewServer()
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := ts.Request("my-service"); ok {
+				return fmt.Errorf("request my-service still exists")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(ts.Host(), "d1", "./run.sh"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("singularity_request.service", "state", "ACTIVE"),
+					resource.TestCheckResourceAttr("singularity_deploy.service", "id", "my-service/d1"),
+					resource.TestCheckResourceAttr("data.singularity_request.service", "active_deploy_id", "d1"),
+				),
+			},
+			{
+				Config:      testAccConfig(ts.Host(), "d1", "./other.sh"),
+				ExpectError: regexp.MustCompile("requires a new deploy_id"),
+			},
+			{
+				Config: testAccConfig(ts.Host(), "d2", "./other.sh"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("singularity_deploy.service", "id", "my-service/d2"),
+					resource.TestCheckResourceAttr("data.singularity_request.service", "active_deploy_id", "d2"),
+				),
+			},
+			{
+				ResourceName:      "singularity_deploy.service",
+				ImportState:       true,
+				ImportStateId:     "my-service",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "singularity_request.service",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
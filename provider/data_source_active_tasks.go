@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+func dataSourceActiveTasks() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists active Singularity tasks, optionally of a single request.",
+		ReadContext: dataSourceActiveTasksRead,
+		Schema: map[string]*schema.Schema{
+			"request_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tasks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"request_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deploy_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_no": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"started_at": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceActiveTasksRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	_, tasks, err := m.(*singularity.Client).GetActiveTasks()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	requestID := d.Get("request_id").(string)
+	list := make([]interface{}, 0, len(tasks))
+	for _, t := range tasks {
+		id := t.TaskID
+		if requestID != "" && id.RequestID != requestID {
+			continue
+		}
+		list = append(list, map[string]interface{}{
+			"id":          id.ID,
+			"request_id":  id.RequestID,
+			"deploy_id":   id.DeployID,
+			"host":        id.Host,
+			"instance_no": id.InstanceNo,
+			"started_at":  int(id.StartedAt),
+		})
+	}
+	if err := d.Set("tasks", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if requestID == "" {
+		d.SetId("*")
+	} else {
+		d.SetId(requestID)
+	}
+	return nil
+}
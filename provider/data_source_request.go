@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+func dataSourceRequest() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up an existing Singularity request.",
+		ReadContext: dataSourceRequestRead,
+		Schema: map[string]*schema.Schema{
+			"request_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"request_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instances": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"schedule": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owners": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"active_deploy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"pending_deploy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Get("request_id").(string)
+	res, err := m.(*singularity.Client).GetRequestByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if res.RestyResponse.StatusCode() == http.StatusNotFound {
+		return diag.Errorf("Singularity request %s not found", id)
+	}
+	r := res.Body
+
+	values := map[string]interface{}{
+		"request_type":      r.RequestType.String(),
+		"state":             r.State.String(),
+		"instances":         int(r.Instances),
+		"schedule":          r.Schedule,
+		"owners":            r.Owners,
+		"active_deploy_id":  r.RequestDeployState.ActiveDeploy.DeployID,
+		"pending_deploy_id": r.RequestDeployState.PendingDeployState.DeployID,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(id)
+	return nil
+}
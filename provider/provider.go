@@ -0,0 +1,94 @@
+// Package provider implements a Terraform provider which manages
+// Singularity requests and deploys through the singularity package.
+package provider
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// Provider returns the Singularity Terraform provider.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SINGULARITY_HOST", nil),
+				Description: "Singularity host, including any path prefix. Can be set with SINGULARITY_HOST.",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Singularity port. 443 uses HTTPS. Can be set with SINGULARITY_PORT.",
+			},
+			"retry": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Number of retries for failed HTTP requests. Can be set with SINGULARITY_RETRY.",
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"singularity_request": resourceRequest(),
+			"singularity_deploy":  resourceDeploy(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"singularity_request":      dataSourceRequest(),
+			"singularity_active_tasks": dataSourceActiveTasks(),
+		},
+		ConfigureContextFunc: configure,
+	}
+}
+
+func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	port, err := intFromEnv(d.Get("port").(int), "SINGULARITY_PORT")
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	retry, err := intFromEnv(d.Get("retry").(int), "SINGULARITY_RETRY")
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	c := singularity.NewConfig().
+		SetHost(d.Get("host").(string)).
+		SetPort(port).
+		SetRetry(retry).
+		Build()
+	return singularity.NewClient(c), nil
+}
+
+// intFromEnv returns v, or the value of the environment variable k if v is
+// unset.
+func intFromEnv(v int, k string) (int, error) {
+	if v != 0 || os.Getenv(k) == "" {
+		return v, nil
+	}
+	return strconv.Atoi(os.Getenv(k))
+}
+
+// stringList converts a []interface{} from a TypeList of strings.
+func stringList(v interface{}) []string {
+	var s []string
+	for _, i := range v.([]interface{}) {
+		s = append(s, i.(string))
+	}
+	return s
+}
+
+// stringMap converts a map[string]interface{} from a TypeMap of strings.
+func stringMap(v interface{}) map[string]string {
+	m := v.(map[string]interface{})
+	if len(m) == 0 {
+		return nil
+	}
+	s := make(map[string]string, len(m))
+	for k, i := range m {
+		s[k] = i.(string)
+	}
+	return s
+}
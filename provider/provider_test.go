@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	singularity "github.com/lenfree/go-mesos-singularity"
+	"github.com/lenfree/go-mesos-singularity/singularitytest"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("InternalValidate: unexpected error %v", err)
+	}
+}
+
+func TestIntFromEnv(t *testing.T) {
+	t.Setenv("SINGULARITY_TEST_PORT", "8080")
+
+	var data = []struct {
+		v        int
+		key      string
+		expected int
+	}{
+		{0, "SINGULARITY_TEST_PORT", 8080},
+		{443, "SINGULARITY_TEST_PORT", 443},
+		{0, "SINGULARITY_TEST_UNSET", 0},
+	}
+	for _, tt := range data {
+		got, err := intFromEnv(tt.v, tt.key)
+		if err != nil {
+			t.Errorf("intFromEnv(%d, %s): unexpected error %v", tt.v, tt.key, err)
+		}
+		if got != tt.expected {
+			t.Errorf("intFromEnv(%d, %s): expected %d, got %d", tt.v, tt.key, tt.expected, got)
+		}
+	}
+}
+
+// testClient returns a fake Singularity server and a client for it.
+func testClient(t *testing.T) (*singularitytest.Server, *singularity.Client) {
+	ts := singularitytest.NewServer()
+	t.Cleanup(ts.Close)
+	return ts, ts.Client()
+}
+
+func TestResourceRequestCRUD(t *testing.T) {
+	ts, c := testClient(t)
+	ctx := context.Background()
+	r := resourceRequest()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"request_id":   "my-service",
+		"request_type": "SERVICE",
+		"instances":    2,
+		"owners":       []interface{}{"team@example.com"},
+	})
+
+	if diags := r.CreateContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Create: unexpected error %v", diags)
+	}
+	if d.Id() != "my-service" {
+		t.Errorf("Create: expected ID %s, got %s", "my-service", d.Id())
+	}
+	if d.Get("state") != "ACTIVE" {
+		t.Errorf("Create: expected state %s, got %v", "ACTIVE", d.Get("state"))
+	}
+	stored, ok := ts.Request("my-service")
+	if !ok || stored.Instances != 2 {
+		t.Fatalf("Create: expected stored request with 2 instances, got %+v", stored)
+	}
+
+	// Changes made outside of Terraform show up on the next read.
+	stored.SingularityRequest.Instances = 5
+	ts.AddRequest(stored.SingularityRequest)
+	if diags := r.ReadContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Read: unexpected error %v", diags)
+	}
+	if d.Get("instances") != 5 {
+		t.Errorf("Read: expected drifted instances %d, got %v", 5, d.Get("instances"))
+	}
+
+	d.Set("instances", 3)
+	if diags := r.UpdateContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Update: unexpected error %v", diags)
+	}
+	if stored, _ := ts.Request("my-service"); stored.Instances != 3 {
+		t.Errorf("Update: expected %d instances, got %d", 3, stored.Instances)
+	}
+
+	if diags := r.DeleteContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Delete: unexpected error %v", diags)
+	}
+	if _, ok := ts.Request("my-service"); ok {
+		t.Errorf("Delete: expected request to be deleted")
+	}
+
+	// A request deleted outside of Terraform is removed from the state.
+	d.SetId("my-service")
+	if diags := r.ReadContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Read: unexpected error %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Read: expected missing request to clear ID, got %s", d.Id())
+	}
+}
+
+func TestResourceDeployCRUD(t *testing.T) {
+	ts, c := testClient(t)
+	ts.AddRequest(singularity.SingularityRequest{ID: "my-service", RequestType: "SERVICE"})
+	ctx := context.Background()
+	r := resourceDeploy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"request_id": "my-service",
+		"deploy_id":  "d1",
+		"command":    "./run.sh",
+		"env":        map[string]interface{}{"LOG_LEVEL": "info"},
+		"labels":     map[string]interface{}{"team": "web"},
+		"user":       "app",
+		"cpus":       0.5,
+		"memory_mb":  128.0,
+		"docker": []interface{}{map[string]interface{}{
+			"image":   "golang:latest",
+			"network": "BRIDGE",
+		}},
+	})
+
+	if diags := r.CreateContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Create: unexpected error %v", diags)
+	}
+	if d.Id() != "my-service/d1" {
+		t.Errorf("Create: expected ID %s, got %s", "my-service/d1", d.Id())
+	}
+	stored, _ := ts.Request("my-service")
+	if stored.RequestDeployState.ActiveDeploy.DeployID != "d1" {
+		t.Errorf("Create: expected active deploy %s, got %s", "d1", stored.RequestDeployState.ActiveDeploy.DeployID)
+	}
+	if d.Get("docker.0.image") != "golang:latest" || d.Get("env.LOG_LEVEL") != "info" ||
+		d.Get("labels.team") != "web" || d.Get("user") != "app" {
+		t.Errorf("Read: unexpected state %v", d.State())
+	}
+
+	// A newer deploy replaces this one.
+	dep, _ := (&singularity.Manifest{
+		Request: singularity.ManifestRequest{ID: "my-service"},
+		Deploy:  &singularity.ManifestDeploy{ID: "d2", Command: "./run.sh"},
+	}).BuildDeploy()
+	if _, err := singularity.NewDeployRequest().AttachDeploy(dep).Create(c); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.ReadContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Read: unexpected error %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Read: expected replaced deploy to clear ID, got %s", d.Id())
+	}
+}
+
+func TestResourceDeployMesosImage(t *testing.T) {
+	ts, c := testClient(t)
+	ts.AddRequest(singularity.SingularityRequest{ID: "my-service", RequestType: "SERVICE"})
+	r := resourceDeploy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"request_id": "my-service",
+		"deploy_id":  "d1",
+		"command":    "./run.sh",
+		"mesos_image": []interface{}{map[string]interface{}{
+			"type": "DOCKER",
+			"name": "golang:latest",
+		}},
+	})
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("Create: unexpected error %v", diags)
+	}
+	if res := deployManifest(d).Deploy.Resources; res != nil {
+		t.Errorf("Create: expected no resources to be sent, got %+v", res)
+	}
+	stored, _ := ts.Request("my-service")
+	if info := stored.ActiveDeploy.ContainerInfo; info.Type != "MESOS" || info.Mesos == nil || info.Mesos.Image.Docker.Name != "golang:latest" {
+		t.Errorf("Create: expected MESOS container, got %+v", info)
+	}
+	if d.Get("mesos_image.0.name") != "golang:latest" {
+		t.Errorf("Read: unexpected state %v", d.State())
+	}
+}
+
+func TestResourceDeployFailed(t *testing.T) {
+	ts, c := testClient(t)
+	ts.DeployState = "FAILED"
+	ts.AddRequest(singularity.SingularityRequest{ID: "my-service", RequestType: "SERVICE"})
+	r := resourceDeploy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"request_id": "my-service",
+		"deploy_id":  "d1",
+		"command":    "./run.sh",
+	})
+	if diags := r.CreateContext(context.Background(), d, c); !diags.HasError() {
+		t.Errorf("Create: expected error for failed deploy")
+	}
+	if d.Id() != "" {
+		t.Errorf("Create: expected no ID for failed deploy, got %s", d.Id())
+	}
+}
+
+func TestResourceDeployConflict(t *testing.T) {
+	ts, c := testClient(t)
+	ts.DeployState = "WAITING"
+	ts.AddRequest(singularity.SingularityRequest{ID: "my-service", RequestType: "SERVICE"})
+	ctx := context.Background()
+	r := resourceDeploy()
+	first := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"request_id":      "my-service",
+		"deploy_id":       "d1",
+		"wait_for_deploy": false,
+	})
+	if diags := r.CreateContext(ctx, first, c); diags.HasError() {
+		t.Fatalf("Create: unexpected error %v", diags)
+	}
+
+	// Singularity refuses a second deploy while d1 is pending.
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"request_id":      "my-service",
+		"deploy_id":       "d2",
+		"wait_for_deploy": false,
+	})
+	if diags := r.CreateContext(ctx, d, c); !diags.HasError() {
+		t.Errorf("Create: expected error for conflicting deploy")
+	}
+	if d.Id() != "" {
+		t.Errorf("Create: expected no ID for conflicting deploy, got %s", d.Id())
+	}
+}
+
+func TestResourceReadError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"database unavailable"}`))
+	}))
+	defer ts.Close()
+	c := singularity.NewClient(singularity.NewConfig().SetHost(strings.TrimPrefix(ts.URL, "http://")).Build())
+	ctx := context.Background()
+
+	// Only a 404 means the request is gone; other errors keep the state.
+	var data = []struct {
+		r   *schema.Resource
+		id  string
+		raw map[string]interface{}
+	}{
+		{resourceRequest(), "my-service", map[string]interface{}{"request_id": "my-service", "request_type": "SERVICE"}},
+		{resourceDeploy(), "my-service/d1", map[string]interface{}{"request_id": "my-service", "deploy_id": "d1"}},
+	}
+	for _, tt := range data {
+		d := schema.TestResourceDataRaw(t, tt.r.Schema, tt.raw)
+		d.SetId(tt.id)
+		if diags := tt.r.ReadContext(ctx, d, c); !diags.HasError() {
+			t.Errorf("Read(%s): expected error for status 500", tt.id)
+		}
+		if d.Id() != tt.id {
+			t.Errorf("Read(%s): expected ID to be kept, got %q", tt.id, d.Id())
+		}
+	}
+}
+
+func TestResourceDeployCancelPending(t *testing.T) {
+	ts, c := testClient(t)
+	ts.DeployState = "WAITING"
+	ts.AddRequest(singularity.SingularityRequest{ID: "my-service", RequestType: "SERVICE"})
+	ctx := context.Background()
+	r := resourceDeploy()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"request_id":      "my-service",
+		"deploy_id":       "d1",
+		"wait_for_deploy": false,
+	})
+	if diags := r.CreateContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Create: unexpected error %v", diags)
+	}
+	if diags := r.DeleteContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Delete: unexpected error %v", diags)
+	}
+	_, h, err := c.GetDeployHistory("my-service", "d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.DeployResult == nil || h.DeployResult.DeployState != "CANCELED" {
+		t.Errorf("Delete: expected pending deploy to be canceled, got %+v", h.DeployResult)
+	}
+}
+
+func TestResourceDeployImport(t *testing.T) {
+	ts, c := testClient(t)
+	ts.AddRequest(singularity.SingularityRequest{ID: "my-service", RequestType: "SERVICE"})
+	dep, _ := (&singularity.Manifest{
+		Request: singularity.ManifestRequest{ID: "my-service"},
+		Deploy:  &singularity.ManifestDeploy{ID: "d1", Command: "./run.sh"},
+	}).BuildDeploy()
+	if _, err := singularity.NewDeployRequest().AttachDeploy(dep).Create(c); err != nil {
+		t.Fatal(err)
+	}
+
+	var data = []struct {
+		id       string
+		expected string
+	}{
+		{"my-service", "my-service/d1"},
+		{"my-service/d1", "my-service/d1"},
+	}
+	r := resourceDeploy()
+	for _, tt := range data {
+		d := r.TestResourceData()
+		d.SetId(tt.id)
+		res, err := r.Importer.StateContext(context.Background(), d, c)
+		if err != nil {
+			t.Fatalf("Import(%s): unexpected error %v", tt.id, err)
+		}
+		if res[0].Id() != tt.expected || res[0].Get("deploy_id") != "d1" {
+			t.Errorf("Import(%s): expected %s, got %s", tt.id, tt.expected, res[0].Id())
+		}
+	}
+
+	d := r.TestResourceData()
+	d.SetId("missing")
+	if _, err := r.Importer.StateContext(context.Background(), d, c); err == nil {
+		t.Errorf("Import(missing): expected error")
+	}
+}
+
+func TestDataSources(t *testing.T) {
+	ts, c := testClient(t)
+	ts.AddRequest(singularity.SingularityRequest{ID: "my-service", RequestType: "SERVICE", Instances: 2})
+	ts.AddRequest(singularity.SingularityRequest{ID: "other", RequestType: "WORKER"})
+	ts.AddTask(singularity.SingularityTask{TaskID: singularity.SingularityTaskID{ID: "t1", RequestID: "my-service", Host: "h1"}})
+	ts.AddTask(singularity.SingularityTask{TaskID: singularity.SingularityTaskID{ID: "t2", RequestID: "other"}})
+	ctx := context.Background()
+
+	r := dataSourceRequest()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"request_id": "my-service"})
+	if diags := r.ReadContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Read: unexpected error %v", diags)
+	}
+	if d.Get("request_type") != "SERVICE" || d.Get("instances") != 2 || d.Get("state") != "ACTIVE" {
+		t.Errorf("Read: unexpected state %v", d.State())
+	}
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"request_id": "missing"})
+	if diags := r.ReadContext(ctx, d, c); !diags.HasError() {
+		t.Errorf("Read: expected error for missing request")
+	}
+
+	r = dataSourceActiveTasks()
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if diags := r.ReadContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Read: unexpected error %v", diags)
+	}
+	if d.Get("tasks.#") != 2 {
+		t.Errorf("Read: expected %d tasks, got %v", 2, d.Get("tasks.#"))
+	}
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"request_id": "my-service"})
+	if diags := r.ReadContext(ctx, d, c); diags.HasError() {
+		t.Fatalf("Read: unexpected error %v", diags)
+	}
+	if d.Get("tasks.#") != 1 || d.Get("tasks.0.host") != "h1" {
+		t.Errorf("Read: unexpected state %v", d.State())
+	}
+}
@@ -0,0 +1,479 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// deployPollInterval is how often a deploy is polled while waiting for it.
+const deployPollInterval = 2 * time.Second
+
+// deployFields are the attributes which make up a deploy. Singularity
+// deploys are immutable, so changing any of them needs a new deploy_id.
+var deployFields = []string{
+	"command", "arguments", "env", "metadata", "labels", "user",
+	"cpus", "memory_mb", "disk_mb", "num_ports", "docker", "mesos_image", "volume", "uris",
+}
+
+func resourceDeploy() *schema.Resource {
+	return &schema.Resource{
+		Description: "A Singularity deploy of a request. Deploys cannot be removed: " +
+			"destroying this resource cancels the deploy if it is still pending, " +
+			"and otherwise only removes it from the Terraform state.",
+		CreateContext: resourceDeployCreate,
+		ReadContext:   resourceDeployRead,
+		UpdateContext: resourceDeployRead,
+		DeleteContext: resourceDeployDelete,
+		CustomizeDiff: resourceDeployCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDeployImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"request_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"deploy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"command": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"arguments": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"env": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"cpus": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"memory_mb": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"disk_mb": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"num_ports": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"docker": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"network": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"force_pull_image": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						"privileged": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						"parameters": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"mesos_image": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"docker"},
+				Description:   "Run the deploy in this image with the Mesos containerizer.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"DOCKER", "APPC"}, false),
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"cached": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"volume": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host_path": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"container_path": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"uris": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_deploy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Wait for the deploy to finish, and fail if it does not succeed.",
+			},
+			"wait_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     600,
+				Description: "Seconds to wait for the deploy to finish. 0 waits forever.",
+			},
+		},
+	}
+}
+
+// deployManifest returns the manifest deploy described by d.
+func deployManifest(d *schema.ResourceData) *singularity.Manifest {
+	md := &singularity.ManifestDeploy{
+		ID:        d.Get("deploy_id").(string),
+		Command:   d.Get("command").(string),
+		Arguments: stringList(d.Get("arguments")),
+		Env:       stringMap(d.Get("env")),
+		Metadata:  stringMap(d.Get("metadata")),
+		Labels:    stringMap(d.Get("labels")),
+		User:      d.Get("user").(string),
+	}
+	// Singularity applies its default resources only when a deploy has none,
+	// so the block is left out unless one of them is set.
+	for _, k := range []string{"cpus", "memory_mb", "disk_mb", "num_ports"} {
+		if _, ok := d.GetOk(k); ok {
+			md.Resources = &singularity.ManifestResources{
+				Cpus:     d.Get("cpus").(float64),
+				MemoryMb: d.Get("memory_mb").(float64),
+				DiskMb:   d.Get("disk_mb").(float64),
+				NumPorts: int64(d.Get("num_ports").(int)),
+			}
+			break
+		}
+	}
+	for _, u := range stringList(d.Get("uris")) {
+		md.URIs = append(md.URIs, singularity.ManifestArtifact{URI: u})
+	}
+
+	// A deploy with a docker block runs with Docker, and one with only a
+	// Mesos image or volumes runs with the Mesos containerizer.
+	docker := d.Get("docker").([]interface{})
+	image := d.Get("mesos_image").([]interface{})
+	volumes := d.Get("volume").([]interface{})
+	switch {
+	case len(docker) > 0:
+		md.Container = &singularity.ManifestContainer{Type: "DOCKER"}
+	case len(image) > 0 || len(volumes) > 0:
+		md.Container = &singularity.ManifestContainer{Type: "MESOS"}
+	}
+	if len(image) > 0 && image[0] != nil {
+		v := image[0].(map[string]interface{})
+		md.Container.Mesos = &singularity.ManifestMesos{
+			Image: &singularity.ManifestMesosImage{
+				Type:   v["type"].(string),
+				Name:   v["name"].(string),
+				ID:     v["id"].(string),
+				Cached: v["cached"].(bool),
+			},
+		}
+	}
+	if len(docker) > 0 && docker[0] != nil {
+		v := docker[0].(map[string]interface{})
+		md.Container.Docker = &singularity.ManifestDocker{
+			Image:          v["image"].(string),
+			Network:        v["network"].(string),
+			ForcePullImage: v["force_pull_image"].(bool),
+			Privileged:     v["privileged"].(bool),
+			Parameters:     stringMap(v["parameters"]),
+		}
+	}
+	for _, i := range volumes {
+		v := i.(map[string]interface{})
+		md.Container.Volumes = append(md.Container.Volumes, singularity.ManifestVolume{
+			HostPath:      v["host_path"].(string),
+			ContainerPath: v["container_path"].(string),
+			Mode:          v["mode"].(string),
+		})
+	}
+
+	return &singularity.Manifest{
+		Version: singularity.ManifestVersion,
+		Request: singularity.ManifestRequest{ID: d.Get("request_id").(string)},
+		Deploy:  md,
+	}
+}
+
+func deployID(requestID, deployID string) string {
+	return requestID + "/" + deployID
+}
+
+func resourceDeployCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || d.HasChange("deploy_id") {
+		return nil
+	}
+	for _, k := range deployFields {
+		if d.HasChange(k) {
+			return fmt.Errorf("%s of deploy %s changed: changing a deploy requires a new deploy_id", k, d.Get("deploy_id"))
+		}
+	}
+	return nil
+}
+
+func resourceDeployCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*singularity.Client)
+	dep, err := deployManifest(d).BuildDeploy()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := singularity.NewDeployRequest().AttachDeploy(dep).Create(c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Create reports a 409, an already pending deploy, as success.
+	if res.RestyResponse.StatusCode() == http.StatusConflict {
+		return diag.Errorf("Singularity deploy %s was not accepted: %s", dep.Get().ID, res.RestyResponse.Body())
+	}
+
+	requestID := d.Get("request_id").(string)
+	id := d.Get("deploy_id").(string)
+	if d.Get("wait_for_deploy").(bool) {
+		timeout := time.Duration(d.Get("wait_timeout").(int)) * time.Second
+		result, err := c.WaitForDeploy(requestID, id, deployPollInterval, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !result.Succeeded() {
+			return diag.Errorf("Singularity deploy %s finished with %s: %s", id, result.DeployState, result.Message)
+		}
+	}
+	d.SetId(deployID(requestID, id))
+	return resourceDeployRead(ctx, d, m)
+}
+
+func resourceDeployRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Get("deploy_id").(string)
+	res, err := m.(*singularity.Client).GetRequestByID(d.Get("request_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	r := res.Body
+	switch {
+	case res.RestyResponse.StatusCode() == http.StatusNotFound:
+		// The request, and so its deploys, were deleted.
+		d.SetId("")
+		return nil
+	case r.RequestDeployState.PendingDeployState.DeployID == id:
+		return nil
+	case r.RequestDeployState.ActiveDeploy.DeployID != id:
+		// Another deploy replaced this one, or it failed.
+		d.SetId("")
+		return nil
+	case r.ActiveDeploy.ID != id:
+		return nil
+	}
+
+	a := r.ActiveDeploy
+	var uris []string
+	for _, u := range a.Uris {
+		uris = append(uris, u.URI)
+	}
+	var docker []interface{}
+	if a.ContainerInfo.DockerInfo.Image != "" {
+		i := a.ContainerInfo.DockerInfo
+		docker = append(docker, map[string]interface{}{
+			"image":            i.Image,
+			"network":          i.Network,
+			"force_pull_image": i.ForcePullImage,
+			"privileged":       i.Privileged,
+			"parameters":       i.Parameters,
+		})
+	}
+	var image []interface{}
+	if m := a.ContainerInfo.Mesos; m != nil && m.Image != nil {
+		v := map[string]interface{}{
+			"type":   m.Image.Type,
+			"cached": m.Image.Cached,
+		}
+		if m.Image.Docker != nil {
+			v["name"] = m.Image.Docker.Name
+		}
+		if m.Image.Appc != nil {
+			v["name"] = m.Image.Appc.Name
+			v["id"] = m.Image.Appc.ID
+		}
+		image = append(image, v)
+	}
+	var volumes []interface{}
+	for _, v := range a.ContainerInfo.Volumes {
+		volumes = append(volumes, map[string]interface{}{
+			"host_path":      v.HostPath,
+			"container_path": v.ContainerPath,
+			"mode":           v.Mode,
+		})
+	}
+	values := map[string]interface{}{
+		"command":     a.Command,
+		"arguments":   a.Arguments,
+		"env":         a.Env,
+		"metadata":    a.Metadata,
+		"labels":      a.Labels,
+		"user":        a.User,
+		"cpus":        a.Cpus,
+		"memory_mb":   a.MemoryMb,
+		"disk_mb":     a.DiskMb,
+		"num_ports":   int(a.NumPorts),
+		"docker":      docker,
+		"mesos_image": image,
+		"volume":      volumes,
+		"uris":        uris,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceDeployDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*singularity.Client)
+	requestID := d.Get("request_id").(string)
+	id := d.Get("deploy_id").(string)
+	res, err := c.GetRequestByID(requestID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if res.Body.RequestDeployState.PendingDeployState.DeployID == id {
+		if _, err := singularity.NewDeleteDeploy(requestID, id).Delete(c); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceDeployImport accepts either "request_id/deploy_id", or a request
+// ID on its own to import the request's active deploy.
+func resourceDeployImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	requestID := parts[0]
+	var id string
+	if len(parts) == 2 {
+		id = parts[1]
+	} else {
+		res, err := m.(*singularity.Client).GetRequestByID(requestID)
+		if err != nil {
+			return nil, err
+		}
+		if res.RestyResponse.StatusCode() == http.StatusNotFound {
+			return nil, fmt.Errorf("Singularity request %s not found", requestID)
+		}
+		id = res.Body.RequestDeployState.ActiveDeploy.DeployID
+		if id == "" {
+			return nil, fmt.Errorf("Singularity request %s has no active deploy", requestID)
+		}
+	}
+	if requestID == "" || id == "" {
+		return nil, fmt.Errorf("invalid import ID %q: expected request_id or request_id/deploy_id", d.Id())
+	}
+
+	d.SetId(deployID(requestID, id))
+	d.Set("request_id", requestID)
+	d.Set("deploy_id", id)
+	d.Set("wait_for_deploy", true)
+	d.Set("wait_timeout", 600)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+var requestTypes = []string{"SERVICE", "WORKER", "SCHEDULED", "ON_DEMAND", "RUN_ONCE"}
+
+func resourceRequest() *schema.Resource {
+	return &schema.Resource{
+		Description:   "A Singularity request: a single project or deployable item.",
+		CreateContext: resourceRequestCreate,
+		ReadContext:   resourceRequestRead,
+		UpdateContext: resourceRequestUpdate,
+		DeleteContext: resourceRequestDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"request_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"request_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(requestTypes, false),
+			},
+			"instances": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"schedule": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"schedule_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"num_retries_on_failure": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"max_tasks_per_offer": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"slave_placement": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"owners": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"required_role": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rack_sensitive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"load_balanced": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"skip_healthchecks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// requestManifest returns the manifest request described by d.
+func requestManifest(d *schema.ResourceData) *singularity.Manifest {
+	return &singularity.Manifest{
+		Version: singularity.ManifestVersion,
+		Request: singularity.ManifestRequest{
+			ID:                  d.Get("request_id").(string),
+			Type:                d.Get("request_type").(string),
+			Instances:           int64(d.Get("instances").(int)),
+			Schedule:            d.Get("schedule").(string),
+			ScheduleType:        d.Get("schedule_type").(string),
+			NumRetriesOnFailure: int64(d.Get("num_retries_on_failure").(int)),
+			MaxTasksPerOffer:    d.Get("max_tasks_per_offer").(int),
+			SlavePlacement:      d.Get("slave_placement").(string),
+			Owners:              stringList(d.Get("owners")),
+			RequiredRole:        d.Get("required_role").(string),
+			RackSensitive:       d.Get("rack_sensitive").(bool),
+			LoadBalanced:        d.Get("load_balanced").(bool),
+			SkipHealthchecks:    d.Get("skip_healthchecks").(bool),
+		},
+	}
+}
+
+// saveRequest creates or updates the request described by d. Singularity
+// updates an existing request when it is posted again.
+func saveRequest(d *schema.ResourceData, c *singularity.Client) error {
+	r, err := requestManifest(d).BuildRequest()
+	if err != nil {
+		return err
+	}
+	res, err := r.Create(c)
+	if err != nil {
+		return err
+	}
+	if code := res.RestyResponse.StatusCode(); code < 200 || code > 299 {
+		return fmt.Errorf("Create Singularity request error: %s", res.RestyResponse.Body())
+	}
+	return nil
+}
+
+func resourceRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := saveRequest(d, m.(*singularity.Client)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("request_id").(string))
+	return resourceRequestRead(ctx, d, m)
+}
+
+func resourceRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	res, err := m.(*singularity.Client).GetRequestByID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if res.RestyResponse.StatusCode() == http.StatusNotFound {
+		// The request was deleted outside of Terraform.
+		d.SetId("")
+		return nil
+	}
+	r := res.Body.SingularityRequest
+
+	placement := ""
+	if r.SlavePlacement != nil {
+		placement = r.SlavePlacement.String()
+	}
+	values := map[string]interface{}{
+		"request_id":             r.ID,
+		"request_type":           r.RequestType.String(),
+		"instances":              int(r.Instances),
+		"schedule":               r.Schedule,
+		"schedule_type":          r.ScheduleType,
+		"num_retries_on_failure": int(r.NumRetriesOnFailure),
+		"max_tasks_per_offer":    r.MaxTasksPerOffer,
+		"slave_placement":        placement,
+		"owners":                 r.Owners,
+		"required_role":          r.RequiredRole,
+		"rack_sensitive":         r.RackSensitive,
+		"load_balanced":          r.LoadBalanced,
+		"skip_healthchecks":      r.SkipHealthchecks,
+		"state":                  res.Body.State.String(),
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := saveRequest(d, m.(*singularity.Client)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceRequestRead(ctx, d, m)
+}
+
+func resourceRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	r := singularity.NewDeleteRequest(d.Id(), "Deleted by Terraform", "", false)
+	if _, err := singularity.DeleteRequest(m.(*singularity.Client), r); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
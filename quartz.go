@@ -0,0 +1,309 @@
+package singularity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quartz year bounds. Quartz does not accept years outside this range, and
+// it also bounds how far ahead quartzSchedule.next searches.
+const (
+	quartzMinYear = 1970
+	quartzMaxYear = 2099
+)
+
+var (
+	quartzMonths = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	quartzDays = map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}
+)
+
+// quartzSchedule is a parsed Quartz cron expression, which has the fields
+// seconds, minutes, hours, day-of-month, month, day-of-week and an optional
+// year. Day of week runs from 1 (SUN) to 7 (SAT). Exactly one of
+// day-of-month and day-of-week must be "?".
+type quartzSchedule struct {
+	seconds, minutes, hours []bool
+	months                  []bool
+	years                   []bool // indexed from quartzMinYear
+
+	// Day of month, used unless it is "?".
+	anyDom      bool
+	dom         []bool
+	lastDay     bool
+	lastOffset  int   // L-n
+	lastWeekday bool  // LW
+	weekdays    []int // nW: the weekday nearest to day n
+
+	// Day of week, used when day of month is "?".
+	dow     []bool
+	lastDow []int    // nL: the last day n of the month
+	nthDow  [][2]int // n#k: the kth day n of the month
+}
+
+// parseQuartz parses a Quartz cron expression.
+func parseQuartz(spec string) (*quartzSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("Parse %s quartz schedule error. Expected 6 or 7 fields, found %d", spec, len(fields))
+	}
+	if len(fields) == 6 {
+		fields = append(fields, "*")
+	}
+	domField, dowField := strings.ToUpper(fields[3]), strings.ToUpper(fields[5])
+	if (domField == "?") == (dowField == "?") {
+		return nil, fmt.Errorf("Parse %s quartz schedule error. Exactly one of day-of-month and day-of-week must be ?", spec)
+	}
+
+	s := &quartzSchedule{anyDom: domField == "?"}
+	var err error
+	if s.seconds, err = quartzField(fields[0], 0, 59, nil); err != nil {
+		return nil, quartzError(spec, "seconds", err)
+	}
+	if s.minutes, err = quartzField(fields[1], 0, 59, nil); err != nil {
+		return nil, quartzError(spec, "minutes", err)
+	}
+	if s.hours, err = quartzField(fields[2], 0, 23, nil); err != nil {
+		return nil, quartzError(spec, "hours", err)
+	}
+	if s.months, err = quartzField(fields[4], 1, 12, quartzMonths); err != nil {
+		return nil, quartzError(spec, "month", err)
+	}
+	if s.years, err = quartzField(fields[6], quartzMinYear, quartzMaxYear, nil); err != nil {
+		return nil, quartzError(spec, "year", err)
+	}
+	if s.anyDom {
+		err = s.parseDow(dowField)
+		if err != nil {
+			return nil, quartzError(spec, "day-of-week", err)
+		}
+	} else if err = s.parseDom(domField); err != nil {
+		return nil, quartzError(spec, "day-of-month", err)
+	}
+	return s, nil
+}
+
+func quartzError(spec, field string, err error) error {
+	return fmt.Errorf("Parse %s quartz schedule error. Invalid %s: %v", spec, field, err)
+}
+
+// parseDom parses a day-of-month field, which may use L, L-n, LW and nW
+// alongside the usual values.
+func (s *quartzSchedule) parseDom(field string) error {
+	var plain []string
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case item == "L":
+			s.lastDay = true
+		case item == "LW":
+			s.lastWeekday = true
+		case strings.HasPrefix(item, "L-"):
+			n, err := strconv.Atoi(item[2:])
+			if err != nil || n < 0 || n > 30 {
+				return fmt.Errorf("invalid offset %q", item)
+			}
+			s.lastDay = true
+			s.lastOffset = n
+		case strings.HasSuffix(item, "W"):
+			n, err := strconv.Atoi(strings.TrimSuffix(item, "W"))
+			if err != nil || n < 1 || n > 31 {
+				return fmt.Errorf("invalid weekday %q", item)
+			}
+			s.weekdays = append(s.weekdays, n)
+		default:
+			plain = append(plain, item)
+		}
+	}
+	var err error
+	s.dom, err = quartzField(strings.Join(plain, ","), 1, 31, nil)
+	return err
+}
+
+// parseDow parses a day-of-week field, which may use nL and n#k alongside
+// the usual values.
+func (s *quartzSchedule) parseDow(field string) error {
+	var plain []string
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case item == "L":
+			plain = append(plain, "7")
+		case strings.HasSuffix(item, "L"):
+			n, err := quartzValue(strings.TrimSuffix(item, "L"), 1, 7, quartzDays)
+			if err != nil {
+				return err
+			}
+			s.lastDow = append(s.lastDow, n)
+		case strings.Contains(item, "#"):
+			parts := strings.SplitN(item, "#", 2)
+			n, err := quartzValue(parts[0], 1, 7, quartzDays)
+			if err != nil {
+				return err
+			}
+			k, err := strconv.Atoi(parts[1])
+			if err != nil || k < 1 || k > 5 {
+				return fmt.Errorf("invalid occurrence %q", item)
+			}
+			s.nthDow = append(s.nthDow, [2]int{n, k})
+		default:
+			plain = append(plain, item)
+		}
+	}
+	var err error
+	s.dow, err = quartzField(strings.Join(plain, ","), 1, 7, quartzDays)
+	return err
+}
+
+// quartzField parses a comma separated list of *, values, ranges and
+// increments into a set indexed from min. An empty field is an empty set.
+func quartzField(field string, min, max int, names map[string]int) ([]bool, error) {
+	set := make([]bool, max-min+1)
+	if field == "" {
+		return set, nil
+	}
+	for _, item := range strings.Split(strings.ToUpper(field), ",") {
+		start, end, step := min, max, 1
+		expr := item
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid increment %q", item)
+			}
+			expr = item[:i]
+		}
+		switch {
+		case expr == "*" || expr == "?":
+		case strings.Contains(expr, "-"):
+			parts := strings.SplitN(expr, "-", 2)
+			var err error
+			if start, err = quartzValue(parts[0], min, max, names); err != nil {
+				return nil, err
+			}
+			if end, err = quartzValue(parts[1], min, max, names); err != nil {
+				return nil, err
+			}
+			if start > end {
+				return nil, fmt.Errorf("invalid range %q", expr)
+			}
+		default:
+			var err error
+			if start, err = quartzValue(expr, min, max, names); err != nil {
+				return nil, err
+			}
+			if step == 1 {
+				end = start
+			}
+		}
+		for v := start; v <= end; v += step {
+			set[v-min] = true
+		}
+	}
+	return set, nil
+}
+
+func quartzValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[s]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("value %q is not between %d and %d", s, min, max)
+	}
+	return v, nil
+}
+
+// next returns the first time after t which matches s, in t's location, or
+// the zero time if there is none before quartzMaxYear ends.
+func (s *quartzSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Second).Add(time.Second)
+	loc := t.Location()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for ; day.Year() <= quartzMaxYear; day = day.AddDate(0, 0, 1) {
+		y, m, d := day.Date()
+		if y < quartzMinYear || !s.years[y-quartzMinYear] || !s.months[m-1] || !s.matchDay(y, m, d) {
+			continue
+		}
+		for h, ok := range s.hours {
+			if !ok {
+				continue
+			}
+			for min, ok := range s.minutes {
+				if !ok {
+					continue
+				}
+				for sec, ok := range s.seconds {
+					if !ok {
+						continue
+					}
+					c := time.Date(y, m, d, h, min, sec, 0, loc)
+					// time.Date moves a time skipped by a daylight saving
+					// change to another hour, which must not fire.
+					if c.Hour() != h || c.Minute() != min {
+						continue
+					}
+					if !c.Before(t) {
+						return c
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func (s *quartzSchedule) matchDay(y int, m time.Month, d int) bool {
+	last := time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if !s.anyDom {
+		if s.dom[d-1] || s.lastDay && d == last-s.lastOffset {
+			return true
+		}
+		if s.lastWeekday && d == nearestWeekday(y, m, last, last) {
+			return true
+		}
+		for _, n := range s.weekdays {
+			if n <= last && d == nearestWeekday(y, m, n, last) {
+				return true
+			}
+		}
+		return false
+	}
+
+	wd := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Weekday()) + 1
+	if s.dow[wd-1] {
+		return true
+	}
+	for _, n := range s.lastDow {
+		if n == wd && d+7 > last {
+			return true
+		}
+	}
+	for _, nk := range s.nthDow {
+		if nk[0] == wd && (d-1)/7+1 == nk[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestWeekday returns the weekday (Monday to Friday) nearest to day n of
+// the month, without leaving the month.
+func nearestWeekday(y int, m time.Month, n, last int) int {
+	switch time.Date(y, m, n, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if n == 1 {
+			return 3
+		}
+		return n - 1
+	case time.Sunday:
+		if n == last {
+			return n - 2
+		}
+		return n + 1
+	}
+	return n
+}
@@ -0,0 +1,114 @@
+package singularity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuartzErrors(t *testing.T) {
+	var data = []string{
+		"0 0 12 * *",
+		"0 0 12 * * MON",
+		"0 0 12 ? * ?",
+		"60 0 12 ? * MON",
+		"0 0 25 ? * MON",
+		"0 0 12 ? FOO MON",
+		"0 0 12 ? * 8",
+		"0 0 12 ? * MON#6",
+		"0 0 12 32W * ?",
+		"0 0 12 ? * MON 1900",
+		"0 0 12 ? * MON/0",
+		"0 0 12-10 ? * MON",
+	}
+	for _, spec := range data {
+		if _, err := parseQuartz(spec); err == nil {
+			t.Errorf("parseQuartz(%s): expected error", spec)
+		}
+	}
+}
+
+func TestQuartzNext(t *testing.T) {
+	// Friday 1 March 2019, 10:00 UTC.
+	from := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	var data = []struct {
+		spec     string
+		expected []string
+	}{
+		{"0 0 12 ? * MON-FRI", []string{"2019-03-01T12:00:00Z", "2019-03-04T12:00:00Z", "2019-03-05T12:00:00Z"}},
+		{"0 0/20 10 * * ?", []string{"2019-03-01T10:20:00Z", "2019-03-01T10:40:00Z", "2019-03-02T10:00:00Z"}},
+		{"15,45 0 0 1 JAN,JUL ?", []string{"2019-07-01T00:00:15Z", "2019-07-01T00:00:45Z", "2020-01-01T00:00:15Z"}},
+		{"0 0 9 L * ?", []string{"2019-03-31T09:00:00Z", "2019-04-30T09:00:00Z", "2019-05-31T09:00:00Z"}},
+		{"0 0 9 L-2 * ?", []string{"2019-03-29T09:00:00Z", "2019-04-28T09:00:00Z", "2019-05-29T09:00:00Z"}},
+		// The weekday nearest 1 June 2019, a Saturday, is Monday the 3rd.
+		{"0 0 9 1W * ?", []string{"2019-04-01T09:00:00Z", "2019-05-01T09:00:00Z", "2019-06-03T09:00:00Z"}},
+		{"0 0 9 LW * ?", []string{"2019-03-29T09:00:00Z", "2019-04-30T09:00:00Z", "2019-05-31T09:00:00Z"}},
+		{"0 0 9 ? * 6L", []string{"2019-03-29T09:00:00Z", "2019-04-26T09:00:00Z", "2019-05-31T09:00:00Z"}},
+		{"0 0 9 ? * MON#2", []string{"2019-03-11T09:00:00Z", "2019-04-08T09:00:00Z", "2019-05-13T09:00:00Z"}},
+		{"0 0 9 29 FEB ? 2019-2024", []string{"2020-02-29T09:00:00Z", "2024-02-29T09:00:00Z"}},
+	}
+
+	for _, tt := range data {
+		q, err := parseQuartz(tt.spec)
+		if err != nil {
+			t.Errorf("parseQuartz(%s): unexpected error %v", tt.spec, err)
+			continue
+		}
+		var got []string
+		next := from
+		for i := 0; i < 3; i++ {
+			next = q.next(next)
+			if next.IsZero() {
+				break
+			}
+			got = append(got, next.Format(time.RFC3339))
+		}
+		if len(got) != len(tt.expected) {
+			t.Errorf("next(%s): expected %v, got %v", tt.spec, tt.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("next(%s): expected %v, got %v", tt.spec, tt.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestQuartzNextDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("America/New_York: %v", err)
+	}
+
+	// Clocks go forward from 02:00 to 03:00 on 8 March 2026, and back from
+	// 02:00 to 01:00 on 1 November 2026.
+	var data = []struct {
+		spec     string
+		from     time.Time
+		expected []string
+	}{
+		{"0 30 2 * * ?", time.Date(2026, 3, 7, 12, 0, 0, 0, loc), []string{"2026-03-09T02:30:00-04:00", "2026-03-10T02:30:00-04:00"}},
+		{"0 0/30 * * * ?", time.Date(2026, 3, 8, 1, 0, 0, 0, loc), []string{"2026-03-08T01:30:00-05:00", "2026-03-08T03:00:00-04:00"}},
+		{"0 30 1 * * ?", time.Date(2026, 10, 31, 12, 0, 0, 0, loc), []string{"2026-11-01T01:30:00-04:00", "2026-11-02T01:30:00-05:00"}},
+	}
+
+	for _, tt := range data {
+		q, err := parseQuartz(tt.spec)
+		if err != nil {
+			t.Errorf("parseQuartz(%s): unexpected error %v", tt.spec, err)
+			continue
+		}
+		var got []string
+		next := tt.from
+		for range tt.expected {
+			next = q.next(next)
+			got = append(got, next.Format(time.RFC3339))
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("next(%s) from %v: expected %v, got %v", tt.spec, tt.from, tt.expected, got)
+		}
+	}
+}
@@ -2,63 +2,60 @@ package singularity
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty"
 	cron "gopkg.in/robfig/cron.v2"
 )
 
-const (
-	ON_DEMAND = 1
-	SERVICE   = 2
-	SCHEDULED = 3
-	RUN_ONCE  = 4
-	WORKER    = 5
-)
-
-// NewRequest accepts 5 types of Singularity reqeuests, a string id
-// and return default values for each type of request with request id.
-func NewRequest(t int, id string) ServiceRequest {
+// NewRequest accepts one of the 5 types of Singularity requests and a string
+// id, and returns default values for that type of request with the id. Any
+// other type is kept as it is, so that Validate reports it.
+func NewRequest(t RequestType, id string) ServiceRequest {
 	switch t {
-	case ON_DEMAND:
+	case RequestTypeOnDemand:
 		return &SingularityRequest{
-			RequestType: "ON_DEMAND",
+			RequestType: RequestTypeOnDemand,
 			ID:          id,
 		}
-	case SERVICE:
+	case RequestTypeService:
 		return &SingularityRequest{
-			RequestType: "SERVICE",
+			RequestType: RequestTypeService,
 			Instances:   1,
 			ID:          id,
 		}
-	case SCHEDULED:
+	case RequestTypeScheduled:
 		return &SingularityRequest{
-			RequestType:  "SCHEDULED",
+			RequestType:  RequestTypeScheduled,
 			ScheduleType: "CRON",
 			ID:           id,
 		}
-	case RUN_ONCE:
+	case RequestTypeRunOnce:
 		return &SingularityRequest{
-			RequestType: "RUN_ONCE",
+			RequestType: RequestTypeRunOnce,
 			Instances:   1,
 			ID:          id,
 		}
-	case WORKER:
+	case RequestTypeWorker:
 		return &SingularityRequest{
-			RequestType: "WORKER",
+			RequestType: RequestTypeWorker,
 			Instances:   1,
 			ID:          id,
 		}
 	}
-	return nil
+	return &SingularityRequest{
+		RequestType: t,
+		ID:          id,
+	}
 }
 
 // GetRequests retrieve the list of all Singularity requests.
 // https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#endpoint-/api/requests
 func (c *Client) GetRequests() (*resty.Response, Requests, error) {
 	var body Requests
-	res, err := c.Rest.
-		R().
+	res, err := c.request().
 		Get("/api/requests")
 
 	err = c.Rest.JSONUnmarshal(res.Body(), &body)
@@ -79,15 +76,22 @@ func (s Requests) GetRequestID(n string) Request {
 }
 
 // GetRequestByID accpets string id and retrieve a specific Singularity Request by ID
+// A request which doesn't exist is returned with a 404 RestyResponse, an
+// empty Body and no error. Any other status outside 2xx is an error.
 // https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apirequestsrequestrequestid
 func (c *Client) GetRequestByID(id string) (HTTPResponse, error) {
-	res, err := c.Rest.
-		R().
+	res, err := c.request().
 		Get("/api/requests/request" + "/" + id)
 
 	if err != nil {
 		return HTTPResponse{}, fmt.Errorf("Get Singularity request not found: %v", err)
 	}
+	if res.StatusCode() == http.StatusNotFound {
+		return HTTPResponse{RestyResponse: res}, nil
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return HTTPResponse{RestyResponse: res}, fmt.Errorf("Get Singularity request error: %v", string(res.Body()))
+	}
 
 	var data Request
 	err = c.Rest.JSONUnmarshal(res.Body(), &data)
@@ -100,9 +104,9 @@ func (c *Client) GetRequestByID(id string) (HTTPResponse, error) {
 	}, nil
 }
 
-// HTTPResponse contains response and body from a http query.
-// TODO: Move different response type to use interface{} rather
-// than user defined types.
+// HTTPResponse contains response and body from a http query. Each call
+// fills a different field; the v2 package returns a typed result for each
+// call instead.
 type HTTPResponse struct {
 	RestyResponse *resty.Response
 	Body          Request
@@ -126,9 +130,15 @@ type ServiceRequest interface {
 	SetInstances(int64) ServiceRequest
 	SetSchedule(string) (ServiceRequest, error)
 	SetScheduleType(string) (ServiceRequest, error)
+	SetQuartzSchedule(string) (ServiceRequest, error)
+	SetScheduleTimeZone(string) (ServiceRequest, error)
+	NextRuns(int, time.Time) ([]time.Time, error)
 	SetMaxTasksPerOffer(int) ServiceRequest
 	SetNumRetriesOnFailures(int64) ServiceRequest
-	SetSlavePlacement(string) ServiceRequest
+	SetSlavePlacement(SlavePlacement) ServiceRequest
+	SetTaskExecutionTimeLimit(time.Duration) ServiceRequest
+	SetKillOldNonLongRunningTasksAfter(time.Duration) ServiceRequest
+	Validate() error
 }
 
 // SetID accepts a string to assign a request ID.
@@ -151,19 +161,28 @@ func (r *SingularityRequest) SetNumRetriesOnFailures(i int64) ServiceRequest {
 	return r
 }
 
-// SetScheduleType accepts a cron schedule format.
-// Only cron is accepted because this is widely know than quartz.
+// SetScheduleType accepts a schedule type, CRON or QUARTZ, which says how
+// Schedule is parsed.
 func (r *SingularityRequest) SetScheduleType(t string) (ServiceRequest, error) {
-	if strings.ToLower(t) != "cron" {
-		return nil, fmt.Errorf("%v", "Only cron scheduleType is allowed.")
+	t = strings.ToUpper(t)
+	if t != "CRON" && t != "QUARTZ" {
+		return nil, fmt.Errorf("%v", "Only CRON and QUARTZ scheduleTypes are allowed.")
 	}
 	r.ScheduleType = t
 	return r, nil
 }
 
 // SetSchedule accepts a cron schedule format as string
-// and set shedule for this request.
+// and set shedule for this request. The schedule is parsed as
+// Quartz if the schedule type is QUARTZ.
 func (r *SingularityRequest) SetSchedule(s string) (ServiceRequest, error) {
+	if r.ScheduleType == "QUARTZ" {
+		if _, err := parseQuartz(s); err != nil {
+			return nil, err
+		}
+		r.Schedule = s
+		return r, nil
+	}
 	// Singularity Request expects CRON schedule a string. Hence, we just use cron package
 	// to parse and validate this value.
 	_, err := cron.Parse(s)
@@ -189,12 +208,26 @@ func (r *SingularityRequest) SetMaxTasksPerOffer(i int) ServiceRequest {
 	return r
 }
 
+// SetTaskExecutionTimeLimit accepts a time.Duration after which Singularity
+// kills the tasks of this request. This is optional.
+func (r *SingularityRequest) SetTaskExecutionTimeLimit(d time.Duration) ServiceRequest {
+	r.TaskExecutionTimeLimitMillis = MillisOf(d)
+	return r
+}
+
+// SetKillOldNonLongRunningTasksAfter accepts a time.Duration after which
+// Singularity kills tasks of an older deploy of this ON_DEMAND or SCHEDULED
+// request. This is optional.
+func (r *SingularityRequest) SetKillOldNonLongRunningTasksAfter(d time.Duration) ServiceRequest {
+	r.KillOldNonLongRunningTasksAfterMillis = MillisOf(d)
+	return r
+}
+
 // Create accepts ServiceRequest struct and Creates a Singularity
 // job based on a requestType. Valid types are: SERVICE, WORKER, SCHEDULED,
 // ON_DEMAND, RUN_ONCE.
 func (r *SingularityRequest) Create(c *Client) (HTTPResponse, error) {
-	res, err := c.Rest.
-		R().
+	res, err := c.request().
 		SetHeader("Content-Type", "application/json").
 		SetBody(r).
 		Post("/api/requests")
@@ -251,8 +284,7 @@ func DeleteRequest(c *Client, r DeleteHTTPRequest) (HTTPResponse, error) {
 // contains metadata when deleting this Request. This also deletes any
 // deploy attach to this requestID.
 func (r DeleteHTTPRequest) delete(c *Client) (HTTPResponse, error) {
-	res, err := c.Rest.
-		R().
+	res, err := c.request().
 		Delete("/api/requests/request/" + r.id)
 	if err != nil {
 		return HTTPResponse{}, fmt.Errorf("Delete Singularity request error: %v", err)
@@ -291,26 +323,134 @@ func ScaleRequest(c *Client, r ScaleHTTPRequest) (HTTPResponse, error) {
 	return r.scale(c)
 }
 
-// NewRequestScale accepts an id string and a int and returns a pointer to
-// type ScaleRequest which have a minimum required paramters to scale a
-// Singularity request.
+// NewRequestScale accepts an id string, a message and an instance count i,
+// and returns a pointer to type ScaleRequest which have a minimum required
+// paramters to scale a Singularity request. A non-zero in makes a bounce
+// incremental, like SetIncremental(true).
 func NewRequestScale(id, m string, i, in int) *ScaleHTTPRequest {
 	return &ScaleHTTPRequest{
 		id: id,
 		SingularityScaleRequest: SingularityScaleRequest{
 			Instances:   i,
 			Message:     m,
-			Incremental: in,
+			Incremental: in != 0,
 		},
 	}
 }
 
+// SetMessage accepts a message to show to users about why the request was
+// scaled.
+func (r *ScaleHTTPRequest) SetMessage(m string) *ScaleHTTPRequest {
+	r.Message = m
+	return r
+}
+
+// SetDuration accepts a time.Duration after which Singularity scales the
+// request back to its current instance count. Zero, the default, scales it
+// for good.
+func (r *ScaleHTTPRequest) SetDuration(d time.Duration) *ScaleHTTPRequest {
+	r.DurationMillis = MillisOf(d)
+	return r
+}
+
+// SetBounce accepts a bool to bounce the request after scaling it, so that
+// its tasks are spread over agents again.
+func (r *ScaleHTTPRequest) SetBounce(b bool) *ScaleHTTPRequest {
+	r.Bounce = b
+	return r
+}
+
+// SetIncremental accepts a bool to kill old tasks of a bounce as soon as
+// each replacement task is available.
+func (r *ScaleHTTPRequest) SetIncremental(b bool) *ScaleHTTPRequest {
+	r.Incremental = b
+	return r
+}
+
+// SetSkipHealthchecks accepts a bool to skip the healthchecks of the tasks
+// started by this scale.
+func (r *ScaleHTTPRequest) SetSkipHealthchecks(b bool) *ScaleHTTPRequest {
+	r.SkipHealthchecks = b
+	return r
+}
+
+// SetActionID accepts an id to associate with this scale, which Singularity
+// records in the expiring scale.
+func (r *ScaleHTTPRequest) SetActionID(id string) *ScaleHTTPRequest {
+	r.ActionID = id
+	return r
+}
+
+// CancelExpiringScale accepts a *Client and a request id, and cancels the
+// expiring scale of that request. The request keeps its current instance
+// count instead of being scaled back.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#delete-apirequestsrequestrequestidscale
+func CancelExpiringScale(c *Client, id string) (HTTPResponse, error) {
+	return requestAction(c, "Cancel scale", "DELETE", id, "scale", nil)
+}
+
+// GetExpiringScale accepts a request id and retrieves its expiring scale,
+// or nil if the request was not scaled for a limited time.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apirequestsrequestrequestid
+func (c *Client) GetExpiringScale(requestID string) (*SingularityExpiringScale, error) {
+	p, err := c.GetRequestParent(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if p.SingularityExpiringScale.StartMillis == 0 {
+		return nil, nil
+	}
+	return &p.SingularityExpiringScale, nil
+}
+
+// Expires returns when Singularity scales the request back to
+// RevertToInstances.
+func (s SingularityExpiringScale) Expires() time.Time {
+	return s.StartMillis.Time().Add(s.SingularityExpiringAPIRequestObject.DurationMillis.Duration())
+}
+
+// GetRequestHistory accepts a request id and retrieves the changes to that
+// request, newest first.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apihistoryrequestrequestidrequests
+func (c *Client) GetRequestHistory(requestID string) (*resty.Response, []SingularityRequestHistory, error) {
+	res, err := c.request().
+		Get("/api/history/request/" + requestID + "/requests")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity request history error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity request history error: %v", string(res.Body()))
+	}
+
+	var body []SingularityRequestHistory
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity request history error: %v", err)
+	}
+	return res, body, nil
+}
+
+// GetScaleHistory accepts a request id and retrieves the times that request
+// was scaled, or scaled back by an expiring scale, newest first.
+func (c *Client) GetScaleHistory(requestID string) ([]SingularityRequestHistory, error) {
+	_, history, err := c.GetRequestHistory(requestID)
+	if err != nil {
+		return nil, err
+	}
+	var scales []SingularityRequestHistory
+	for _, h := range history {
+		if h.EventType == "SCALED" || h.EventType == "SCALE_REVERTED" {
+			scales = append(scales, h)
+		}
+	}
+	return scales, nil
+}
+
 // Scale accepts ServiceRequest struct and Creates a Singularity
 // job based on a requestType. Valid types are: SERVICE, WORKER, SCHEDULED,
 // ON_DEMAND, RUN_ONCE.
 func (r *ScaleHTTPRequest) scale(c *Client) (HTTPResponse, error) {
-	res, err := c.Rest.
-		R().
+	res, err := c.request().
 		SetHeader("Content-Type", "application/json").
 		SetBody(r.SingularityScaleRequest).
 		Put("/api/requests/request/" + r.id + "/scale")
@@ -336,11 +476,12 @@ func (r *ScaleHTTPRequest) scale(c *Client) (HTTPResponse, error) {
 	return response, nil
 }
 
-// SetSlavePlacement accepts a string and return a ServiceRequest struct. This
-// is to set a strategy for determining where to place new tasks. Can be
-// SEPARATE, OPTIMISTIC, GREEDY, SEPARATE_BY_DEPLOY, or SEPARATE_BY_REQUEST.
-func (r *SingularityRequest) SetSlavePlacement(s string) ServiceRequest {
-	r.SlavePlacement = &s
+// SetSlavePlacement accepts a SlavePlacement and return a ServiceRequest
+// struct. This is to set a strategy for determining where to place new tasks.
+// Can be SEPARATE, OPTIMISTIC, GREEDY, SEPARATE_BY_DEPLOY, SEPARATE_BY_REQUEST
+// or SPREAD_ALL_SLAVES.
+func (r *SingularityRequest) SetSlavePlacement(p SlavePlacement) ServiceRequest {
+	r.SlavePlacement = &p
 	return r
 }
 
@@ -398,10 +539,11 @@ func (r *SingularityDeployRequest) SetID(id string) *SingularityDeployRequest {
 	return r
 }
 
-// Create Creates a deploy and attach to a existing request.
+// Create Creates a deploy and attach to a existing request. A 409, which
+// Singularity returns when another deploy is still pending, is returned
+// with its RestyResponse and no error; callers should check the status.
 func (r *SingularityDeployRequest) Create(c *Client) (HTTPResponse, error) {
-	res, err := c.Rest.
-		R().
+	res, err := c.request().
 		SetHeader("Content-Type", "application/json").
 		SetBody(r).
 		Post("/api/deploys/")
@@ -409,8 +551,12 @@ func (r *SingularityDeployRequest) Create(c *Client) (HTTPResponse, error) {
 		return HTTPResponse{}, fmt.Errorf("Scale Singularity request error: %v", err)
 	}
 
-	// Status code 409 happens when job is still in pending status.
-	if res.StatusCode() >= 200 && res.StatusCode() <= 299 || res.StatusCode() == 409 {
+	// Status code 409 happens when job is still in pending status. Its body
+	// is a plain text message rather than a request.
+	if res.StatusCode() == http.StatusConflict {
+		return HTTPResponse{RestyResponse: res}, nil
+	}
+	if res.StatusCode() >= 200 && res.StatusCode() <= 299 {
 		// TODO: Maybe use interface and type assertion? Since response would have different types
 		// of responses based on request body sent.
 		var data SingularityRequestParent
@@ -450,8 +596,7 @@ type DeleteHTTPDeploy struct {
 // (best effort - the deploy may still succeed or fail).
 // https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#delete-apideploysdeploydeployidrequestrequestid
 func (r DeleteHTTPDeploy) Delete(c *Client) (HTTPResponse, error) {
-	res, err := c.Rest.
-		R().
+	res, err := c.request().
 		Delete("/api/deploys/deploy/" + r.deployID + "/request/" + r.requestID)
 	if err != nil {
 		return HTTPResponse{}, fmt.Errorf("Delete Singularity deploy  error: %v", err)
@@ -477,17 +622,22 @@ type Deploy interface {
 	Get() SingularityDeploy
 	SetRequestID(string) Deploy
 	SetContainerInfo(ContainerInfo) (Deploy, error)
+	RemoveContainerInfo() Deploy
+	SetDocker(DockerBuilder) (Deploy, error)
 	SetArgs(...string) Deploy
 	SetURIs([]SingularityMesosArtifact) Deploy
 	SetResources(SingularityDeployResources) Deploy
 	SetCustomExecutorID(string) Deploy
 	SetCustomExecutorSource(string) Deploy
+	SetCustomExecutorCmd(string) Deploy
+	SetExecutorData(ExecutorDataBuilder) (Deploy, error)
 	SetAutoAdvanceDeploySteps(bool) Deploy
 	SetServiceBasePath(string) Deploy
 	SetMetadata(map[string]string) Deploy
 	SetLabels(map[string]string) Deploy
 	SetUser(string) Deploy
 	SetDeployStepWaitTimeMs(int) Deploy
+	SetDeployStepWait(time.Duration) Deploy
 	SetSkipHealthchecksOnDeploy(bool) Deploy
 	SetCommand(string) Deploy
 	SetDeployInstanceCountPerStep(int) Deploy
@@ -498,15 +648,15 @@ type Deploy interface {
 	SetVersion(string) Deploy
 	SetID(string) Deploy
 	SetDeployHealthTimeoutSeconds(int64) Deploy
+	Validate() error
 }
 
 // NewDeploy accept a deploy ID string and returns a Singularity deploy object.
+// The deploy has no container, so its command runs directly on the Mesos
+// agent, until SetContainerInfo is called.
 func NewDeploy(id string) Deploy {
 	return &SingularityDeploy{
 		ID: id,
-		ContainerInfo: ContainerInfo{
-			Type: "DOCKER", // We only support DOCKER at the moment.
-		},
 	}
 }
 
@@ -522,18 +672,24 @@ func (d *SingularityDeploy) SetRequestID(id string) Deploy {
 	return d
 }
 
-// Should I create a Constructor for containerinfo object?
-
-// SetContainerInfo accepts a string request ID which is associated with this deploy.
-// This is optional.  Currently only supports DOCKER.
+// SetContainerInfo accepts a ContainerInfo, such as one from
+// NewDockerContainerInfo or NewMesosContainerInfo, to run this deploy in.
+// This is optional. The container type must be DOCKER or MESOS.
 func (d *SingularityDeploy) SetContainerInfo(c ContainerInfo) (Deploy, error) {
-	if c.Type != "DOCKER" {
-		return nil, fmt.Errorf("Error setting Container Type, %v", "Only supports DOCKER. Please create an issue if you need other than DOCKER.")
+	if c.Type != "DOCKER" && c.Type != "MESOS" {
+		return nil, fmt.Errorf("Error setting Container Type, %q is not one of DOCKER or MESOS", c.Type)
 	}
 	d.ContainerInfo = c
 	return d, nil
 }
 
+// RemoveContainerInfo removes the container of this deploy, so that its
+// command runs directly on the Mesos agent.
+func (d *SingularityDeploy) RemoveContainerInfo() Deploy {
+	d.ContainerInfo = ContainerInfo{}
+	return d
+}
+
 // SetArgs accepts variadic string of command arguments. This is optional.
 func (d *SingularityDeploy) SetArgs(s ...string) Deploy {
 	for _, i := range s {
@@ -572,6 +728,13 @@ func (d *SingularityDeploy) SetCustomExecutorSource(s string) Deploy {
 	return d
 }
 
+// SetCustomExecutorCmd accepts the command which starts a custom Mesos
+// executor, such as /usr/local/bin/singularity-executor. This is optional.
+func (d *SingularityDeploy) SetCustomExecutorCmd(cmd string) Deploy {
+	d.CustomExecutorCmd = cmd
+	return d
+}
+
 // SetAutoAdvanceDeploySteps accepts a bool which sets deploy to automatically
 // advance to the next target instance count after deployStepWaitTimeMs seconds.
 func (d *SingularityDeploy) SetAutoAdvanceDeploySteps(b bool) Deploy {
@@ -610,7 +773,14 @@ func (d *SingularityDeploy) SetUser(u string) Deploy {
 // SetDeployStepWaitTimeMs accepts an time int to wait this long between
 // deploy steps. This is optional.
 func (d *SingularityDeploy) SetDeployStepWaitTimeMs(t int) Deploy {
-	d.DeployStepWaitTimeMs = t
+	d.DeployStepWaitTimeMs = Millis(t)
+	return d
+}
+
+// SetDeployStepWait accepts a time.Duration to wait this long between deploy
+// steps. This is optional.
+func (d *SingularityDeploy) SetDeployStepWait(t time.Duration) Deploy {
+	d.DeployStepWaitTimeMs = MillisOf(t)
 	return d
 }
 
@@ -688,3 +858,145 @@ func (d *SingularityDeploy) SetDeployHealthTimeoutSeconds(t int64) Deploy {
 func (d *SingularityDeploy) Build() *SingularityDeploy {
 	return d
 }
+
+// PauseHTTPRequest contains a request id and a body parameter required to
+// pause a Singularity request.
+type PauseHTTPRequest struct {
+	id string
+	SingularityPauseRequest
+}
+
+// NewPauseRequest accepts a request id string, a message and a bool to kill
+// running tasks immediately rather than letting them finish.
+func NewPauseRequest(id, m string, kill bool) PauseHTTPRequest {
+	return PauseHTTPRequest{
+		id: id,
+		SingularityPauseRequest: SingularityPauseRequest{
+			KillTasks: kill,
+			Message:   m,
+		},
+	}
+}
+
+// SetDuration accepts a time.Duration after which Singularity unpauses the
+// request again. This is optional.
+func (r PauseHTTPRequest) SetDuration(d time.Duration) PauseHTTPRequest {
+	r.DurationMillis = MillisOf(d)
+	return r
+}
+
+// PauseRequest accepts a *Client and PauseHTTPRequest and pauses an existing
+// Singularity request. A paused request does not launch new tasks.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidpause
+func PauseRequest(c *Client, r PauseHTTPRequest) (HTTPResponse, error) {
+	return requestAction(c, "Pause", "POST", r.id, "pause", r.SingularityPauseRequest)
+}
+
+// UnpauseHTTPRequest contains a request id and a body parameter required to
+// unpause a Singularity request.
+type UnpauseHTTPRequest struct {
+	id string
+	SingularityUnpauseRequest
+}
+
+// NewUnpauseRequest accepts a request id string and a message.
+func NewUnpauseRequest(id, m string) UnpauseHTTPRequest {
+	return UnpauseHTTPRequest{
+		id: id,
+		SingularityUnpauseRequest: SingularityUnpauseRequest{
+			Message: m,
+		},
+	}
+}
+
+// UnpauseRequest accepts a *Client and UnpauseHTTPRequest and unpauses a
+// paused Singularity request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidunpause
+func UnpauseRequest(c *Client, r UnpauseHTTPRequest) (HTTPResponse, error) {
+	return requestAction(c, "Unpause", "POST", r.id, "unpause", r.SingularityUnpauseRequest)
+}
+
+// BounceHTTPRequest contains a request id and a body parameter required to
+// bounce a Singularity request.
+type BounceHTTPRequest struct {
+	id string
+	SingularityBounceRequest
+}
+
+// NewBounceRequest accepts a request id string, a message and a bool to kill
+// old tasks as soon as each replacement task is available.
+func NewBounceRequest(id, m string, incremental bool) BounceHTTPRequest {
+	return BounceHTTPRequest{
+		id: id,
+		SingularityBounceRequest: SingularityBounceRequest{
+			Incremental: incremental,
+			Message:     m,
+		},
+	}
+}
+
+// SetDuration accepts a time.Duration Singularity has to finish the bounce
+// in. This is optional.
+func (r BounceHTTPRequest) SetDuration(d time.Duration) BounceHTTPRequest {
+	r.DurationMillis = MillisOf(d)
+	return r
+}
+
+// BounceRequest accepts a *Client and BounceHTTPRequest and restarts all
+// tasks of an existing Singularity request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidbounce
+func BounceRequest(c *Client, r BounceHTTPRequest) (HTTPResponse, error) {
+	return requestAction(c, "Bounce", "POST", r.id, "bounce", r.SingularityBounceRequest)
+}
+
+// RunHTTPRequest contains a request id and a body parameter required to
+// run a Singularity request immediately.
+type RunHTTPRequest struct {
+	id string
+	SingularityRunNowRequest
+}
+
+// NewRunRequest accepts a request id string, a message and command line
+// arguments to pass to the task.
+func NewRunRequest(id, m string, args ...string) RunHTTPRequest {
+	return RunHTTPRequest{
+		id: id,
+		SingularityRunNowRequest: SingularityRunNowRequest{
+			Message:         m,
+			CommandLineArgs: args,
+		},
+	}
+}
+
+// RunRequest accepts a *Client and RunHTTPRequest and schedules a task of an
+// ON_DEMAND or SCHEDULED Singularity request to run immediately.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidrun
+func RunRequest(c *Client, r RunHTTPRequest) (HTTPResponse, error) {
+	return requestAction(c, "Run", "POST", r.id, "run", r.SingularityRunNowRequest)
+}
+
+// requestAction sends body to /api/requests/request/{id}/{action} with
+// method, or no body if it is nil, and returns the updated request.
+func requestAction(c *Client, name, method, id, action string, body interface{}) (HTTPResponse, error) {
+	req := c.request()
+	if body != nil {
+		req.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	res, err := req.Execute(method, "/api/requests/request/"+id+"/"+action)
+	if err != nil {
+		return HTTPResponse{}, fmt.Errorf("%s Singularity request error: %v", name, err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return HTTPResponse{}, fmt.Errorf("%s Singularity request error: %v", name, string(res.Body()))
+	}
+
+	var data SingularityRequestParent
+	err = c.Rest.JSONUnmarshal(res.Body(), &data)
+	if err != nil {
+		return HTTPResponse{}, fmt.Errorf("Parse Singularity request error: %v", err)
+	}
+	return HTTPResponse{
+		RestyResponse: res,
+		RequestParent: data,
+	}, nil
+}
@@ -1,27 +1,35 @@
 package singularity
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
-func TestNewRequestNil(t *testing.T) {
+func TestNewRequestInvalidType(t *testing.T) {
 	var data = []struct {
-		id             string
-		expectedResult string
+		requestType RequestType
+		id          string
 	}{
-		{"test-id", "nil"},
-		{"demand-123", "nil"},
+		{RequestType("BOGUS"), "test-id"},
+		{RequestType("BATCH"), "demand-123"},
+		{RequestType(""), "empty"},
 	}
 
 	for _, tt := range data {
-		req := NewRequest(6, tt.id)
-		if req != nil {
-			t.Errorf("NewRequest(%s, %s): expected %s, got %s",
-				"ON_DEMAND",
-				tt.id,
-				tt.expectedResult,
-				req)
+		// Builder methods must not panic on an unknown type.
+		req := NewRequest(tt.requestType, tt.id).SetInstances(2).Get()
+		if req.RequestType != tt.requestType || req.ID != tt.id {
+			t.Errorf("NewRequest(%s, %s): expected the type and id to be kept, got %+v", tt.requestType, tt.id, req)
+		}
+		f := fields(t, req.Validate())
+		if len(f) != 1 || f[0] != "requestType" {
+			t.Errorf("NewRequest(%s, %s): expected Validate to report requestType, got %v", tt.requestType, tt.id, f)
 		}
 	}
 }
@@ -29,7 +37,7 @@ func TestNewRequestNil(t *testing.T) {
 func TestOnDemandRequestDefault(t *testing.T) {
 	var data = []struct {
 		expectedID   string
-		expectedType string
+		expectedType RequestType
 	}{
 		{"test-id", "ON_DEMAND"},
 		{"demand-123", "ON_DEMAND"},
@@ -50,7 +58,7 @@ func TestOnDemandRequestSet(t *testing.T) {
 	var data = []struct {
 		initID       string
 		expectedID   string
-		expectedType string
+		expectedType RequestType
 	}{
 		{"myid", "test-id", "ON_DEMAND"},
 		{"odl-idname", "demand-123", "ON_DEMAND"},
@@ -71,7 +79,7 @@ func TestOnDemandRequestSet(t *testing.T) {
 func TestNewServiceRequestDefault(t *testing.T) {
 	var data = []struct {
 		expectedID        string
-		expectedType      string
+		expectedType      RequestType
 		expectedInstances int64
 	}{
 		{"test-id", "SERVICE", 1},
@@ -94,7 +102,7 @@ func TestNewServiceRequestDefault(t *testing.T) {
 func TestNewServiceRequestSet(t *testing.T) {
 	var data = []struct {
 		expectedID         string
-		expectedType       string
+		expectedType       RequestType
 		expectedInstances  int64
 		expectedNumRetries int64
 	}{
@@ -126,7 +134,7 @@ func TestNewScheduledRequestSet(t *testing.T) {
 		actualCron           string
 		actualScheduleType   string
 		expectedID           string
-		expectedType         string
+		expectedType         RequestType
 		expectedCron         string
 		expectedScheduleType string
 		expectedError        bool
@@ -167,7 +175,7 @@ func TestNewScheduledRequestSet(t *testing.T) {
 func TestNewWorkerRequestDefault(t *testing.T) {
 	var data = []struct {
 		expectedID        string
-		expectedType      string
+		expectedType      RequestType
 		expectedInstances int64
 	}{
 		{"test-id", "WORKER", 1},
@@ -190,7 +198,7 @@ func TestNewWorkerRequestDefault(t *testing.T) {
 func TestNewWorkerRequestSet(t *testing.T) {
 	var data = []struct {
 		expectedID        string
-		expectedType      string
+		expectedType      RequestType
 		expectedInstances int64
 	}{
 		{"test-id", "WORKER", 0},
@@ -214,7 +222,7 @@ func TestNewWorkerRequestSet(t *testing.T) {
 func TestNewRunOnceRequestDefault(t *testing.T) {
 	var data = []struct {
 		expectedID        string
-		expectedType      string
+		expectedType      RequestType
 		expectedInstances int64
 	}{
 		{"test-id", "RUN_ONCE", 1},
@@ -239,7 +247,7 @@ func TestNewRunOnceRequestDefault(t *testing.T) {
 func TestNewRunOnceSet(t *testing.T) {
 	var data = []struct {
 		expectedID        string
-		expectedType      string
+		expectedType      RequestType
 		instances         int64
 		expectedInstances int64
 	}{
@@ -286,17 +294,101 @@ func TestNewRequestScale(t *testing.T) {
 			req.SingularityScaleRequest.Message,
 			expectedMessage)
 	}
-	if req.SingularityScaleRequest.Incremental != expectedIncrement {
+	if !req.SingularityScaleRequest.Incremental {
 		t.Errorf("Got %v, expected %v ",
 			req.SingularityScaleRequest.Incremental,
-			expectedIncrement)
+			true)
+	}
+}
+
+func TestScaleBuilder(t *testing.T) {
+	req := NewRequestScale("web", "traffic spike", 6, 0).
+		SetDuration(30 * time.Minute).
+		SetBounce(true).
+		SetIncremental(true).
+		SetSkipHealthchecks(true).
+		SetActionID("scale-1").
+		SetMessage("launch day")
+	b, err := json.Marshal(req.SingularityScaleRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"skipHealthchecks":true,"durationMillis":1800000,"bounce":true,"message":"launch day","actionId":"scale-1","instances":6,"incremental":true}`
+	if string(b) != expected {
+		t.Errorf("Marshal: expected %s, got %s", expected, b)
+	}
+
+	// A plain scale sends only the instance count and message, so Singularity
+	// doesn't revert it.
+	b, _ = json.Marshal(NewRequestScale("web", "", 0, 0).SingularityScaleRequest)
+	if string(b) != `{"instances":0}` {
+		t.Errorf("Marshal: expected {\"instances\":0}, got %s", b)
+	}
+}
+
+func TestExpiringScale(t *testing.T) {
+	var method, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		switch {
+		case r.URL.Path == "/api/requests/request/web/scale":
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+			w.Write([]byte(`{"request":{"id":"web","instances":6},"state":"ACTIVE"}`))
+		case r.URL.Path == "/api/requests/request/web":
+			w.Write([]byte(`{"request":{"id":"web","instances":6},"expiringScale":{"requestId":"web","startMillis":1511057800000,` +
+				`"revertToInstances":3,"expiringAPIRequestObject":{"instances":6,"durationMillis":1800000}}}`))
+		case r.URL.Path == "/api/history/request/web/requests":
+			w.Write([]byte(`[{"eventType":"SCALE_REVERTED","request":{"id":"web","instances":3}},` +
+				`{"eventType":"PAUSED","request":{"id":"web"}},` +
+				`{"eventType":"SCALED","request":{"id":"web","instances":6}}]`))
+		case r.URL.Path == "/api/requests/request/batch":
+			w.Write([]byte(`{"request":{"id":"batch"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	s, err := c.GetExpiringScale("web")
+	if err != nil || s == nil || s.RevertToInstances != 3 {
+		t.Fatalf("GetExpiringScale(web): got %+v, %v", s, err)
+	}
+	if expected := time.Unix(1511059600, 0); !s.Expires().Equal(expected) {
+		t.Errorf("Expires: expected %v, got %v", expected, s.Expires())
+	}
+	if s, err := c.GetExpiringScale("batch"); err != nil || s != nil {
+		t.Errorf("GetExpiringScale(batch): expected nil, got %+v, %v", s, err)
+	}
+	if _, err := c.GetExpiringScale("missing"); err == nil {
+		t.Errorf("GetExpiringScale(missing): expected an error")
+	}
+
+	res, err := CancelExpiringScale(c, "web")
+	if err != nil || res.RequestParent.ID != "web" {
+		t.Fatalf("CancelExpiringScale(web): got %+v, %v", res.RequestParent, err)
+	}
+	if method != "DELETE" || body != "" {
+		t.Errorf("CancelExpiringScale(web): expected DELETE without a body, got %s %q", method, body)
+	}
+	if _, err := CancelExpiringScale(c, "missing"); err == nil {
+		t.Errorf("CancelExpiringScale(missing): expected an error")
+	}
+
+	scales, err := c.GetScaleHistory("web")
+	if err != nil || len(scales) != 2 || scales[0].EventType != "SCALE_REVERTED" || scales[1].Request.Instances != 6 {
+		t.Errorf("GetScaleHistory(web): got %+v, %v", scales, err)
+	}
+	if _, err := c.GetScaleHistory("missing"); err == nil {
+		t.Errorf("GetScaleHistory(missing): expected an error")
 	}
 }
 
 func TestSetMaxTasksPerOffer(t *testing.T) {
 	var data = []struct {
 		expectedID               string
-		expectedType             string
+		expectedType             RequestType
 		maxTasksPerOffer         int
 		expectedMaxTasksPerOffer int
 	}{
@@ -333,10 +425,10 @@ func TestNewDeployRequest(t *testing.T) {
 func TestRequestSlavePlacement(t *testing.T) {
 	var data = []struct {
 		actualID               string
-		actualSlavePlacement   string
+		actualSlavePlacement   SlavePlacement
 		expectedID             string
-		expectedType           string
-		expectedSlavePlacement string
+		expectedType           RequestType
+		expectedSlavePlacement SlavePlacement
 	}{
 		{"test-id", "SPREAD_ALL_SLAVES", "test-id", "WORKER", "SPREAD_ALL_SLAVES"},
 	}
@@ -456,8 +548,8 @@ func TestDeploySetRequestID(t *testing.T) {
 		expectedRequestID         string
 		expectedContainerInfoType string
 	}{
-		{"test-id", "request123", "request123", "DOCKER"},
-		{"demand-123", "myrequest", "myrequest", "DOCKER"},
+		{"test-id", "request123", "request123", ""},
+		{"demand-123", "myrequest", "myrequest", ""},
 	}
 
 	for _, tt := range data {
@@ -859,7 +951,7 @@ func TestDeploySetDeployStepWaitTimeMs(t *testing.T) {
 	var data = []struct {
 		id           string
 		time         int
-		expectedTime int
+		expectedTime Millis
 	}{
 		{"mydeploy", 30, 30},
 		{"newdeploy_id", 2, 2},
@@ -876,6 +968,44 @@ func TestDeploySetDeployStepWaitTimeMs(t *testing.T) {
 	}
 }
 
+func TestDeploySetDeployStepWait(t *testing.T) {
+	var data = []struct {
+		time         time.Duration
+		expectedTime Millis
+	}{
+		{30 * time.Second, 30000},
+		{1500 * time.Microsecond, 1},
+	}
+
+	for _, tt := range data {
+		req := NewDeploy("mydeploy").SetDeployStepWait(tt.time).Build()
+		if req.DeployStepWaitTimeMs != tt.expectedTime {
+			t.Errorf("SetDeployStepWait(%v): expected %v, got %v",
+				tt.time,
+				tt.expectedTime,
+				req.DeployStepWaitTimeMs)
+		}
+		if req.DeployStepWaitTimeMs.Duration() != tt.time.Truncate(time.Millisecond) {
+			t.Errorf("DeployStepWaitTimeMs.Duration(): expected %v, got %v",
+				tt.time.Truncate(time.Millisecond),
+				req.DeployStepWaitTimeMs.Duration())
+		}
+	}
+}
+
+func TestRequestTimeLimits(t *testing.T) {
+	req := NewRequest(RequestTypeOnDemand, "job").
+		SetTaskExecutionTimeLimit(time.Hour).
+		SetKillOldNonLongRunningTasksAfter(10 * time.Minute).
+		Get()
+	if req.TaskExecutionTimeLimitMillis != 3600000 {
+		t.Errorf("SetTaskExecutionTimeLimit(1h): expected 3600000, got %v", req.TaskExecutionTimeLimitMillis)
+	}
+	if req.KillOldNonLongRunningTasksAfterMillis.Duration() != 10*time.Minute {
+		t.Errorf("SetKillOldNonLongRunningTasksAfter(10m): expected 10m0s, got %v", req.KillOldNonLongRunningTasksAfterMillis.Duration())
+	}
+}
+
 func TestDeploySetSkipHealthchecksOnDeploy(t *testing.T) {
 	var data = []struct {
 		id            string
@@ -1323,3 +1453,78 @@ func TestDeployRunNowRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestActions(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		gotBody = nil
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		if strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"request":{"id":"test-id","requestType":"WORKER","instances":2},"state":"PAUSED"}`))
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	var data = []struct {
+		name         string
+		call         func(id string) (HTTPResponse, error)
+		expectedPath string
+		expectedBody map[string]interface{}
+	}{
+		{
+			"pause",
+			func(id string) (HTTPResponse, error) {
+				return PauseRequest(c, NewPauseRequest(id, "maintenance", true))
+			},
+			"POST /api/requests/request/test-id/pause",
+			map[string]interface{}{"killTasks": true, "message": "maintenance"},
+		},
+		{
+			"unpause",
+			func(id string) (HTTPResponse, error) { return UnpauseRequest(c, NewUnpauseRequest(id, "done")) },
+			"POST /api/requests/request/test-id/unpause",
+			map[string]interface{}{"message": "done"},
+		},
+		{
+			"bounce",
+			func(id string) (HTTPResponse, error) { return BounceRequest(c, NewBounceRequest(id, "", true)) },
+			"POST /api/requests/request/test-id/bounce",
+			map[string]interface{}{"incremental": true},
+		},
+		{
+			"run",
+			func(id string) (HTTPResponse, error) { return RunRequest(c, NewRunRequest(id, "", "-v")) },
+			"POST /api/requests/request/test-id/run",
+			map[string]interface{}{"commandLineArgs": []interface{}{"-v"}},
+		},
+	}
+
+	for _, tt := range data {
+		res, err := tt.call("test-id")
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if gotPath != tt.expectedPath {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expectedPath, gotPath)
+		}
+		for k, v := range tt.expectedBody {
+			if !reflect.DeepEqual(gotBody[k], v) {
+				t.Errorf("%s: expected %s %v, got %v", tt.name, k, v, gotBody[k])
+			}
+		}
+		if res.RequestParent.State != "PAUSED" || res.RequestParent.SingularityRequest.ID != "test-id" {
+			t.Errorf("%s: unexpected response %+v", tt.name, res.RequestParent)
+		}
+		if _, err := tt.call("missing"); err == nil {
+			t.Errorf("%s: expected error for missing request", tt.name)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package singularity
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RollbackResult reports a deploy and, if it was rolled back, the deploy
+// which replaced it.
+type RollbackResult struct {
+	RequestID string
+	// DeployID is the deploy which was waited on or rolled back, and
+	// Result is its result if it was waited on.
+	DeployID string
+	Result   SingularityDeployResult
+	// PreviousDeployID is the last successful deploy before DeployID. It
+	// was cloned and deployed again as RollbackDeployID, which finished
+	// with RollbackResult. These are empty if there was no rollback.
+	PreviousDeployID string
+	RollbackDeployID string
+	RollbackResult   SingularityDeployResult
+}
+
+// RolledBack returns true if a rollback deploy was made.
+func (r RollbackResult) RolledBack() bool {
+	return r.RollbackDeployID != ""
+}
+
+// Rollback accepts a request id and rolls back its active deploy: the last
+// successful deploy before it is cloned with a new ID and deployed again.
+// It waits for the new deploy to finish and returns its result.
+func (c *Client) Rollback(requestID string) (RollbackResult, error) {
+	res, err := c.GetRequestByID(requestID)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	active := res.Body.RequestDeployState.ActiveDeploy.DeployID
+	if active == "" {
+		return RollbackResult{}, fmt.Errorf("Rollback Singularity request %s error: no active deploy", requestID)
+	}
+	r := RollbackResult{RequestID: requestID, DeployID: active}
+	return r, c.rollback(&r, defaultPollInterval, 0)
+}
+
+// WaitForDeployOrRollback waits for a deploy like WaitForDeploy. If the
+// deploy does not succeed, it is rolled back to the last successful deploy
+// before it, as by Rollback, and the result of both deploys is returned.
+func (c *Client) WaitForDeployOrRollback(requestID, deployID string, interval, timeout time.Duration) (RollbackResult, error) {
+	res, err := c.WaitForDeploy(requestID, deployID, interval, timeout)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	r := RollbackResult{RequestID: requestID, DeployID: deployID, Result: res}
+	if res.Succeeded() {
+		return r, nil
+	}
+	return r, c.rollback(&r, interval, timeout)
+}
+
+// rollback deploys a clone of the last successful deploy before r.DeployID,
+// and fills in the rest of r.
+func (c *Client) rollback(r *RollbackResult, interval, timeout time.Duration) error {
+	history, err := c.GetDeployHistories(r.RequestID)
+	if err != nil {
+		return err
+	}
+	r.PreviousDeployID = previousSuccessfulDeploy(history, r.DeployID)
+	if r.PreviousDeployID == "" {
+		return fmt.Errorf("Rollback Singularity deploy %s error: no successful deploy before it", r.DeployID)
+	}
+	_, h, err := c.GetDeployHistory(r.RequestID, r.PreviousDeployID)
+	if err != nil {
+		return err
+	}
+	if h.Deploy == nil {
+		return fmt.Errorf("Rollback Singularity deploy %s error: deploy %s has no history", r.DeployID, r.PreviousDeployID)
+	}
+
+	d := *h.Deploy
+	d.ID = derivedDeployID(r.PreviousDeployID, "rb", time.Now())
+	d.Timestamp = 0
+	dr := &SingularityDeployRequest{
+		SingularityDeploy: d,
+		Message:           fmt.Sprintf("Rollback of %s to %s", r.DeployID, r.PreviousDeployID),
+	}
+	// Create reports a 409, an already pending deploy, as success.
+	res, err := dr.Create(c)
+	if err != nil {
+		return err
+	}
+	if code := res.RestyResponse.StatusCode(); code < 200 || code > 299 {
+		return fmt.Errorf("Rollback Singularity deploy %s error: %s", r.DeployID, res.RestyResponse.Body())
+	}
+	r.RollbackDeployID = d.ID
+	r.RollbackResult, err = c.WaitForDeploy(r.RequestID, d.ID, interval, timeout)
+	return err
+}
+
+// previousSuccessfulDeploy returns the ID of the newest successful deploy in
+// history, which is newest first, that is older than deployID.
+func previousSuccessfulDeploy(history []SingularityDeployHistory, deployID string) string {
+	start := 0
+	for i, h := range history {
+		if h.DeployMarker.DeployID == deployID {
+			start = i + 1
+			break
+		}
+	}
+	for _, h := range history[start:] {
+		if h.DeployMarker.DeployID != deployID && h.DeployResult != nil && h.DeployResult.Succeeded() {
+			return h.DeployMarker.DeployID
+		}
+	}
+	return ""
+}
+
+// derivedDeployID returns a new deploy ID for a copy of deployID, tagged
+// with tag and the time, which is kept within the deploy ID length limit.
+func derivedDeployID(deployID, tag string, now time.Time) string {
+	suffix := "_" + tag + strconv.FormatInt(now.Unix(), 36)
+	if len(deployID)+len(suffix) > maxDeployIDLength {
+		deployID = deployID[:maxDeployIDLength-len(suffix)]
+	}
+	return deployID + suffix
+}
@@ -0,0 +1,48 @@
+package singularity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviousSuccessfulDeploy(t *testing.T) {
+	history := func(states ...DeployState) []SingularityDeployHistory {
+		var h []SingularityDeployHistory
+		for i, s := range states {
+			h = append(h, SingularityDeployHistory{
+				DeployMarker: SingularityDeployMarker{DeployID: string(rune('a' + i))},
+				DeployResult: &SingularityDeployResult{DeployState: s},
+			})
+		}
+		return h
+	}
+
+	var data = []struct {
+		history  []SingularityDeployHistory
+		deployID string
+		expected string
+	}{
+		{history("FAILED", "SUCCEEDED", "SUCCEEDED"), "a", "b"},
+		{history("SUCCEEDED", "FAILED", "SUCCEEDED"), "a", "c"},
+		{history("SUCCEEDED", "SUCCEEDED"), "b", ""},
+		{history("SUCCEEDED", "SUCCEEDED"), "missing", "a"},
+		{history("FAILED", "CANCELED"), "a", ""},
+	}
+	for _, tt := range data {
+		if got := previousSuccessfulDeploy(tt.history, tt.deployID); got != tt.expected {
+			t.Errorf("previousSuccessfulDeploy(%s): expected %q, got %q", tt.deployID, tt.expected, got)
+		}
+	}
+}
+
+func TestDerivedDeployID(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	if got := derivedDeployID("d1", "rb", now); got != "d1_rbot27eo" {
+		t.Errorf("derivedDeployID(d1): expected %s, got %s", "d1_rbot27eo", got)
+	}
+	long := "a123456789a123456789a123456789a123456789a123456789"
+	got := derivedDeployID(long, "rb", now)
+	if len(got) != maxDeployIDLength || !deployIDPattern.MatchString(got) {
+		t.Errorf("derivedDeployID(%s): expected a valid %d character ID, got %s", long, maxDeployIDLength, got)
+	}
+}
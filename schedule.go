@@ -0,0 +1,87 @@
+package singularity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+// SetQuartzSchedule accepts a Quartz cron expression, such as
+// "0 0 12 ? * MON-FRI", and sets it as the schedule of this request.
+func (r *SingularityRequest) SetQuartzSchedule(s string) (ServiceRequest, error) {
+	if _, err := parseQuartz(s); err != nil {
+		return nil, err
+	}
+	r.QuartzSchedule = s
+	r.ScheduleType = "QUARTZ"
+	return r, nil
+}
+
+// SetScheduleTimeZone accepts an IANA time zone name, such as
+// "Australia/Sydney", which the schedule of this request runs in.
+func (r *SingularityRequest) SetScheduleTimeZone(tz string) (ServiceRequest, error) {
+	if _, err := time.LoadLocation(tz); err != nil {
+		return nil, fmt.Errorf("Set Singularity schedule time zone error: %v", err)
+	}
+	r.ScheduleTimeZone = tz
+	return r, nil
+}
+
+// NextRuns returns the next n times after from at which this request's
+// schedule fires, in the request's ScheduleTimeZone. Without a time zone the
+// location of from is used. Fewer than n times are returned if the schedule
+// stops firing.
+func (r *SingularityRequest) NextRuns(n int, from time.Time) ([]time.Time, error) {
+	loc := from.Location()
+	if r.ScheduleTimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(r.ScheduleTimeZone); err != nil {
+			return nil, fmt.Errorf("Load Singularity schedule time zone error: %v", err)
+		}
+	}
+
+	next, err := r.scheduleFunc(loc)
+	if err != nil {
+		return nil, err
+	}
+	var runs []time.Time
+	t := from.In(loc)
+	for len(runs) < n {
+		t = next(t)
+		if t.IsZero() {
+			break
+		}
+		runs = append(runs, t)
+	}
+	return runs, nil
+}
+
+// scheduleFunc returns a function giving the next time this request's
+// schedule fires after a given time, in loc.
+func (r *SingularityRequest) scheduleFunc(loc *time.Location) (func(time.Time) time.Time, error) {
+	spec := r.QuartzSchedule
+	if spec == "" && strings.ToUpper(r.ScheduleType) == "QUARTZ" {
+		spec = r.Schedule
+	}
+	if spec != "" {
+		q, err := parseQuartz(spec)
+		if err != nil {
+			return nil, err
+		}
+		return q.next, nil
+	}
+
+	if r.Schedule == "" {
+		return nil, fmt.Errorf("Singularity request %s has no schedule", r.ID)
+	}
+	s, err := cron.Parse(r.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("Parse %s cron schedule error. %v", r.Schedule, err)
+	}
+	if spec, ok := s.(*cron.SpecSchedule); ok {
+		spec.Location = loc
+	}
+	return s.Next, nil
+}
@@ -0,0 +1,90 @@
+package singularity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetQuartzSchedule(t *testing.T) {
+	r := NewRequest(SCHEDULED, "job")
+	if _, err := r.SetQuartzSchedule("0 0 12 ? * MON-FRI"); err != nil {
+		t.Fatalf("SetQuartzSchedule: unexpected error %v", err)
+	}
+	req := r.Get()
+	if req.QuartzSchedule != "0 0 12 ? * MON-FRI" || req.ScheduleType != "QUARTZ" {
+		t.Errorf("SetQuartzSchedule: got %+v", req)
+	}
+	if _, err := r.SetQuartzSchedule("0 0 12 * * *"); err == nil {
+		t.Errorf("SetQuartzSchedule: expected error for invalid schedule")
+	}
+
+	r = NewRequest(SCHEDULED, "job")
+	if _, err := r.SetScheduleType("quartz"); err != nil {
+		t.Fatalf("SetScheduleType: unexpected error %v", err)
+	}
+	if _, err := r.SetSchedule("0 0 12 ? * MON-FRI"); err != nil {
+		t.Errorf("SetSchedule: unexpected error for quartz schedule %v", err)
+	}
+	if _, err := r.SetScheduleType("anacron"); err == nil {
+		t.Errorf("SetScheduleType: expected error for unknown type")
+	}
+
+	if _, err := r.SetScheduleTimeZone("Australia/Sydney"); err != nil {
+		t.Errorf("SetScheduleTimeZone: unexpected error %v", err)
+	}
+	if _, err := r.SetScheduleTimeZone("Mars/Olympus_Mons"); err == nil {
+		t.Errorf("SetScheduleTimeZone: expected error for unknown time zone")
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	// Friday 1 March 2019, 10:00 UTC, which is 21:00 in Sydney.
+	from := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	var data = []struct {
+		name     string
+		request  SingularityRequest
+		expected []string
+	}{
+		{"cron", SingularityRequest{Schedule: "30 9 * * 1-5"},
+			[]string{"2019-03-04T09:30:00Z", "2019-03-05T09:30:00Z"}},
+		{"cron in time zone", SingularityRequest{Schedule: "30 9 * * 1-5", ScheduleTimeZone: "Australia/Sydney"},
+			[]string{"2019-03-04T09:30:00+11:00", "2019-03-05T09:30:00+11:00"}},
+		{"quartz", SingularityRequest{QuartzSchedule: "0 30 9 ? * MON-FRI", ScheduleTimeZone: "Australia/Sydney"},
+			[]string{"2019-03-04T09:30:00+11:00", "2019-03-05T09:30:00+11:00"}},
+		{"quartz schedule type", SingularityRequest{Schedule: "0 30 9 ? * MON-FRI", ScheduleType: "QUARTZ"},
+			[]string{"2019-03-04T09:30:00Z", "2019-03-05T09:30:00Z"}},
+		// Sydney leaves daylight saving on 7 April 2019.
+		{"daylight saving", SingularityRequest{QuartzSchedule: "0 0 9 ? * SUN", ScheduleTimeZone: "Australia/Sydney"},
+			[]string{"2019-03-03T09:00:00+11:00", "2019-03-10T09:00:00+11:00"}},
+	}
+
+	for _, tt := range data {
+		runs, err := tt.request.NextRuns(2, from)
+		if err != nil {
+			t.Errorf("NextRuns(%s): unexpected error %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, r := range runs {
+			got = append(got, r.Format(time.RFC3339))
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("NextRuns(%s): expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	r := SingularityRequest{ID: "job", QuartzSchedule: "0 0 9 ? * SUN", ScheduleTimeZone: "Australia/Sydney"}
+	runs, _ := r.NextRuns(6, from)
+	if runs[5].Format(time.RFC3339) != "2019-04-07T09:00:00+10:00" {
+		t.Errorf("NextRuns(daylight saving): expected 2019-04-07T09:00:00+10:00, got %v", runs[5])
+	}
+
+	if _, err := (&SingularityRequest{ID: "job"}).NextRuns(1, from); err == nil {
+		t.Errorf("NextRuns: expected error for request without schedule")
+	}
+	if _, err := (&SingularityRequest{Schedule: "* * * * *", ScheduleTimeZone: "Nowhere"}).NextRuns(1, from); err == nil {
+		t.Errorf("NextRuns: expected error for unknown time zone")
+	}
+}
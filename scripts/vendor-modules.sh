@@ -0,0 +1,68 @@
+#!/bin/sh
+# vendor-modules.sh copies the modules with a /vN import path into vendor/.
+# dep can't fetch these, so they are taken from the Go module proxy and
+# pruned the way dep prunes the provider's other dependencies: only the
+# listed packages are kept, without their tests.
+#
+# Run it after dep ensure; make vendor does both.
+set -e
+
+# vendor_module MODULE VERSION PACKAGE...
+vendor_module() {
+	module=$1
+	version=$2
+	shift 2
+	src=$(GO111MODULE=on GOFLAGS=-mod=mod go mod download -json "$module@$version" |
+		sed -n 's/^[[:space:]]*"Dir": "\(.*\)",$/\1/p')
+	if [ -z "$src" ]; then
+		echo "vendor-modules.sh: can't download $module@$version" >&2
+		exit 1
+	fi
+	dst=vendor/$module
+	rm -rf "$dst"
+	mkdir -p "$dst"
+	for f in "$src"/*; do
+		case $(basename "$f") in
+		LICENSE* | LICENCE* | COPYING* | NOTICE* | PATENTS* | AUTHORS* | CONTRIBUTORS*)
+			cp "$f" "$dst/"
+			;;
+		esac
+	done
+	for pkg in "$@"; do
+		mkdir -p "$dst/$pkg"
+		for f in "$src/$pkg"/*; do
+			case $f in
+			*_test.go) ;;
+			*) [ -f "$f" ] && cp "$f" "$dst/$pkg/" ;;
+			esac
+		done
+	done
+	chmod -R u+w "$dst"
+}
+
+vendor_module github.com/apparentlymart/go-textseg/v13 v13.0.0 \
+	textseg
+
+vendor_module github.com/hashicorp/hcl/v2 v2.3.0 \
+	. \
+	ext/customdecode \
+	hclsyntax
+
+vendor_module github.com/hashicorp/terraform-plugin-sdk/v2 v2.10.1 \
+	diag \
+	helper/logging \
+	helper/resource \
+	helper/schema \
+	helper/structure \
+	helper/validation \
+	internal/addrs \
+	internal/configs/configschema \
+	internal/configs/hcl2shim \
+	internal/helper/hashcode \
+	internal/plans/objchange \
+	internal/plugin/convert \
+	internal/plugintest \
+	internal/tfdiags \
+	meta \
+	plugin \
+	terraform
@@ -3,14 +3,19 @@
 package singularity
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/go-resty/resty"
+	"github.com/lenfree/go-mesos-singularity/api"
 )
 
 // Client contains Singularity endpoint for http requests
 type Client struct {
 	Rest *resty.Client
+
+	hooks *hookTransport
+	ctx   context.Context
 }
 
 // Config contains Singularity HTTP endpoint and configuration for
@@ -71,6 +76,37 @@ func NewClient(c config) *Client {
 	}
 }
 
+// APIError is returned by the generated API and by version 2 of the client
+// when Singularity answers with a status outside 2xx.
+type APIError = api.Error
+
+// WithContext returns a copy of c whose calls are made with ctx. They are
+// canceled when ctx is, and hooks are given ctx as the parent of each
+// attempt, so that a span started by the caller is the parent of the spans
+// of the calls.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+// request returns a new request from the API client, made with the context
+// of c.
+func (c *Client) request() *resty.Request {
+	return c.API().R()
+}
+
+// API returns the low level client generated from Singularity's Swagger
+// description, for endpoints which this package has no call for. It shares
+// c's transport, retries, hooks and context.
+func (c *Client) API() *api.Client {
+	a := api.NewClient(c.Rest)
+	if c.ctx != nil {
+		a = a.WithContext(c.ctx)
+	}
+	return a
+}
+
 func endpoint(c *config) string {
 	// if port is uninitialised, port would be http/80.
 	if c.Port == 0 || c.Port == 80 {
@@ -1,6 +1,8 @@
 package singularity
 
 import (
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -127,3 +129,8 @@ func TestEndpoint(t *testing.T) {
 	}
 
 }
+
+// newTestClient returns a Client which talks to ts.
+func newTestClient(ts *httptest.Server) *Client {
+	return NewClient(NewConfig().SetHost(strings.TrimPrefix(ts.URL, "http://")).Build())
+}
@@ -0,0 +1,446 @@
+// Package singularitytest provides an in-memory Singularity server for
+// testing code which uses the singularity package.
+package singularitytest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// Server is a fake Singularity API. Requests and deploys posted to it are
+// kept in memory, and deploys finish immediately with DeployState. A WAITING
+// deploy stays pending; if it has deployInstanceCountPerStep set, its first
+// step is complete and PUT /api/deploys/update advances it, succeeding once
+// it reaches the request's instance count.
+type Server struct {
+	*httptest.Server
+
+	// DeployState is the result given to new deploys. It defaults to
+	// SUCCEEDED; a SUCCEEDED deploy becomes the request's active deploy.
+	DeployState singularity.DeployState
+
+	mu            sync.Mutex
+	requests      map[string]*singularity.Request
+	deploys       map[string]map[string]*singularity.SingularityDeployHistory
+	pending       map[string]*singularity.SingularityPendingDeploy
+	order         map[string][]string // deploy IDs of each request, oldest first
+	tasks         []singularity.SingularityTask
+	pendingTasks  []singularity.SingularityPendingTaskID
+	cleaningTasks []singularity.SingularityTaskCleanup
+	slaves        []singularity.SingularitySlave
+	racks         []singularity.SingularityRack
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		DeployState: "SUCCEEDED",
+		requests:    make(map[string]*singularity.Request),
+		deploys:     make(map[string]map[string]*singularity.SingularityDeployHistory),
+		pending:     make(map[string]*singularity.SingularityPendingDeploy),
+		order:       make(map[string][]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Host returns the host and port of this server, as accepted by
+// singularity.NewConfig().SetHost.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+// Client returns a singularity.Client which talks to this server.
+func (s *Server) Client() *singularity.Client {
+	return singularity.NewClient(singularity.NewConfig().SetHost(s.Host()).Build())
+}
+
+// AddRequest stores a request as if it had been created through the API.
+func (s *Server) AddRequest(r singularity.SingularityRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putRequest(r)
+}
+
+// AddTask adds an active task.
+func (s *Server) AddTask(t singularity.SingularityTask) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks = append(s.tasks, t)
+}
+
+// AddPendingTask adds a task waiting to be launched.
+func (s *Server) AddPendingTask(t singularity.SingularityPendingTaskID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pendingTasks = append(s.pendingTasks, t)
+}
+
+// AddCleaningTask adds a task which is being cleaned up.
+func (s *Server) AddCleaningTask(t singularity.SingularityTaskCleanup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleaningTasks = append(s.cleaningTasks, t)
+}
+
+// AddSlave adds a Mesos agent.
+func (s *Server) AddSlave(a singularity.SingularitySlave) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slaves = append(s.slaves, a)
+}
+
+// AddRack adds a rack.
+func (s *Server) AddRack(r singularity.SingularityRack) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.racks = append(s.racks, r)
+}
+
+// Request returns a stored request, and false if there is none.
+func (s *Server) Request(id string) (singularity.Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.requests[id]
+	if !ok {
+		return singularity.Request{}, false
+	}
+	return *r, true
+}
+
+func (s *Server) putRequest(r singularity.SingularityRequest) *singularity.Request {
+	req, ok := s.requests[r.ID]
+	if !ok {
+		req = &singularity.Request{State: "ACTIVE"}
+		req.RequestDeployState.RequestID = r.ID
+		s.requests[r.ID] = req
+	}
+	req.SingularityRequest = r
+	return req
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	route := r.Method + " " + strings.Join(path, "/")
+	switch {
+	case route == "GET api/requests":
+		ids := make([]string, 0, len(s.requests))
+		for id := range s.requests {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		// Like Singularity, the active deploy is left out unless full
+		// request data is asked for.
+		full := r.URL.Query().Get("includeFullRequestData") == "true"
+		reqs := make(singularity.Requests, 0, len(ids))
+		for _, id := range ids {
+			req := *s.requests[id]
+			if !full {
+				req.ActiveDeploy = singularity.SingularityDeploy{}
+			}
+			reqs = append(reqs, req)
+		}
+		reply(w, http.StatusOK, reqs)
+	case route == "POST api/requests":
+		var body singularity.SingularityRequest
+		if !decode(w, r, &body) {
+			return
+		}
+		if body.ID == "" || body.RequestType == "" {
+			reply(w, http.StatusBadRequest, "request id and requestType are required")
+			return
+		}
+		reply(w, http.StatusOK, s.putRequest(body))
+	case len(path) >= 4 && path[1] == "requests" && path[2] == "request":
+		s.serveRequest(w, r, path[3], path[4:])
+	case route == "POST api/deploys":
+		s.createDeploy(w, r)
+	case route == "PUT api/deploys/update":
+		s.updateDeploy(w, r)
+	case r.Method == "DELETE" && len(path) == 6 && path[1] == "deploys" && path[2] == "deploy":
+		s.cancelDeploy(w, path[5], path[3])
+	case r.Method == "GET" && len(path) == 5 && path[1] == "history" && path[4] == "deploys":
+		ids := s.order[path[3]]
+		history := make([]singularity.SingularityDeployHistory, 0, len(ids))
+		for i := len(ids) - 1; i >= 0; i-- {
+			h := *s.deploys[path[3]][ids[i]]
+			h.Deploy = nil
+			history = append(history, h)
+		}
+		reply(w, http.StatusOK, history)
+	case r.Method == "GET" && len(path) == 6 && path[1] == "history" && path[4] == "deploy":
+		h, ok := s.deploys[path[3]][path[5]]
+		if !ok {
+			reply(w, http.StatusNotFound, "deploy not found")
+			return
+		}
+		reply(w, http.StatusOK, h)
+	case r.Method == "GET" && len(path) == 6 && path[1] == "history" && path[4] == "tasks":
+		var history []singularity.SingularityTaskIDHistory
+		for _, t := range s.tasks {
+			if t.TaskID.RequestID == path[3] {
+				history = append(history, singularity.SingularityTaskIDHistory{
+					TaskID:        t.TaskID,
+					LastTaskState: "TASK_RUNNING",
+				})
+			}
+		}
+		reply(w, http.StatusOK, history)
+	case route == "GET api/tasks/active":
+		tasks := s.tasks
+		if tasks == nil {
+			tasks = []singularity.SingularityTask{}
+		}
+		reply(w, http.StatusOK, tasks)
+	case route == "GET api/tasks/scheduled/ids":
+		tasks := append([]singularity.SingularityPendingTaskID{}, s.pendingTasks...)
+		reply(w, http.StatusOK, tasks)
+	case route == "GET api/tasks/cleaning":
+		tasks := append([]singularity.SingularityTaskCleanup{}, s.cleaningTasks...)
+		reply(w, http.StatusOK, tasks)
+	case route == "GET api/deploys/pending":
+		ids := make([]string, 0, len(s.pending))
+		for id := range s.pending {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		deploys := make([]singularity.SingularityPendingDeploy, 0, len(ids))
+		for _, id := range ids {
+			deploys = append(deploys, *s.pending[id])
+		}
+		reply(w, http.StatusOK, deploys)
+	case route == "GET api/slaves":
+		slaves := []singularity.SingularitySlave{}
+		for _, a := range s.slaves {
+			if state := r.URL.Query().Get("state"); state == "" || a.CurrentState.State == state {
+				slaves = append(slaves, a)
+			}
+		}
+		reply(w, http.StatusOK, slaves)
+	case route == "GET api/racks":
+		racks := []singularity.SingularityRack{}
+		for _, rack := range s.racks {
+			if state := r.URL.Query().Get("state"); state == "" || rack.CurrentState.State == state {
+				racks = append(racks, rack)
+			}
+		}
+		reply(w, http.StatusOK, racks)
+	case r.Method == "DELETE" && len(path) == 4 && path[1] == "tasks" && path[2] == "task":
+		for i, t := range s.tasks {
+			if t.TaskID.ID == path[3] {
+				s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+				reply(w, http.StatusOK, singularity.SingularityTaskCleanup{
+					TaskID:      t.TaskID,
+					CleanupType: "USER_REQUESTED",
+				})
+				return
+			}
+		}
+		reply(w, http.StatusNotFound, "task not found")
+	default:
+		reply(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveRequest(w http.ResponseWriter, r *http.Request, id string, action []string) {
+	req, ok := s.requests[id]
+	if !ok {
+		reply(w, http.StatusNotFound, "request not found")
+		return
+	}
+	route := r.Method + " " + strings.Join(action, "/")
+	switch route {
+	case "GET ":
+		// Singularity replies with a SingularityRequestParent, which has
+		// the pending deploy's progress as well.
+		reply(w, http.StatusOK, struct {
+			*singularity.Request
+			PendingDeployState *singularity.SingularityPendingDeploy `json:"pendingDeployState,omitempty"`
+		}{req, s.pending[id]})
+	case "DELETE ":
+		delete(s.requests, id)
+		delete(s.deploys, id)
+		delete(s.pending, id)
+		delete(s.order, id)
+		reply(w, http.StatusOK, req.SingularityRequest)
+	case "PUT scale":
+		var body singularity.SingularityScaleRequest
+		if !decode(w, r, &body) {
+			return
+		}
+		req.Instances = int64(body.Instances)
+		reply(w, http.StatusOK, parent(req))
+	case "POST pause":
+		req.State = "PAUSED"
+		reply(w, http.StatusOK, parent(req))
+	case "POST unpause":
+		req.State = "ACTIVE"
+		reply(w, http.StatusOK, parent(req))
+	case "POST bounce", "POST run":
+		reply(w, http.StatusOK, parent(req))
+	default:
+		reply(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) createDeploy(w http.ResponseWriter, r *http.Request) {
+	var body singularity.SingularityDeployRequest
+	if !decode(w, r, &body) {
+		return
+	}
+	d := body.SingularityDeploy
+	if body.SingularityRequest != nil {
+		s.putRequest(*body.SingularityRequest)
+	}
+	req, ok := s.requests[d.RequestID]
+	if !ok {
+		reply(w, http.StatusBadRequest, "request "+d.RequestID+" does not exist")
+		return
+	}
+	if d.ID == "" {
+		reply(w, http.StatusBadRequest, "deploy id is required")
+		return
+	}
+	if _, ok := s.deploys[d.RequestID][d.ID]; ok {
+		reply(w, http.StatusBadRequest, "deploy "+d.ID+" already exists")
+		return
+	}
+	if _, ok := s.pending[d.RequestID]; ok {
+		reply(w, http.StatusConflict, "a deploy of "+d.RequestID+" is already pending")
+		return
+	}
+
+	if s.deploys[d.RequestID] == nil {
+		s.deploys[d.RequestID] = make(map[string]*singularity.SingularityDeployHistory)
+	}
+	h := &singularity.SingularityDeployHistory{
+		DeployMarker: singularity.SingularityDeployMarker{
+			RequestID: d.RequestID,
+			DeployID:  d.ID,
+			Timestamp: singularity.EpochMillisOf(time.Now()),
+			Message:   body.Message,
+		},
+		Deploy: &d,
+	}
+	s.deploys[d.RequestID][d.ID] = h
+	s.order[d.RequestID] = append(s.order[d.RequestID], d.ID)
+
+	switch s.DeployState {
+	case "SUCCEEDED":
+		activate(req, h)
+	case "", "WAITING":
+		req.RequestDeployState.PendingDeployState.DeployID = d.ID
+		req.RequestDeployState.PendingDeployState.RequestID = d.RequestID
+		p := &singularity.SingularityPendingDeploy{CurrentDeployState: "WAITING"}
+		p.SingularityDeployMarker = h.DeployMarker
+		p.AutoAdvanceDeploySteps = d.AutoAdvanceDeploySteps
+		if n := d.DeployInstanceCountPerStep; n > 0 {
+			p.DeployInstanceCountPerStep = n
+			p.TargetActiveInstances = n
+			p.CurrentActiveInstances = n
+			p.StepComplete = true
+		}
+		s.pending[d.RequestID] = p
+	default:
+		h.DeployResult = &singularity.SingularityDeployResult{DeployState: s.DeployState}
+	}
+	reply(w, http.StatusOK, parent(req))
+}
+
+func (s *Server) cancelDeploy(w http.ResponseWriter, requestID, deployID string) {
+	h, ok := s.deploys[requestID][deployID]
+	req := s.requests[requestID]
+	if !ok || req == nil {
+		reply(w, http.StatusNotFound, "deploy not found")
+		return
+	}
+	if req.RequestDeployState.PendingDeployState.DeployID != deployID {
+		reply(w, http.StatusBadRequest, "deploy "+deployID+" is not pending")
+		return
+	}
+	req.RequestDeployState.PendingDeployState.DeployID = ""
+	delete(s.pending, requestID)
+	h.DeployResult = &singularity.SingularityDeployResult{DeployState: "CANCELED"}
+	reply(w, http.StatusOK, parent(req))
+}
+
+func (s *Server) updateDeploy(w http.ResponseWriter, r *http.Request) {
+	var body singularity.SingularityUpdatePendingDeployRequest
+	if !decode(w, r, &body) {
+		return
+	}
+	p, ok := s.pending[body.RequestID]
+	if !ok || p.SingularityDeployMarker.DeployID != body.DeployID {
+		reply(w, http.StatusBadRequest, "deploy "+body.DeployID+" is not pending")
+		return
+	}
+	req := s.requests[body.RequestID]
+	if body.TargetActiveInstances < 1 || int64(body.TargetActiveInstances) > req.Instances {
+		reply(w, http.StatusBadRequest, "targetActiveInstances is out of range")
+		return
+	}
+	p.TargetActiveInstances = body.TargetActiveInstances
+	p.CurrentActiveInstances = body.TargetActiveInstances
+	p.StepComplete = true
+	if int64(body.TargetActiveInstances) == req.Instances {
+		req.RequestDeployState.PendingDeployState.DeployID = ""
+		delete(s.pending, body.RequestID)
+		activate(req, s.deploys[body.RequestID][body.DeployID])
+	}
+	reply(w, http.StatusOK, parent(req))
+}
+
+// activate makes the deploy of h the active deploy of req.
+func activate(req *singularity.Request, h *singularity.SingularityDeployHistory) {
+	h.DeployResult = &singularity.SingularityDeployResult{DeployState: "SUCCEEDED"}
+	req.RequestDeployState.ActiveDeploy.DeployID = h.DeployMarker.DeployID
+	req.RequestDeployState.ActiveDeploy.RequestID = h.DeployMarker.RequestID
+	if h.Deploy != nil {
+		req.ActiveDeploy = *h.Deploy
+	}
+}
+
+// parent returns the SingularityRequestParent of a stored request.
+func parent(r *singularity.Request) singularity.SingularityRequestParent {
+	var p singularity.SingularityRequestParent
+	p.SingularityRequest = r.SingularityRequest
+	p.State = r.State
+	p.RequestDeployState.RequestID = r.RequestDeployState.RequestID
+	p.RequestDeployState.ActiveDeploy.DeployID = r.RequestDeployState.ActiveDeploy.DeployID
+	p.RequestDeployState.ActiveDeploy.RequestID = r.RequestDeployState.ActiveDeploy.RequestID
+	return p
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		reply(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// reply writes v as JSON, or a string v as plain text, which is how
+// Singularity sends its error messages.
+func reply(w http.ResponseWriter, status int, v interface{}) {
+	if msg, ok := v.(string); ok {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(status)
+		io.WriteString(w, msg)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,214 @@
+package singularitytest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+func TestServerDeploy(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+	c := ts.Client()
+
+	r := singularity.NewRequest(singularity.RequestTypeService, "my-service")
+	if _, err := r.Create(c); err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	d := singularity.NewDeploy("d1").SetRequestID("my-service").SetCommand("./run.sh").
+		SetLabels(map[string]string{"team": "web"})
+	if _, err := singularity.NewDeployRequest().AttachDeploy(d).Create(c); err != nil {
+		t.Fatalf("Create deploy: unexpected error %v", err)
+	}
+	if _, err := singularity.NewDeployRequest().AttachDeploy(d).Create(c); err == nil {
+		t.Errorf("Create deploy: expected error for duplicate deploy")
+	}
+
+	res, err := c.GetRequestByID("my-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Body.RequestDeployState.ActiveDeploy.DeployID != "d1" || res.Body.ActiveDeploy.Command != "./run.sh" {
+		t.Errorf("GetRequestByID: unexpected active deploy %+v", res.Body.ActiveDeploy)
+	}
+	_, reqs, err := c.GetRequests()
+	if err != nil || len(reqs) != 1 || reqs[0].ActiveDeploy.ID != "" {
+		t.Errorf("GetRequests: expected the active deploy to be left out, got %+v, %v", reqs, err)
+	}
+	if ids, err := singularity.SelectRequests(c, singularity.Selector{Labels: map[string]string{"team": "web"}}); err != nil || len(ids) != 1 {
+		t.Errorf("SelectRequests: expected my-service by the labels of its deploy, got %v, %v", ids, err)
+	}
+	result, err := c.WaitForDeploy("my-service", "d1", 0, 0)
+	if err != nil || !result.Succeeded() {
+		t.Errorf("WaitForDeploy: expected success, got %+v, %v", result, err)
+	}
+
+	if _, err := singularity.DeleteRequest(c, singularity.NewDeleteRequest("my-service", "", "", false)); err != nil {
+		t.Fatalf("DeleteRequest: unexpected error %v", err)
+	}
+	if _, ok := ts.Request("my-service"); ok {
+		t.Errorf("DeleteRequest: expected request to be deleted")
+	}
+}
+
+func TestServerDeploySteps(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+	ts.DeployState = "WAITING"
+	c := ts.Client()
+
+	r := singularity.NewRequest(singularity.RequestTypeService, "my-service").SetInstances(2)
+	if _, err := r.Create(c); err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	d := singularity.NewDeploy("d1").SetRequestID("my-service").SetCommand("./run.sh").
+		SetDeployInstanceCountPerStep(1).
+		SetAutoAdvanceDeploySteps(false)
+	if _, err := singularity.NewDeployRequest().AttachDeploy(d).Create(c); err != nil {
+		t.Fatalf("Create deploy: unexpected error %v", err)
+	}
+
+	p, err := c.GetRequestParent("my-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress := p.SingularityPendingDeploy.SingularityDeployProgress; !progress.StepComplete || progress.TargetActiveInstances != 1 {
+		t.Errorf("GetRequestParent: expected the first step to be complete, got %+v", progress)
+	}
+
+	canary := singularity.NewCanary("my-service", "d1", func(singularity.SingularityDeployProgress) (bool, error) {
+		return true, nil
+	})
+	canary.Interval = time.Millisecond
+	result, err := c.RunCanary(canary)
+	if err != nil || !result.Succeeded() {
+		t.Errorf("RunCanary: expected success, got %+v, %v", result, err)
+	}
+	if _, err := c.UpdatePendingDeploy("my-service", "d1", 2); err == nil {
+		t.Errorf("UpdatePendingDeploy: expected error once the deploy has finished")
+	}
+}
+
+func TestServerRollback(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+	c := ts.Client()
+
+	if _, err := singularity.NewRequest(singularity.RequestTypeService, "my-service").Create(c); err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	deploy := func(id string) {
+		d := singularity.NewDeploy(id).SetRequestID("my-service").SetCommand("./run.sh " + id)
+		if _, err := singularity.NewDeployRequest().AttachDeploy(d).Create(c); err != nil {
+			t.Fatalf("Create deploy %s: unexpected error %v", id, err)
+		}
+	}
+	deploy("d1")
+	deploy("d2")
+
+	r, err := c.Rollback("my-service")
+	if err != nil {
+		t.Fatalf("Rollback: unexpected error %v", err)
+	}
+	if r.DeployID != "d2" || r.PreviousDeployID != "d1" || !r.RollbackResult.Succeeded() {
+		t.Errorf("Rollback: expected d2 to be rolled back to d1, got %+v", r)
+	}
+	req, _ := ts.Request("my-service")
+	if req.ActiveDeploy.ID != r.RollbackDeployID || req.ActiveDeploy.Command != "./run.sh d1" {
+		t.Errorf("Rollback: expected a clone of d1 to be active, got %+v", req.ActiveDeploy)
+	}
+
+	ts.DeployState = "FAILED"
+	deploy("d3")
+	ts.DeployState = "SUCCEEDED"
+	r, err = c.WaitForDeployOrRollback("my-service", "d3", time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("WaitForDeployOrRollback: unexpected error %v", err)
+	}
+	if r.Result.DeployState != "FAILED" || !r.RolledBack() || !r.RollbackResult.Succeeded() {
+		t.Errorf("WaitForDeployOrRollback: expected a failed deploy and a successful rollback, got %+v", r)
+	}
+
+	deploy("d4")
+	r, err = c.WaitForDeployOrRollback("my-service", "d4", time.Millisecond, 0)
+	if err != nil || r.RolledBack() {
+		t.Errorf("WaitForDeployOrRollback: expected no rollback, got %+v, %v", r, err)
+	}
+}
+
+func TestServerPromote(t *testing.T) {
+	staging, production := NewServer(), NewServer()
+	defer staging.Close()
+	defer production.Close()
+
+	if _, err := singularity.NewRequest(singularity.RequestTypeService, "my-service").Create(staging.Client()); err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	d := singularity.NewDeploy("d1").SetRequestID("my-service").SetCommand("./run.sh").
+		SetEnv(map[string]string{"ENV": "staging", "LOG": "debug"}).
+		SetResources(singularity.SingularityDeployResources{Cpus: 1, MemoryMb: 128})
+	if _, err := singularity.NewDeployRequest().AttachDeploy(d).Create(staging.Client()); err != nil {
+		t.Fatalf("Create deploy: unexpected error %v", err)
+	}
+
+	p := singularity.Promotion{
+		RequestID: "my-service",
+		DryRun:    true,
+		Env:       map[string]string{"ENV": "production"},
+		Instances: 3,
+		Resources: singularity.SingularityDeployResources{MemoryMb: 512},
+	}
+	res, err := singularity.Promote(staging.Client(), production.Client(), p)
+	if err != nil {
+		t.Fatalf("Promote: unexpected error %v", err)
+	}
+	if res.Created {
+		t.Errorf("Promote: expected nothing to be created in a dry run")
+	}
+	if _, ok := production.Request("my-service"); ok {
+		t.Errorf("Promote: expected no request on the target in a dry run")
+	}
+	for _, line := range []string{`+ request.instances: 3`, `+ deploy.env.ENV: "production"`, `+ deploy.resources.memoryMb: 512`} {
+		found := false
+		for _, d := range res.Diff {
+			found = found || d == line
+		}
+		if !found {
+			t.Errorf("Promote: expected diff line %q, got %q", line, res.Diff)
+		}
+	}
+
+	p.DryRun = false
+	p.DeployID = "d1_prod"
+	if res, err = singularity.Promote(staging.Client(), production.Client(), p); err != nil || !res.Created {
+		t.Fatalf("Promote: expected the deploy to be created, got %v", err)
+	}
+	req, _ := production.Request("my-service")
+	if req.Instances != 3 || req.ActiveDeploy.ID != "d1_prod" || req.ActiveDeploy.Env["ENV"] != "production" ||
+		req.ActiveDeploy.Env["LOG"] != "debug" || req.ActiveDeploy.SingularityDeployResources.MemoryMb != 512 {
+		t.Errorf("Promote: unexpected request on the target %+v", req)
+	}
+
+	// Promoting the same deploy again only changes its ID.
+	p.DryRun = true
+	p.DeployID = ""
+	if res, err = singularity.Promote(staging.Client(), production.Client(), p); err != nil {
+		t.Fatalf("Promote: unexpected error %v", err)
+	}
+	if len(res.Diff) != 1 || !strings.HasPrefix(res.Diff[0], `~ deploy.id: "d1_prod" -> "d1_pr`) {
+		t.Errorf("Promote: expected only the deploy ID to change, got %q", res.Diff)
+	}
+
+	// A deploy can't be promoted while another one is pending.
+	production.DeployState = "WAITING"
+	pending := singularity.NewDeploy("d2_prod").SetRequestID("my-service").SetCommand("./run.sh")
+	if _, err := singularity.NewDeployRequest().AttachDeploy(pending).Create(production.Client()); err != nil {
+		t.Fatalf("Create deploy: unexpected error %v", err)
+	}
+	p.DryRun = false
+	if res, err = singularity.Promote(staging.Client(), production.Client(), p); err == nil || res.Created {
+		t.Errorf("Promote: expected an error while a deploy is pending, got %+v", res)
+	}
+}
@@ -0,0 +1,55 @@
+package singularity
+
+import (
+	"fmt"
+
+	"github.com/go-resty/resty"
+)
+
+// GetSlaves retrieves the Mesos agents known to Singularity. A non-empty
+// state, such as ACTIVE or DECOMMISSIONED, returns only agents in that state.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apislaves
+func (c *Client) GetSlaves(state string) (*resty.Response, []SingularitySlave, error) {
+	req := c.request()
+	if state != "" {
+		req.SetQueryParam("state", state)
+	}
+	res, err := req.Get("/api/slaves")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity slaves error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity slaves error: %v", string(res.Body()))
+	}
+
+	var body []SingularitySlave
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity slaves error: %v", err)
+	}
+	return res, body, nil
+}
+
+// GetRacks retrieves the racks known to Singularity. A non-empty state, such
+// as ACTIVE or DECOMMISSIONED, returns only racks in that state.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apiracks
+func (c *Client) GetRacks(state string) (*resty.Response, []SingularityRack, error) {
+	req := c.request()
+	if state != "" {
+		req.SetQueryParam("state", state)
+	}
+	res, err := req.Get("/api/racks")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity racks error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity racks error: %v", string(res.Body()))
+	}
+
+	var body []SingularityRack
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity racks error: %v", err)
+	}
+	return res, body, nil
+}
@@ -0,0 +1,55 @@
+package singularity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGetSlaves(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/slaves" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("state") == "DEAD" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[{"id":"s1","host":"agent1","rackId":"r1","currentState":{"state":"ACTIVE"},"resources":{"numCpus":8}}]`))
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	_, slaves, err := c.GetSlaves("")
+	if err != nil {
+		t.Fatalf("GetSlaves: unexpected error %v", err)
+	}
+	if len(slaves) != 1 || slaves[0].Host != "agent1" || slaves[0].CurrentState.State != "ACTIVE" || slaves[0].Resources.NumCpus != 8 {
+		t.Errorf("GetSlaves: got %+v", slaves)
+	}
+	if _, slaves, _ := c.GetSlaves("DEAD"); len(slaves) != 0 {
+		t.Errorf("GetSlaves(DEAD): expected no slaves, got %+v", slaves)
+	}
+}
+
+func TestClientGetRacks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/racks" || r.URL.Query().Get("state") != "ACTIVE" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`not found`))
+			return
+		}
+		w.Write([]byte(`[{"id":"r1","currentState":{"state":"ACTIVE"}}]`))
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	_, racks, err := c.GetRacks("ACTIVE")
+	if err != nil || len(racks) != 1 || racks[0].ID != "r1" || racks[0].CurrentState.State != "ACTIVE" {
+		t.Errorf("GetRacks(ACTIVE): got %+v, %v", racks, err)
+	}
+	if _, _, err := c.GetRacks(""); err == nil {
+		t.Errorf("GetRacks: expected an error")
+	}
+}
@@ -7,16 +7,16 @@ type SingularityRequest struct {
 	Instances                                       int64             `json:"instances,omitempty"`
 	NumRetriesOnFailure                             int64             `json:"numRetriesOnFailure,omitempty"`
 	QuartzSchedule                                  string            `json:"quartzSchedule,omitempty"`
-	RequestType                                     string            `json:"requestType"`
+	RequestType                                     RequestType       `json:"requestType"`
 	Schedule                                        string            `json:"schedule,omitempty"`
 	ScheduleType                                    string            `json:"scheduleType,omitempty"`
-	HideEvenNumberAcrossRacksHint                   bool              `json:"hideEventNumerAcrossRacksHint,omitempty"`
-	TaskExecutionTimeLimitMillis                    int               `json:"taskExecutionTimeLimitMills"`
+	HideEvenNumberAcrossRacksHint                   bool              `json:"hideEvenNumberAcrossRacksHint,omitempty"`
+	TaskExecutionTimeLimitMillis                    Millis            `json:"taskExecutionTimeLimitMillis,omitempty"`
 	TaskLogErrorRegexCaseSensitive                  bool              `json:"taskLogErrorRegexCaseSensitive"`
 	SkipHealthchecks                                bool              `json:"skipHealthchecks"`
-	WaitAtLeastMillisAfterTaskFinishesForReschedule int               `json:"waitAtleastMillisAfterTaskFinishesForReschedule"`
-	TaskPriorityLevel                               int               `json:"taksPriorityLevel"`
-	RackAffinity                                    []string          `json:"RackAffinity"`
+	WaitAtLeastMillisAfterTaskFinishesForReschedule int               `json:"waitAtLeastMillisAfterTaskFinishesForReschedule,omitempty"`
+	TaskPriorityLevel                               float64           `json:"taskPriorityLevel,omitempty"`
+	RackAffinity                                    []string          `json:"rackAffinity,omitempty"`
 	MaxTasksPerOffer                                int               `json:"maxTasksPerOffer,omitempty"`
 	BounceAfterScale                                bool              `json:"bounceAfterScale"`
 	RackSensitive                                   bool              `json:"rackSensitive"`
@@ -26,79 +26,49 @@ type SingularityRequest struct {
 	ScheduledExpectedRuntimeMillis                  int               `json:"scheduledExpectedRuntimeMillis"`
 	RequiredSlaveAttributes                         map[string]string `json:"requiredSlaveAttributes"`
 	LoadBalanced                                    bool              `json:"loadBalanced,omitempty"`
-	KillOldNonLongRunningTasksAfterMillis           int               `json:"killOldNonLongRunningTasksAfterMillis,omitempty"`
-	ScheduleTimeZone                                string            `json:"scheduledTimeZone"`
-	AllowBounceToSameHost                           bool              `json:"allowBounceToSamehost"`
+	KillOldNonLongRunningTasksAfterMillis           Millis            `json:"killOldNonLongRunningTasksAfterMillis,omitempty"`
+	ScheduleTimeZone                                string            `json:"scheduleTimeZone,omitempty"`
+	AllowBounceToSameHost                           bool              `json:"allowBounceToSameHost,omitempty"`
 	TaskLogErrorRegex                               string            `json:"taskLogErrorRegex"`
-	SlavePlacement                                  *string           `json:"slavePlacement"`
+	SlavePlacement                                  *SlavePlacement   `json:"slavePlacement"`
+	Group                                           string            `json:"group,omitempty"`
+	ReadOnlyGroups                                  []string          `json:"readOnlyGroups,omitempty"`
+	ReadWriteGroups                                 []string          `json:"readWriteGroups,omitempty"`
 }
 
-// ActiveDeploy have a string deployId, requestId and a timestamp.
+// ActiveDeploy have a string deployId, requestId and a timestamp. It marks
+// both the active and the pending deploy of a RequestDeployState.
 type ActiveDeploy struct {
-	DeployID  string `json:"deployId"`
-	RequestID string `json:"requestId"`
-	Timestamp int64  `json:"timestamp"`
+	DeployID  string      `json:"deployId"`
+	RequestID string      `json:"requestId"`
+	Timestamp EpochMillis `json:"timestamp"`
 }
 
 // RequestDeployState contains specific configuration or version
 // of the running code for that deployable item
 type RequestDeployState struct {
-	ActiveDeploy `json:"activeDeploy"`
-	RequestID    string `json:"requestId"`
+	ActiveDeploy       `json:"activeDeploy"`
+	PendingDeployState ActiveDeploy `json:"pendingDeploy"`
+	RequestID          string       `json:"requestId"`
 }
 
 // Request struct contains all singularity requests.
 // This have a JSON response of /api/requests/request/ID.
 type Request struct {
 	SingularityRequest `json:"request"`
-	RequestDeployState struct {
-		ActiveDeploy struct {
-			DeployID  string `json:"deployId"`
-			RequestID string `json:"requestId"`
-			Timestamp int64  `json:"timestamp"`
-		} `json:"activeDeploy"`
-		PendingDeployState struct {
-			DeployID  string `json:"deployId"`
-			RequestID string `json:"requestId"`
-			Timestamp int64  `json:"timestamp"`
-		} `json:"pendingDeploy"`
-		RequestID string `json:"requestId"`
-	} `json:"requestDeployState"`
-	State        string `json:"state"`
-	ActiveDeploy struct {
-		Arguments                  []string `json:"arguments,omitempy"`
-		Command                    string   `json:"command,omitempty"`
-		ContainerInfo              `json:"containerInfo"`
-		Env                        map[string]string `json:"env"`
-		ID                         string            `json:"id"`
-		RequestID                  string            `json:"requestId"`
-		SingularityDeployResources `json:"resources"`
-		Uris                       []SingularityMesosArtifact `json:"uris"`
-		Volumes                    []SingularityVolume        `json:"volumes"`
-		Metadata                   map[string]string          `json:"metadata"`
-	} `json:"activeDeploy"`
-	PendingDeploy struct {
-		CustomExecutorID           string              `json:"customExecutorId"`
-		Volumes                    []SingularityVolume `json:"volumes"`
-		SingularityDeployResources `json:"resources"`
-		Uris                       []SingularityMesosArtifact `json:"uris"`
-		ContainerInfo              `json:"containerInfo"`
-		Arguments                  []string          `json:"arguments"`
-		TaskEnv                    interface{}       `json:"taskEnv"` //Map[int,Map[string,string]]	Map of environment variable overrides for specific task instances.
-		AutoAdvanceDeploySteps     bool              `json:"autoAdvanceDeploySteps"`
-		ID                         string            `json:"id"`
-		Command                    string            `json:"command"`
-		Metadata                   map[string]string `json:"metadata"`
-	} `json:"pendingDeploy"`
-	RunImmediately struct {
+	RequestDeployState RequestDeployState `json:"requestDeployState"`
+	State              RequestState       `json:"state"`
+	ActiveDeploy       SingularityDeploy  `json:"activeDeploy"`
+	PendingDeploy      SingularityDeploy  `json:"pendingDeploy"`
+	RunImmediately     struct {
 		Resources struct {
-			Cpus     int64 `json:"cpus"`
-			DiskMb   int64 `json:"diskMb"`
-			MemoryMb int64 `json:"memoryMb"`
-			NumPorts int64 `json:"numPorts"`
+			Cpus     float64 `json:"cpus"`
+			DiskMb   float64 `json:"diskMb"`
+			MemoryMb float64 `json:"memoryMb"`
+			NumPorts int64   `json:"numPorts"`
 		} `json:"resources"`
-		RunAt int64  `json:"runAt"`
-		RunID string `json:"runId"`
+		RunAt EpochMillis `json:"runAt"`
+		RunID string      `json:"runId"`
 	} `json:"runImmediately"`
 	SkipHealthchecksOnDeploy bool `json:"skipHealthchecksOnDeploy"`
 }
@@ -113,22 +83,52 @@ type Docker struct {
 	Privileged     bool              `json:"privileged,omitempty"`
 }
 
-// ContainerInfo contains information about a Docker type Singularity
-// container type.
+// ContainerInfo contains information about the container a Singularity
+// deploy runs in. DockerInfo is used by DOCKER containers and Mesos by MESOS
+// containers.
 // https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#-singularitycontainerinfo
 type ContainerInfo struct {
 	DockerInfo `json:"docker"`
-	Type       string              `json:"type"` // Allowable values: MESOS, DOCKER. Default is MESOS.
-	Volumes    []SingularityVolume `json:"volumes,omitempty"`
+	Type       string                `json:"type"` // Allowable values: MESOS, DOCKER. Default is MESOS.
+	Volumes    []SingularityVolume   `json:"volumes,omitempty"`
+	Mesos      *SingularityMesosInfo `json:"mesos,omitempty"`
+}
+
+// SingularityMesosInfo contains settings for the Mesos containerizer.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityMesosInfo
+type SingularityMesosInfo struct {
+	Image *SingularityMesosImage `json:"image,omitempty"`
+}
+
+// SingularityMesosImage is a Docker or AppC image run by the Mesos
+// containerizer. Type is DOCKER or APPC, and says which of Docker and Appc
+// is set.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityMesosImage
+type SingularityMesosImage struct {
+	Type   string                  `json:"type"`
+	Docker *SingularityDockerImage `json:"docker,omitempty"`
+	Appc   *SingularityAppcImage   `json:"appc,omitempty"`
+	Cached bool                    `json:"cached,omitempty"`
+}
+
+// SingularityDockerImage names a Docker image for the Mesos containerizer.
+type SingularityDockerImage struct {
+	Name string `json:"name"`
+}
+
+// SingularityAppcImage names an AppC image for the Mesos containerizer.
+type SingularityAppcImage struct {
+	Name string `json:"name"`
+	ID   string `json:"id,omitempty"`
 }
 
 //https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityDockerInfo
 type DockerInfo struct {
 	Parameters                  map[string]string            `json:"parameters,omitempty"`
 	ForcePullImage              bool                         `json:"forcePullImage,omitempty"`
-	SingularityDockerParameters []SingularityDockerParameter `json:"dockerParameters,omitEmpty"`
-	Privileged                  bool                         `json:"privileged,omitEmpty"`
-	Network                     string                       `json:"network,omitEmpty"` //Value can be BRIDGE, HOST, or NONE
+	SingularityDockerParameters []SingularityDockerParameter `json:"dockerParameters,omitempty"`
+	Privileged                  bool                         `json:"privileged,omitempty"`
+	Network                     string                       `json:"network,omitempty"` //Value can be BRIDGE, HOST, or NONE
 	Image                       string                       `json:"image"`
 	PortMappings                []DockerPortMapping          `json:"portMappings,omitempty"`
 }
@@ -144,8 +144,8 @@ type DockerPortMapping struct {
 
 // https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityDockerParameter
 type SingularityDockerParameter struct {
-	Key   string `json:"key,omitEmpty"`
-	Value string `json:"value,omitEmpty"`
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
 }
 
 // SingularityVolume contains information about Docker volume. This is optional.
@@ -158,25 +158,9 @@ type SingularityVolume struct {
 
 // Task contains JSON response of /api/requests/request/ID.
 type Task struct {
-	ActiveDeploy struct {
-		Arguments                  []string `json:"arguments"`
-		Command                    string   `json:"command"`
-		ContainerInfo              `json:"containerInfo"`
-		Env                        map[string]string `json:"env"`
-		ID                         string            `json:"id"`
-		RequestID                  string            `json:"requestId"`
-		SingularityDeployResources `json:"resources"`
-		Uris                       []string `json:"uris"`
-	} `json:"activeDeploy"`
-	RequestDeployState struct {
-		ActiveDeploy struct {
-			DeployID  string `json:"deployId"`
-			RequestID string `json:"requestId"`
-			Timestamp int64  `json:"timestamp"`
-		} `json:"activeDeploy"`
-		RequestID string `json:"requestId"`
-	} `json:"requestDeployState"`
-	State              string `json:"state"`
+	ActiveDeploy       SingularityDeploy  `json:"activeDeploy"`
+	RequestDeployState RequestDeployState `json:"requestDeployState"`
+	State              RequestState       `json:"state"`
 	SingularityRequest `json:"request"`
 }
 
@@ -192,31 +176,24 @@ type SingularityDeployResources struct {
 // SingularityScaleRequest contains parameters for making scaling a request. For more info, please see:
 // https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#-singularityscalerequest
 type SingularityScaleRequest struct {
-	SkipHealthchecks bool   `json:"skipHealthchecks"`
-	DurationMillis   int64  `json:"durationMillis"`
-	Bounce           bool   `json:"bounce"`
-	Message          string `json:"message"`
-	ActionID         string `json:"actionId"`
+	SkipHealthchecks bool   `json:"skipHealthchecks,omitempty"` // optional	Instruct new tasks that are scheduled immediately while executing the scale to skip healthchecks
+	DurationMillis   Millis `json:"durationMillis,omitempty"`   // optional	The number of milliseconds to wait before reversing the effects of this action
+	Bounce           bool   `json:"bounce,omitempty"`           // optional	Bounce the request to get the new instance count
+	Message          string `json:"message,omitempty"`          // optional	A message to show to users about why this action was taken
+	ActionID         string `json:"actionId,omitempty"`         // optional	An id to associate with this action for metadata purposes
 	Instances        int    `json:"instances"`
-	Incremental      int    `json:"incremental"`
+	Incremental      bool   `json:"incremental,omitempty"` // optional	If Bounce is set, kill old tasks as soon as replacement tasks are available
 }
 
 // SingularityExpiringSkipHealthchecks have parameters for a expiring skip
 // healthchecks.
 type SingularityExpiringSkipHealthchecks struct {
-	User                                string      `json:"user"`
-	RequestID                           string      `json:"requestId"`
-	StartMillis                         int64       `json:"startMillis"`
-	ActionID                            string      `json:"actionId"`
-	SingularityExpiringAPIRequestObject interface{} `json:"expiringAPIRequestObject"`
-	RevertToSkipHealthchecks            bool        `json:"revertToSkipHealthchecks"`
-}
-
-// RequestState contains a string state of a existing job/Singulariy request. Allowable
-// values are:  ACTIVE, DELETING, DELETED, PAUSED, SYSTEM_COOLDOWN, FINISHED,
-// DEPLOYING_TO_UNPAUSE
-type RequestState struct {
-	State string `json:"state"` //Allowable values:
+	User                     string                              `json:"user"`
+	RequestID                string                              `json:"requestId"`
+	StartMillis              EpochMillis                         `json:"startMillis"`
+	ActionID                 string                              `json:"actionId"`
+	ExpiringAPIRequestObject SingularityExpiringAPIRequestObject `json:"expiringAPIRequestObject"`
+	RevertToSkipHealthchecks bool                                `json:"revertToSkipHealthchecks"`
 }
 
 // HealthcheckProtocol contains a string with allowable value of
@@ -245,64 +222,64 @@ type SingularityMesosTaskLabel struct {
 }
 
 type EmbeddedArtifact struct {
-	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask"` // optional
-	Md5sum                     string `json:"md5sum"`                     // optional
-	Filename                   string `json:"filename"`                   // optional
-	Name                       string `json:"name"`                       // optional
-	Content                    []byte `json:"content"`                    // optional
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"` // optional
+	Md5sum                     string `json:"md5sum,omitempty"`                     // optional
+	Filename                   string `json:"filename,omitempty"`                   // optional
+	Name                       string `json:"name,omitempty"`                       // optional
+	Content                    []byte `json:"content,omitempty"`                    // optional
 }
 
 type S3Artifact struct {
-	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask"` //	optional
-	S3Bucket                   string `json:"s3Bucket"`                   //optional
-	Md5sum                     string `json:"md5sum"`                     // optional
-	Filename                   string `json:"filename"`                   //optional
-	Filesize                   int64  `json:"filesize"`                   // long	// optional
-	S3ObjectKey                string `json:"s3ObjectKey"`                // optional
-	Name                       string `json:"name"`                       // optional
-	IsArtifactList             bool   `json:"isArtifactList"`             //optional
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"` //	optional
+	S3Bucket                   string `json:"s3Bucket,omitempty"`                   //optional
+	Md5sum                     string `json:"md5sum,omitempty"`                     // optional
+	Filename                   string `json:"filename,omitempty"`                   //optional
+	Filesize                   int64  `json:"filesize,omitempty"`                   // long	// optional
+	S3ObjectKey                string `json:"s3ObjectKey,omitempty"`                // optional
+	Name                       string `json:"name,omitempty"`                       // optional
+	IsArtifactList             bool   `json:"isArtifactList,omitempty"`             //optional
 }
 
 type S3ArtifactSignature struct {
-	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask"` //	optional
-	S3Bucket                   string `json:"s3Bucket"`                   //optional
-	Md5sum                     string `json:"md5sum"`                     // optional
-	Filename                   string `json:"filename"`                   //optional
-	Filesize                   int64  `json:"filesize"`                   // long	// optional
-	S3ObjectKey                string `json:"s3ObjectKey"`                // optional
-	Name                       string `json:"name"`                       // optional
-	IsArtifactList             bool   `json:"isArtifactList"`             //optional
-	ArtifactFilename           string `json:"artifactFilename"`           // optional
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"` //	optional
+	S3Bucket                   string `json:"s3Bucket,omitempty"`                   //optional
+	Md5sum                     string `json:"md5sum,omitempty"`                     // optional
+	Filename                   string `json:"filename,omitempty"`                   //optional
+	Filesize                   int64  `json:"filesize,omitempty"`                   // long	// optional
+	S3ObjectKey                string `json:"s3ObjectKey,omitempty"`                // optional
+	Name                       string `json:"name,omitempty"`                       // optional
+	IsArtifactList             bool   `json:"isArtifactList,omitempty"`             //optional
+	ArtifactFilename           string `json:"artifactFilename,omitempty"`           // optional
 }
 
 type ExternalArtifact struct {
-	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask"` //	optional
-	Md5sum                     string `json:"md5sum"`                     // optional
-	URL                        string `json:"url"`                        // optional
-	Filename                   string `json:"filename"`                   //optional
-	Filesize                   int64  `json:"filesize"`                   // long	// optional
-	Name                       string `json:"name"`                       // optional
-	IsArtifactList             bool   `json:"isArtifactList"`             //optional
+	TargetFolderRelativeToTask string `json:"targetFolderRelativeToTask,omitempty"` //	optional
+	Md5sum                     string `json:"md5sum,omitempty"`                     // optional
+	URL                        string `json:"url,omitempty"`                        // optional
+	Filename                   string `json:"filename,omitempty"`                   //optional
+	Filesize                   int64  `json:"filesize,omitempty"`                   // long	// optional
+	Name                       string `json:"name,omitempty"`                       // optional
+	IsArtifactList             bool   `json:"isArtifactList,omitempty"`             //optional
 }
 
 type ExecutorData struct {
-	SkipLogrotateAndCompress       bool                  `json:"skipLogrotateAndCompress"`       //	optional	If true, do not run logrotate or compress old log files
-	LoggingExtraFields             map[string]string     `json:"loggingExtraFields"`             //Map[string,string]	optional
-	EmbeddedArtifacts              []EmbeddedArtifact    `json:"embeddedArtifacts"`              //	optional	A list of the full content of any embedded artifacts
-	S3Artifacts                    []S3Artifact          `json:"s3Artifacts"`                    //	optional	List of s3 artifacts for the executor to download
-	SuccessfulExitCodes            []int                 `json:"successfulExitCodes"`            //	optional	Allowable exit codes for the task to be considered FINISHED instead of FAILED
-	RunningSentinel                string                `json:"runningSentinel"`                // optional
-	LogrotateFrequency             string                `json:"logrotateFrequency"`             // SingularityExecutorLogrotateFrequency	optional	Run logrotate this often. Can be HOURLY, DAILY, WEEKLY, MONTHLY
-	MaxOpenFiles                   int                   `json:"maxOpenFiles"`                   // optional	Maximum number of open files the task process is allowed
-	ExternalArtifacts              []ExternalArtifact    `json:"externalArtifacts"`              //	optional	A list of external artifacts for the executor to download
-	User                           string                `json:"user"`                           // optional	Run the task process as this user
-	PreserveTaskSandboxAfterFinish bool                  `json:"preserveTaskSandboxAfterFinish"` //optional	If true, do not delete files in the task sandbox after the task process has terminated
-	ExtraCmdLineArgs               []string              `json:"extraCmdLineArgs"`               //	optional	Extra arguments in addition to any provided in the cmd field
-	LoggingTag                     string                `json:"loggingTag"`                     //optional
-	SigKillProcessesAfterMillis    int64                 `json:"sigKillProcessesAfterMillis"`    //long	optional	Send a sigkill to a process if it has not shut down this many millis after being sent a term signal
-	MaxTaskThreads                 int                   `json:"maxTaskThreads"`                 // optional	Maximum number of threads a task is allowed to use
-	S3ArtifactSignatures           []S3ArtifactSignature `json:"s3ArtifactSignatures"`           //	optional	A list of signatures use to verify downloaded s3artifacts
-	Cmd                            string                `json:"cmd"`                            // required	Command for the custom executor to run
+	SkipLogrotateAndCompress       bool                  `json:"skipLogrotateAndCompress,omitempty"`       //	optional	If true, do not run logrotate or compress old log files
+	LoggingExtraFields             map[string]string     `json:"loggingExtraFields,omitempty"`             //Map[string,string]	optional
+	EmbeddedArtifacts              []EmbeddedArtifact    `json:"embeddedArtifacts,omitempty"`              //	optional	A list of the full content of any embedded artifacts
+	S3Artifacts                    []S3Artifact          `json:"s3Artifacts,omitempty"`                    //	optional	List of s3 artifacts for the executor to download
+	SuccessfulExitCodes            []int                 `json:"successfulExitCodes,omitempty"`            //	optional	Allowable exit codes for the task to be considered FINISHED instead of FAILED
+	RunningSentinel                string                `json:"runningSentinel,omitempty"`                // optional
+	LogrotateFrequency             string                `json:"logrotateFrequency,omitempty"`             // SingularityExecutorLogrotateFrequency	optional	Run logrotate this often. Can be HOURLY, DAILY, WEEKLY, MONTHLY
+	MaxOpenFiles                   int                   `json:"maxOpenFiles,omitempty"`                   // optional	Maximum number of open files the task process is allowed
+	ExternalArtifacts              []ExternalArtifact    `json:"externalArtifacts,omitempty"`              //	optional	A list of external artifacts for the executor to download
+	User                           string                `json:"user,omitempty"`                           // optional	Run the task process as this user
+	PreserveTaskSandboxAfterFinish bool                  `json:"preserveTaskSandboxAfterFinish,omitempty"` //optional	If true, do not delete files in the task sandbox after the task process has terminated
+	ExtraCmdLineArgs               []string              `json:"extraCmdLineArgs,omitempty"`               //	optional	Extra arguments in addition to any provided in the cmd field
+	LoggingTag                     string                `json:"loggingTag,omitempty"`                     //optional
+	SigKillProcessesAfterMillis    Millis                `json:"sigKillProcessesAfterMillis,omitempty"`    //long	optional	Send a sigkill to a process if it has not shut down this many millis after being sent a term signal
+	MaxTaskThreads                 int                   `json:"maxTaskThreads,omitempty"`                 // optional	Maximum number of threads a task is allowed to use
+	S3ArtifactSignatures           []S3ArtifactSignature `json:"s3ArtifactSignatures,omitempty"`           //	optional	A list of signatures use to verify downloaded s3artifacts
+	Cmd                            string                `json:"cmd"`                                      // required	Command for the custom executor to run
 }
 
 // https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityMesosArtifact
@@ -321,7 +298,7 @@ type SingularityDeploy struct {
 	Uris                                  []SingularityMesosArtifact `json:"uris,omitempty"` //Array[SingularityMesosArtifact]	optional	List of URIs to download before executing the deploy command.
 	ContainerInfo                         `json:"containerInfo"`
 	Arguments                             []string                            `json:"arguments,omitempty"`
-	TaskEnv                               interface{}                         `json:"taskEnv,omitempty"` // map[int]map[string]string //Map[int,Map[string,string]]	Map of environment variable overrides for specific task instances.
+	TaskEnv                               map[int]map[string]string           `json:"taskEnv,omitempty"` //Map[int,Map[string,string]]	Map of environment variable overrides for specific task instances.
 	AutoAdvanceDeploySteps                bool                                `json:"autoAdvanceDeploySteps,omitempty"`
 	ServiceBasePath                       string                              `json:"serviceBasePath,omitempty"` // The base path for the API exposed by the deploy. Used in conjunction with the Load balancer API.
 	CustomExecutorSource                  string                              `json:"customExecutorSource,omitempty"`
@@ -331,13 +308,13 @@ type SingularityDeploy struct {
 	Labels                                map[string]string                   `json:"labels,omitempty"`                   // Map[string,string]	optional	Labels for all tasks associated with this deploy
 	User                                  string                              `json:"user,omitempty"`                     //optional	Run tasks as this user
 	RequestID                             string                              `json:"requestId"`                          // required	Singularity Request Id which is associated with this deploy.
-	DeployStepWaitTimeMs                  int                                 `json:"deployStepWaitTimeMs,omitempty"`     // optional	wait this long between deploy steps
+	DeployStepWaitTimeMs                  Millis                              `json:"deployStepWaitTimeMs,omitempty"`     // optional	wait this long between deploy steps
 	SkipHealthchecksOnDeploy              bool                                `json:"skipHealthchecksOnDeploy,omitempty"` //optional	Allows skipping of health checks when deploying.
 	MesosLabels                           *[]SingularityMesosTaskLabel        `json:"mesosLabels,omitempty"`              //Array[SingularityMesosTaskLabel]	optional	Labels for all tasks associated with this deploy
 	Command                               string                              `json:"command,omitempty"`                  //optional	Command to execute for this deployment.
 	*ExecutorData                         `json:"executorData,omitempty"`     //	optional	Executor specific information
 	Shell                                 bool                                `json:"shell,omitempty"`                                 //optional	Override the shell property on the mesos task
-	Timestamp                             int64                               `json:"timestamp,omitempty"`                             //long	optional	Deploy timestamp.
+	Timestamp                             EpochMillis                         `json:"timestamp,omitempty"`                             //long	optional	Deploy timestamp.
 	DeployInstanceCountPerStep            int                                 `json:"deployInstanceCountPerStep,omitempty"`            //	optional	deploy this many instances at a time
 	ConsiderHealthyAfterRunningForSeconds int64                               `json:"considerHealthyAfterRunningForSeconds,omitempty"` //	optional	Number of seconds that a service must be healthy to consider the deployment to be successful.
 	MaxTaskRetries                        int                                 `json:"maxTaskRetries,omitempty"`                        // optional	allowed at most this many failed tasks to be retried before failing the deploy
@@ -355,19 +332,19 @@ type SingularityDeploy struct {
 type SingularityDeployWithLB struct {
 	CustomExecutorID           string `json:"customExecutorId"`
 	SingularityDeployResources `json:"resources"`
-	Uris                       []string `json:"uris"` //Array[SingularityMesosArtifact]	optional	List of URIs to download before executing the deploy command.
+	Uris                       []SingularityMesosArtifact `json:"uris"` //Array[SingularityMesosArtifact]	optional	List of URIs to download before executing the deploy command.
 	ContainerInfo              `json:"containerInfo"`
 	// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#-set List of domains to host this service on, for use with the load balancer api
 	LoadBalancerDomains                   []string `json:"loadBalancerDomains"` //Set
 	HealthcheckOptions                    `json:"healthcheck"`
-	Arguments                             []string          `json:"arguments"`
-	TaskEnv                               interface{}       `json:"taskEnv"` // map[int]map[string]string //Map[int,Map[string,string]]	Map of environment variable overrides for specific task instances.
-	AutoAdvanceDeploySteps                bool              `json:"autoAdvanceDeploySteps"`
-	ServiceBasePath                       string            `json:"serviceBasePath"` // The base path for the API exposed by the deploy. Used in conjunction with the Load balancer API.
-	CustomExecutorSource                  string            `json:"customExecutorSource"`
-	Metadata                              map[string]string `json:"metadata"`                  //ap of metadata key/value pairs associated with the deployment.
-	HealthcheckMaxRetries                 int               `json:"healthcheckMaxRetries"`     //optional	Maximum number of times to retry an individual healthcheck before failing the deploy.
-	HealthcheckTimeoutSeconds             int64             `json:"healthcheckTimeoutSeconds"` //optional	Single healthcheck HTTP timeout in seconds.
+	Arguments                             []string                  `json:"arguments"`
+	TaskEnv                               map[int]map[string]string `json:"taskEnv"` //Map[int,Map[string,string]]	Map of environment variable overrides for specific task instances.
+	AutoAdvanceDeploySteps                bool                      `json:"autoAdvanceDeploySteps"`
+	ServiceBasePath                       string                    `json:"serviceBasePath"` // The base path for the API exposed by the deploy. Used in conjunction with the Load balancer API.
+	CustomExecutorSource                  string                    `json:"customExecutorSource"`
+	Metadata                              map[string]string         `json:"metadata"`                  //ap of metadata key/value pairs associated with the deployment.
+	HealthcheckMaxRetries                 int                       `json:"healthcheckMaxRetries"`     //optional	Maximum number of times to retry an individual healthcheck before failing the deploy.
+	HealthcheckTimeoutSeconds             int64                     `json:"healthcheckTimeoutSeconds"` //optional	Single healthcheck HTTP timeout in seconds.
 	HealthcheckProtocol                   `json:"healthcheckProtocol"`
 	TaskLabels                            map[int]map[string]string           `json:"taskLabels"`                        //Map[int,Map[string,string]]	optional	(Deprecated) Labels for specific tasks associated with this deploy, indexed by instance number
 	HealthcheckPortIndex                  int                                 `json:"healthcheckPortIndex"`              //optional	Perform healthcheck on this dynamically allocated port (e.g. 0 for first port), defaults to first port
@@ -378,16 +355,16 @@ type SingularityDeployWithLB struct {
 	HealthcheckURI                        string                              `json:"healthcheckUri"`                    //optional	Deployment Healthcheck URI, if specified will be called after TASK_RUNNING.
 	User                                  string                              `json:"user"`                              //optional	Run tasks as this user
 	RequestID                             string                              `json:"requestId"`                         // required	Singularity Request Id which is associated with this deploy.
-	LoadBalancerGroups                    interface{}                         `json:"loadBalancerGroups"`                // Set	optional	List of load balancer groups associated with this deployment.
-	DeployStepWaitTimeMs                  int                                 `json:"deployStepWaitTimeMs"`              // optional	wait this long between deploy steps
+	LoadBalancerGroups                    []string                            `json:"loadBalancerGroups"`                // Set	optional	List of load balancer groups associated with this deployment.
+	DeployStepWaitTimeMs                  Millis                              `json:"deployStepWaitTimeMs"`              // optional	wait this long between deploy steps
 	SkipHealthchecksOnDeploy              bool                                `json:"skipHealthchecksOnDeploy"`          //optional	Allows skipping of health checks when deploying.
 	MesosLabels                           []SingularityMesosTaskLabel         `json:"mesosLabels"`                       //Array[SingularityMesosTaskLabel]	optional	Labels for all tasks associated with this deploy
 	HealthcheckIntervalSeconds            int64                               `json:"healthcheckIntervalSeconds"`        //long	optional	Time to wait after a failed healthcheck to try again in seconds.
 	Command                               string                              `json:"command"`                           //optional	Command to execute for this deployment.
 	ExecutorData                          `json:"executorData"`               //	optional	Executor specific information
-	LoadBalancerAdditionalRoutes          []string                            `json:"loadBalancerAdditionsRoutes"`           // optional	Additional routes besides serviceBasePath used by this service
+	LoadBalancerAdditionalRoutes          []string                            `json:"loadBalancerAdditionalRoutes"`          // optional	Additional routes besides serviceBasePath used by this service
 	Shell                                 bool                                `json:"shell"`                                 //optional	Override the shell property on the mesos task
-	Timestamp                             int64                               `json:"timestamp"`                             //long	optional	Deploy timestamp.
+	Timestamp                             EpochMillis                         `json:"timestamp"`                             //long	optional	Deploy timestamp.
 	DeployInstanceCountPerStep            int                                 `json:"deployInstanceCountPerStep"`            //	optional	deploy this many instances at a time
 	ConsiderHealthyAfterRunningForSeconds int64                               `json:"considerHealthyAfterRunningForSeconds"` //	optional	Number of seconds that a service must be healthy to consider the deployment to be successful.
 	LoadBalancerOptions                   map[string]interface{}              `json:"loadBalancerOptions"`                   // Map[string,Object]	optional	Map (Key/Value) of options for the load balancer.
@@ -410,41 +387,41 @@ type SingularityRunNowRequest struct {
 	SkipHealthchecks           bool                         `json:"skipHealthchecks,omitempty"` // 	optional	If set to true, healthchecks will be skipped for this task run
 	CommandLineArgs            []string                     `json:"commandLineArgs,omitempty"`  //	optional	Command line arguments to be passed to the task
 	Message                    string                       `json:"message,omitempty"`          //optional	A message to show to users about why this action was taken
-	RunAt                      int64                        `json:"runAt,omitEmpty"`            //long	optional	Schedule this task to run at a specified time
+	RunAt                      EpochMillis                  `json:"runAt,omitempty"`            //long	optional	Schedule this task to run at a specified time
 }
 
 // SingularityExpiringPause contains information of a existing
 // Singularity request.
 type SingularityExpiringPause struct {
-	User                                string      `json:"user"`
-	RequestID                           string      `json:"requestId"`
-	StartMillis                         int64       `json:"startMillis"`
-	ActionID                            string      `json:"actionId"`
-	SingularityExpiringAPIRequestObject interface{} `json:"expiringAPIRequestObject"`
+	User                     string                  `json:"user"`
+	RequestID                string                  `json:"requestId"`
+	StartMillis              EpochMillis             `json:"startMillis"`
+	ActionID                 string                  `json:"actionId"`
+	ExpiringAPIRequestObject SingularityPauseRequest `json:"expiringAPIRequestObject"`
 }
 
 // SingularityExpiringBounce contains information of a existing
 // Singularity request.
 type SingularityExpiringBounce struct {
-	User                     string      `json:"user"`
-	RequestID                string      `json:"requestId"`
-	StartMillis              int64       `json:"startMillis"`
-	DeployID                 string      `json:"deployId"`
-	ActionID                 string      `json:"actionId"`
-	ExpiringAPIRequestObject interface{} `json:"expiringAPIRequestObject"`
+	User                     string                   `json:"user"`
+	RequestID                string                   `json:"requestId"`
+	StartMillis              EpochMillis              `json:"startMillis"`
+	DeployID                 string                   `json:"deployId"`
+	ActionID                 string                   `json:"actionId"`
+	ExpiringAPIRequestObject SingularityBounceRequest `json:"expiringAPIRequestObject"`
 }
 
 // SingularityDeployProgress contains deploy progress of a existing
 // Singularity request.
 type SingularityDeployProgress struct {
-	AutoAdvanceDeploySteps     bool        `json:"autoAdvanceDeploySteps"`
-	StepComplete               bool        `json:"stepComplete"`
-	DeployStepWaitTimeMs       int64       `json:"deployStepWaitTimeMs"`
-	Timestamp                  int64       `json:"timestamp"`
-	DeployInstanceCountPerStep int         `json:"deployInstanceCountPerStep"`
-	FailedDeployTasks          interface{} `json:"failedDeployTasks"` //Set	optional
-	CurrentActiveInstances     int         `json:"currentActiveInstances"`
-	TargetActiveInstances      int         `json:"targetActiveInstances"`
+	AutoAdvanceDeploySteps     bool                `json:"autoAdvanceDeploySteps"`
+	StepComplete               bool                `json:"stepComplete"`
+	DeployStepWaitTimeMs       Millis              `json:"deployStepWaitTimeMs"`
+	Timestamp                  EpochMillis         `json:"timestamp"`
+	DeployInstanceCountPerStep int                 `json:"deployInstanceCountPerStep"`
+	FailedDeployTasks          []SingularityTaskID `json:"failedDeployTasks"` //Set	optional
+	CurrentActiveInstances     int                 `json:"currentActiveInstances"`
+	TargetActiveInstances      int                 `json:"targetActiveInstances"`
 }
 
 // SingularityLoadBalancerRequestID have loadbalancer information of a
@@ -460,29 +437,29 @@ type SingularityLoadBalancerRequestID struct {
 // Singularity request's loadbalancer.
 type SingularityLoadBalancerUpdate struct {
 	// Allowable values: UNKNOWN, FAILED, WAITING, SUCCESS, CANCELING, CANCELED, INVALID_REQUEST_NOOP
-	LoadBalancerState                string `json:"loadBalancerState"`
+	LoadBalancerState                LoadBalancerState `json:"loadBalancerState"`
 	SingularityLoadBalancerRequestID `json:"loadBalancerRequestId"`
 	URI                              string `json:"uri"`
 	// Allowable values: PRE_ENQUEUE, ENQUEUE, CHECK_STATE, CANCEL, DELETE
-	Method    string `json:"method"`
-	Message   string `json:"message"`
-	Timestamp int64  `json:"timestamp"`
+	Method    string      `json:"method"`
+	Message   string      `json:"message"`
+	Timestamp EpochMillis `json:"timestamp"`
 }
 
 // SingularityDeployMarker holds information of a Singularity deploy.
 type SingularityDeployMarker struct {
-	User      string `json:"user"`
-	RequestID string `json:"requestId"`
-	Message   string `json:"message"`
-	Timestamp int64  `json:"timestamp"`
-	DeployID  string `json:"deployId"`
+	User      string      `json:"user"`
+	RequestID string      `json:"requestId"`
+	Message   string      `json:"message"`
+	Timestamp EpochMillis `json:"timestamp"`
+	DeployID  string      `json:"deployId"`
 }
 
 // SingularityDeployState holds information of a existing Singularity
 // deploy.
 type SingularityDeployState struct {
 	// Allowable values: SUCCEEDED, FAILED_INTERNAL_STATE, CANCELING, WAITING, OVERDUE, FAILED, CANCELED
-	CurrentDeployState            string `json:"currentDeployState"`
+	CurrentDeployState            DeployState `json:"currentDeployState"`
 	SingularityRequest            `json:"updatedRequest"`
 	SingularityDeployProgress     `json:"deployProgress"`
 	SingularityLoadBalancerUpdate `json:"lastLoadBalancerUpdate"`
@@ -491,29 +468,31 @@ type SingularityDeployState struct {
 
 type SingularityExpiringAPIRequestObject struct {
 	ActionID         string `json:"actionId"`
-	DurationMillis   int64  `json:"durationMillis"`
+	DurationMillis   Millis `json:"durationMillis"`
 	Instances        int64  `json:"instances"`
 	Message          string `json:"message"`
 	SkipHealthchecks bool   `json:"skipHealthchecks"`
+	Bounce           bool   `json:"bounce,omitempty"`
+	Incremental      bool   `json:"incremental,omitempty"`
 }
 
 // SingularityExpiringScale holds information of a expiring scale Singularity
 // deploy.
 type SingularityExpiringScale struct {
-	RevertToInstances                   int    `json:"revertToInstances"`
-	User                                string `json:"user"`
-	RequestID                           string `json:"requestId"`
-	Bounce                              bool   `json:"bounce"`
-	StartMillis                         int64  `json:"startMillis"`
-	ActionID                            string `json:"actionId"`
-	DurationMillis                      int64  `json:"durationMillis"`
+	RevertToInstances                   int         `json:"revertToInstances"`
+	User                                string      `json:"user"`
+	RequestID                           string      `json:"requestId"`
+	Bounce                              bool        `json:"bounce"`
+	StartMillis                         EpochMillis `json:"startMillis"`
+	ActionID                            string      `json:"actionId"`
+	DurationMillis                      Millis      `json:"durationMillis"`
 	SingularityExpiringAPIRequestObject `json:"expiringAPIRequestObject"`
 }
 
 // SingularityPendingDeploy holds information of a pending Singularity
 // deploy.
 type SingularityPendingDeploy struct {
-	CurrentDeployState            string `json:"currentDeployState"` // Allowable values: SUCCEEDED, FAILED_INTERNAL_STATE, CANCELING, WAITING, OVERDUE, FAILED, CANCELED
+	CurrentDeployState            DeployState `json:"currentDeployState"` // Allowable values: SUCCEEDED, FAILED_INTERNAL_STATE, CANCELING, WAITING, OVERDUE, FAILED, CANCELED
 	SingularityRequest            `json:"updatedRequest"`
 	SingularityDeployProgress     `json:"deployProgress"`
 	SingularityLoadBalancerUpdate `json:"lastLoadBalancerUpdate"`
@@ -523,48 +502,15 @@ type SingularityPendingDeploy struct {
 // SingularityRequestParent contains request of a Singularity deploy.
 type SingularityRequestParent struct {
 	SingularityExpiringSkipHealthchecks `json:"expiringSkipHealthchecks"`
-	PendingDeploy                       struct {
-		CustomExecutorID           string `json:"customExecutorId"`
-		SingularityDeployResources `json:"resources"`
-		Uris                       interface{} `json:"uris"` //Array[SingularityMesosArtifact]	optional	List of URIs to download before executing the deploy command.
-		ContainerInfo              `json:"containerInfo"`
-		// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#-set List of domains to host this service on, for use with the load balancer api
-		LoadBalancerDomains    interface{} `json:"loadBalancerDomains"` //Set
-		HealthcheckOptions     `json:"healthcheck"`
-		Arguments              []string    `json:"arguments"`
-		TaskEnv                interface{} `json:"taskEnv"` //Map[int,Map[string,string]]	Map of environment variable overrides for specific task instances.
-		AutoAdvanceDeploySteps bool        `json:"autoAdvanceDeploySteps"`
-		ID                     string      `json:"id"`
-		Command                string      `json:"command"`
-	} `json:"pendingDeploy"`
-	ActiveDeploy struct {
-		CustomExecutorID           string `json:"customExecutorId"`
-		SingularityDeployResources `json:"resources"`
-		Uris                       interface{} `json:"uris"` //Array[SingularityMesosArtifact]	optional	List of URIs to download before executing the deploy command.
-		ContainerInfo              `json:"containerInfo"`
-		// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#-set List of domains to host this service on, for use with the load balancer api
-		LoadBalancerDomains    interface{} `json:"loadBalancerDomains"` //Set
-		HealthcheckOptions     `json:"healthcheck"`
-		Arguments              []string    `json:"arguments"`
-		TaskEnv                interface{} `json:"taskEnv"` //Map[int,Map[string,string]]	Map of environment variable overrides for specific task instances.
-		AutoAdvanceDeploySteps bool        `json:"autoAdvanceDeploySteps"`
-		ID                     string      `json:"id"`
-		Command                string      `json:"command"`
-	} `json:"activeDeploy"`
-	SingularityExpiringPause  `json:"expiringPause"`
-	SingularityExpiringBounce `json:"expiringBounce"`
-	SingularityRequest        `json:"request"`
-	SingularityPendingDeploy  `json:"pendingDeployState"`
-	SingularityExpiringScale  `json:"expiringScale"`
-	RequestDeployState        struct {
-		ActiveDeploy struct {
-			DeployID  string `json:"deployId"`
-			RequestID string `json:"requestId"`
-			Timestamp int64  `json:"timestamp"`
-		} `json:"activeDeploy"`
-		RequestID string `json:"requestId"`
-	} `json:"requestDeployState"`
-	State string `json:"state"`
+	PendingDeploy                       SingularityDeploy `json:"pendingDeploy"`
+	ActiveDeploy                        SingularityDeploy `json:"activeDeploy"`
+	SingularityExpiringPause            `json:"expiringPause"`
+	SingularityExpiringBounce           `json:"expiringBounce"`
+	SingularityRequest                  `json:"request"`
+	SingularityPendingDeploy            `json:"pendingDeployState"`
+	SingularityExpiringScale            `json:"expiringScale"`
+	RequestDeployState                  RequestDeployState `json:"requestDeployState"`
+	State                               RequestState       `json:"state"`
 }
 
 // SingularityDeleteRequest contains HTTP body for a Delete Singularity Request. Please see below URL for
@@ -578,8 +524,250 @@ type SingularityDeleteRequest struct {
 }
 
 type SingularityDeployRequest struct {
-	UnpauseOnSuccessfulDeploy bool                    //optional	If deploy is successful, also unpause the request
-	SingularityDeploy         `json:"deploy"`         // required	The Singularity deploy object, containing all the required details about the Deploy
-	*SingularityRequest       `json:"updatedRequest"` // optional	use this request data for this deploy, and update the request on successful deploy
-	Message                   string                  //optional	A message to show users about this deploy (metadata)
+	UnpauseOnSuccessfulDeploy bool                              `json:"unpauseOnSuccessfulDeploy,omitempty"` //optional	If deploy is successful, also unpause the request
+	SingularityDeploy         `json:"deploy"`                   // required	The Singularity deploy object, containing all the required details about the Deploy
+	*SingularityRequest       `json:"updatedRequest,omitempty"` // optional	use this request data for this deploy, and update the request on successful deploy
+	Message                   string                            `json:"message,omitempty"` //optional	A message to show users about this deploy (metadata)
+}
+
+// SingularityPauseRequest contains HTTP body for pausing a Singularity request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityPauseRequest
+type SingularityPauseRequest struct {
+	KillTasks      bool   `json:"killTasks,omitempty"`      // optional	If set to false, tasks will be allowed to finish instead of killed immediately
+	DurationMillis Millis `json:"durationMillis,omitempty"` // optional	The number of milliseconds to wait before reversing the effects of this action (unpausing it)
+	Message        string `json:"message,omitempty"`        // optional	A message to show to users about why this action was taken
+	ActionID       string `json:"actionId,omitempty"`       // optional	An id to associate with this action for metadata purposes
+}
+
+// SingularityUnpauseRequest contains HTTP body for unpausing a Singularity request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityUnpauseRequest
+type SingularityUnpauseRequest struct {
+	SkipHealthchecks bool   `json:"skipHealthchecks,omitempty"` // optional	If set to true, instructs new tasks that are scheduled immediately while unpausing to skip healthchecks
+	Message          string `json:"message,omitempty"`          // optional	A message to show to users about why this action was taken
+	ActionID         string `json:"actionId,omitempty"`         // optional	An id to associate with this action for metadata purposes
+}
+
+// SingularityBounceRequest contains HTTP body for bouncing a Singularity request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityBounceRequest
+type SingularityBounceRequest struct {
+	Incremental      bool   `json:"incremental,omitempty"`      // optional	If present and set to true, old tasks will be killed as soon as replacement tasks are available, instead of waiting for all replacement tasks to be healthy
+	SkipHealthchecks bool   `json:"skipHealthchecks,omitempty"` // optional	Instruct replacement tasks for this bounce only to skip healthchecks
+	DurationMillis   Millis `json:"durationMillis,omitempty"`   // optional	The number of milliseconds to wait before reversing the effects of this action
+	Message          string `json:"message,omitempty"`          // optional	A message to show to users about why this action was taken
+	ActionID         string `json:"actionId,omitempty"`         // optional	An id to associate with this action for metadata purposes
+}
+
+// SingularityKillTaskRequest contains HTTP body for killing a Singularity task.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityKillTaskRequest
+type SingularityKillTaskRequest struct {
+	WaitForReplacementTask bool   `json:"waitForReplacementTask,omitempty"` // optional	If set to true, treats this task kill as a bounce - launching another task and waiting for it to become healthy
+	Override               bool   `json:"override,omitempty"`               // optional	If set to true, instructs the executor to attempt to immediately kill the task, rather than waiting gracefully
+	Message                string `json:"message,omitempty"`                // optional	A message to show to users about why this action was taken
+	ActionID               string `json:"actionId,omitempty"`               // optional	An id to associate with this action for metadata purposes
+}
+
+// SingularityTaskID identifies a single Singularity task.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityTaskId
+type SingularityTaskID struct {
+	ID              string      `json:"id"`
+	RequestID       string      `json:"requestId"`
+	DeployID        string      `json:"deployId"`
+	StartedAt       EpochMillis `json:"startedAt"`
+	InstanceNo      int         `json:"instanceNo"`
+	Host            string      `json:"host"`
+	SanitizedHost   string      `json:"sanitizedHost"`
+	RackID          string      `json:"rackId"`
+	SanitizedRackID string      `json:"sanitizedRackId"`
+}
+
+// SingularityTaskRequest contains the request and deploy a task was launched from.
+type SingularityTaskRequest struct {
+	Request SingularityRequest `json:"request"`
+	Deploy  SingularityDeploy  `json:"deploy"`
+}
+
+// SingularityTask holds information of an active Singularity task.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityTask
+type SingularityTask struct {
+	TaskID      SingularityTaskID      `json:"taskId"`
+	TaskRequest SingularityTaskRequest `json:"taskRequest"`
+}
+
+// SingularityTaskIDHistory holds the last known state of a Singularity task.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityTaskIdHistory
+type SingularityTaskIDHistory struct {
+	TaskID SingularityTaskID `json:"taskId"`
+	// Allowable values: TASK_LAUNCHED, TASK_STAGING, TASK_STARTING, TASK_RUNNING, TASK_CLEANING, TASK_KILLING,
+	// TASK_FINISHED, TASK_FAILED, TASK_KILLED, TASK_LOST, TASK_LOST_WHILE_DOWN, TASK_ERROR, TASK_DROPPED,
+	// TASK_GONE, TASK_UNREACHABLE, TASK_GONE_BY_OPERATOR, TASK_UNKNOWN
+	LastTaskState string      `json:"lastTaskState"`
+	UpdatedAt     EpochMillis `json:"updatedAt"`
+	RunID         string      `json:"runId"`
+}
+
+// SingularityTaskCleanup holds information of a task which is being killed.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityTaskCleanup
+type SingularityTaskCleanup struct {
+	TaskID      SingularityTaskID `json:"taskId"`
+	User        string            `json:"user"`
+	CleanupType string            `json:"cleanupType"`
+	Timestamp   EpochMillis       `json:"timestamp"`
+	Message     string            `json:"message"`
+	ActionID    string            `json:"actionId"`
+}
+
+// MesosFileChunk is a chunk of a file read from a task sandbox.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-MesosFileChunkObject
+type MesosFileChunk struct {
+	Data       string `json:"data"`
+	Offset     int64  `json:"offset"`
+	NextOffset int64  `json:"nextOffset"`
+}
+
+// SingularityDeployFailure describes why a task failed a deploy.
+type SingularityDeployFailure struct {
+	Reason  string            `json:"reason"` // Allowable values: TASK_FAILED_ON_STARTUP, TASK_FAILED_HEALTH_CHECKS, TASK_COULD_NOT_BE_SCHEDULED, TASK_NEVER_ENTERED_RUNNING, TASK_EXPECTED_RUNNING_FINISHED, DEPLOY_CANCELLED, DEPLOY_OVERDUE, FAILED_TO_SAVE_DEPLOY_STATE, LOAD_BALANCER_UPDATE_FAILED, PENDING_DEPLOY_REMOVED
+	TaskID  SingularityTaskID `json:"taskId"`
+	Message string            `json:"message"`
+}
+
+// SingularityDeployResult contains the final state of a Singularity deploy.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityDeployResult
+type SingularityDeployResult struct {
+	// Allowable values: SUCCEEDED, FAILED_INTERNAL_STATE, CANCELING, WAITING, OVERDUE, FAILED, CANCELED
+	DeployState    DeployState                `json:"deployState"`
+	Message        string                     `json:"message"`
+	DeployFailures []SingularityDeployFailure `json:"deployFailures"`
+	Timestamp      EpochMillis                `json:"timestamp"`
+}
+
+// SingularityDeployHistory holds a deploy and its result.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityDeployHistory
+type SingularityDeployHistory struct {
+	DeployResult *SingularityDeployResult `json:"deployResult,omitempty"`
+	DeployMarker SingularityDeployMarker  `json:"deployMarker"`
+	Deploy       *SingularityDeploy       `json:"deploy,omitempty"`
+}
+
+// SingularityUpdatePendingDeployRequest sets the target instance count of
+// the next step of a pending deploy.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityUpdatePendingDeployRequest
+type SingularityUpdatePendingDeployRequest struct {
+	RequestID             string `json:"requestId"`
+	DeployID              string `json:"deployId"`
+	TargetActiveInstances int    `json:"targetActiveInstances"`
+}
+
+// SingularitySlave is a Mesos agent known to Singularity.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularitySlave
+type SingularitySlave struct {
+	ID           string                      `json:"id"`
+	Host         string                      `json:"host"`
+	RackID       string                      `json:"rackId"`
+	FirstSeenAt  EpochMillis                 `json:"firstSeenAt"`
+	CurrentState SingularityMachineState     `json:"currentState"`
+	Attributes   map[string]string           `json:"attributes"`
+	Resources    SingularityMachineResources `json:"resources"`
+}
+
+// SingularityMachineState is the state of an agent or rack.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityMachineStateHistoryUpdate
+type SingularityMachineState struct {
+	// Allowable values: MISSING_ON_STARTUP, ACTIVE, STARTING_DECOMMISSION, DECOMMISSIONING, DECOMMISSIONED, DEAD, FROZEN
+	State     string      `json:"state"`
+	Timestamp EpochMillis `json:"timestamp"`
+	User      string      `json:"user,omitempty"`
+	Message   string      `json:"message,omitempty"`
+}
+
+// SingularityMachineResources are the resources an agent offers.
+type SingularityMachineResources struct {
+	NumCpus         float64 `json:"numCpus"`
+	MemoryMegaBytes float64 `json:"memoryMegaBytes"`
+	DiskSpace       float64 `json:"diskSpace"`
+	NumPorts        int     `json:"numPorts"`
+}
+
+// SingularityWebhook is a URI Singularity posts request, deploy or task
+// updates to.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityWebhook
+type SingularityWebhook struct {
+	ID        string      `json:"id,omitempty"`
+	URI       string      `json:"uri"`
+	Type      string      `json:"type"` // Allowable values: REQUEST, DEPLOY, TASK
+	User      string      `json:"user,omitempty"`
+	Timestamp EpochMillis `json:"timestamp,omitempty"`
+}
+
+// SingularityRequestHistory is a change to a request. It is the payload of
+// REQUEST webhooks.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityRequestHistory
+type SingularityRequestHistory struct {
+	CreatedAt EpochMillis `json:"createdAt"`
+	// Allowable values: CREATED, UPDATED, DELETING, DELETED, PAUSED, UNPAUSED, ENTERED_COOLDOWN,
+	// EXITED_COOLDOWN, FINISHED, DEPLOYED_TO_UNPAUSE, BOUNCED, SCALED, SCALE_REVERTED
+	EventType string             `json:"eventType"`
+	User      string             `json:"user"`
+	Request   SingularityRequest `json:"request"`
+	Message   string             `json:"message"`
+}
+
+// SingularityDeployUpdate is a change to a deploy. It is the payload of
+// DEPLOY webhooks.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityDeployUpdate
+type SingularityDeployUpdate struct {
+	DeployMarker SingularityDeployMarker  `json:"deployMarker"`
+	Deploy       *SingularityDeploy       `json:"deploy,omitempty"`
+	EventType    string                   `json:"eventType"` // Allowable values: STARTING, FINISHED
+	DeployResult *SingularityDeployResult `json:"deployResult,omitempty"`
+}
+
+// SingularityTaskHistoryUpdate is a change to the state of a task.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityTaskHistoryUpdate
+type SingularityTaskHistoryUpdate struct {
+	TaskID        SingularityTaskID `json:"taskId"`
+	Timestamp     EpochMillis       `json:"timestamp"`
+	TaskState     string            `json:"taskState"`
+	StatusMessage string            `json:"statusMessage"`
+	StatusReason  string            `json:"statusReason"`
+}
+
+// SingularityTaskWebhook is a task and a change to its state. It is the
+// payload of TASK webhooks.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityTaskWebhook
+type SingularityTaskWebhook struct {
+	Task       SingularityTask              `json:"task"`
+	TaskUpdate SingularityTaskHistoryUpdate `json:"taskUpdate"`
+}
+
+// SingularityPendingTaskID identifies a task which is waiting to be
+// launched.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityPendingTaskId
+type SingularityPendingTaskID struct {
+	ID         string      `json:"id"`
+	RequestID  string      `json:"requestId"`
+	DeployID   string      `json:"deployId"`
+	NextRunAt  EpochMillis `json:"nextRunAt"`
+	InstanceNo int         `json:"instanceNo"`
+	// Allowable values: IMMEDIATE, ONEOFF, BOUNCE, NEW_DEPLOY, NEXT_DEPLOY_STEP, UNPAUSED, RETRY,
+	// UPDATED_REQUEST, DECOMISSIONED_SLAVE_OR_RACK, TASK_DONE, STARTUP, CANCEL_BOUNCE, TASK_BOUNCE, DEPLOY_CANCELLED
+	PendingType string      `json:"pendingType"`
+	CreatedAt   EpochMillis `json:"createdAt"`
+}
+
+// SingularityRack is a rack of Mesos agents known to Singularity.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityRack
+type SingularityRack struct {
+	ID           string                  `json:"id"`
+	FirstSeenAt  EpochMillis             `json:"firstSeenAt"`
+	CurrentState SingularityMachineState `json:"currentState"`
+}
+
+// SingularityRequestGroup is a named set of requests, with metadata about
+// them.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#model-SingularityRequestGroup
+type SingularityRequestGroup struct {
+	ID         string            `json:"id"`
+	RequestIDs []string          `json:"requestIds"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
 }
@@ -0,0 +1,311 @@
+package singularity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lenfree/go-mesos-singularity/api"
+)
+
+// TestModelsRoundTrip decodes payloads in the shape Singularity returns and
+// accepts, encodes them again, and checks that no field was dropped or
+// changed on the way.
+//
+// The payloads in testdata are not recorded from a Singularity server. They
+// are written by hand from the models in Singularity's API reference,
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md,
+// so they only catch a model which disagrees with that reference. Replace
+// them with responses recorded from a Singularity server when one is at
+// hand, and name its version here.
+func TestModelsRoundTrip(t *testing.T) {
+	tests := []struct {
+		file  string
+		model func() interface{}
+	}{
+		{"request_parent.json", func() interface{} { return &SingularityRequestParent{} }},
+		{"requests.json", func() interface{} { return &[]Request{} }},
+		{"deploy_request.json", func() interface{} { return &SingularityDeployRequest{} }},
+		{"deploy_history.json", func() interface{} { return &SingularityDeployHistory{} }},
+		{"task_history.json", func() interface{} { return &[]SingularityTaskIDHistory{} }},
+		{"slaves.json", func() interface{} { return &[]SingularitySlave{} }},
+		{"racks.json", func() interface{} { return &[]SingularityRack{} }},
+		{"pending_task_ids.json", func() interface{} { return &[]SingularityPendingTaskID{} }},
+		{"request_history.json", func() interface{} { return &[]SingularityRequestHistory{} }},
+		{"task_webhook.json", func() interface{} { return &SingularityTaskWebhook{} }},
+		{"run_now_request.json", func() interface{} { return &SingularityRunNowRequest{} }},
+	}
+	for _, tt := range tests {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := tt.model()
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Errorf("Unmarshal(%s): unexpected error %v", tt.file, err)
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Errorf("Marshal(%s): unexpected error %v", tt.file, err)
+			continue
+		}
+
+		var expected, got interface{}
+		if err := json.Unmarshal(data, &expected); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		for _, diff := range lossless("", expected, got) {
+			t.Errorf("Marshal(%s): %s", tt.file, diff)
+		}
+	}
+}
+
+func TestModelsJSONNames(t *testing.T) {
+	placement := SlavePlacementSeparate
+	tests := []struct {
+		value    interface{}
+		expected []string
+	}{
+		{
+			SingularityRequest{
+				HideEvenNumberAcrossRacksHint:                   true,
+				TaskExecutionTimeLimitMillis:                    1,
+				WaitAtLeastMillisAfterTaskFinishesForReschedule: 1,
+				TaskPriorityLevel:                               0.5,
+				RackAffinity:                                    []string{"r1"},
+				ScheduleTimeZone:                                "UTC",
+				AllowBounceToSameHost:                           true,
+				SlavePlacement:                                  &placement,
+			},
+			[]string{"hideEvenNumberAcrossRacksHint", "taskExecutionTimeLimitMillis",
+				"waitAtLeastMillisAfterTaskFinishesForReschedule", "taskPriorityLevel", "rackAffinity",
+				"scheduleTimeZone", "allowBounceToSameHost", "slavePlacement"},
+		},
+		{
+			SingularityDeployRequest{UnpauseOnSuccessfulDeploy: true, Message: "m"},
+			[]string{"unpauseOnSuccessfulDeploy", "message", "deploy"},
+		},
+		{
+			SingularityDeployWithLB{LoadBalancerAdditionalRoutes: []string{"/a"}},
+			[]string{"loadBalancerAdditionalRoutes"},
+		},
+		{
+			SingularityRunNowRequest{RunAt: 1},
+			[]string{"runAt"},
+		},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			t.Fatal(err)
+		}
+		for _, k := range tt.expected {
+			if _, ok := fields[k]; !ok {
+				t.Errorf("Marshal(%T): expected field %s, got %s", tt.value, k, b)
+			}
+		}
+	}
+}
+
+// TestModelsMatchAPI checks that the models of this package have the same
+// JSON fields, of the same JSON kinds, as the models generated from
+// api/swagger.json, so that the two can't drift apart. A field added to one
+// must be added to the other.
+func TestModelsMatchAPI(t *testing.T) {
+	tests := []struct {
+		generated, model interface{}
+	}{
+		{api.ExecutorData{}, ExecutorData{}},
+		{api.EmbeddedArtifact{}, EmbeddedArtifact{}},
+		{api.ExternalArtifact{}, ExternalArtifact{}},
+		{api.LoadBalancerRequestID{}, SingularityLoadBalancerRequestID{}},
+		{api.Resources{}, SingularityDeployResources{}},
+		{api.S3Artifact{}, S3Artifact{}},
+		{api.S3ArtifactSignature{}, S3ArtifactSignature{}},
+		{api.SingularityAppcImage{}, SingularityAppcImage{}},
+		{api.SingularityBounceRequest{}, SingularityBounceRequest{}},
+		{api.SingularityContainerInfo{}, ContainerInfo{}},
+		{api.SingularityDeleteRequestRequest{}, SingularityDeleteRequest{}},
+		{api.SingularityDeploy{}, SingularityDeploy{}},
+		{api.SingularityDeployFailure{}, SingularityDeployFailure{}},
+		{api.SingularityDeployHistory{}, SingularityDeployHistory{}},
+		{api.SingularityDeployMarker{}, SingularityDeployMarker{}},
+		{api.SingularityDeployProgress{}, SingularityDeployProgress{}},
+		{api.SingularityDeployRequest{}, SingularityDeployRequest{}},
+		{api.SingularityDeployResult{}, SingularityDeployResult{}},
+		{api.SingularityDockerImage{}, SingularityDockerImage{}},
+		{api.SingularityDockerInfo{}, DockerInfo{}},
+		{api.SingularityDockerParameter{}, SingularityDockerParameter{}},
+		{api.SingularityDockerPortMapping{}, DockerPortMapping{}},
+		{api.SingularityExpiringBounce{}, SingularityExpiringBounce{}},
+		{api.SingularityExpiringPause{}, SingularityExpiringPause{}},
+		{api.SingularityExpiringScale{}, SingularityExpiringScale{}},
+		{api.SingularityExpiringSkipHealthchecks{}, SingularityExpiringSkipHealthchecks{}},
+		{api.SingularityKillTaskRequest{}, SingularityKillTaskRequest{}},
+		{api.SingularityLoadBalancerUpdate{}, SingularityLoadBalancerUpdate{}},
+		{api.SingularityMesosArtifact{}, SingularityMesosArtifact{}},
+		{api.SingularityMesosImage{}, SingularityMesosImage{}},
+		{api.SingularityMesosInfo{}, SingularityMesosInfo{}},
+		{api.SingularityMesosTaskLabel{}, SingularityMesosTaskLabel{}},
+		{api.SingularityPauseRequest{}, SingularityPauseRequest{}},
+		{api.SingularityPendingDeploy{}, SingularityPendingDeploy{}},
+		{api.SingularityRequest{}, SingularityRequest{}},
+		{api.SingularityRequestDeployState{}, RequestDeployState{}},
+		{api.SingularityRequestHistory{}, SingularityRequestHistory{}},
+		{api.SingularityRequestParent{}, SingularityRequestParent{}},
+		{api.SingularityRunNowRequest{}, SingularityRunNowRequest{}},
+		{api.SingularityScaleRequest{}, SingularityScaleRequest{}},
+		{api.SingularityTaskCleanup{}, SingularityTaskCleanup{}},
+		{api.SingularityTaskID{}, SingularityTaskID{}},
+		{api.SingularityTaskIDHistory{}, SingularityTaskIDHistory{}},
+		{api.SingularityUnpauseRequest{}, SingularityUnpauseRequest{}},
+		{api.SingularityVolume{}, SingularityVolume{}},
+	}
+	for _, tt := range tests {
+		generated, model := jsonFields(reflect.TypeOf(tt.generated)), jsonFields(reflect.TypeOf(tt.model))
+		for name, kind := range generated {
+			switch k, ok := model[name]; {
+			case !ok:
+				t.Errorf("%T: expected field %s of %T", tt.model, name, tt.generated)
+			case k != kind && k != "any" && kind != "any":
+				t.Errorf("%T: expected field %s to be a JSON %s as in %T, got %s", tt.model, name, kind, tt.generated, k)
+			}
+		}
+		for name := range model {
+			if _, ok := generated[name]; !ok {
+				t.Errorf("%T: field %s is missing from %T; add it to api/swagger.json", tt.model, name, tt.generated)
+			}
+		}
+	}
+}
+
+// jsonFields returns the JSON kind of each field of the struct type t, by the
+// name encoding/json gives it.
+func jsonFields(t reflect.Type) map[string]string {
+	fields := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Anonymous && name == "" {
+			for k, v := range jsonFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = jsonKind(f.Type)
+	}
+	return fields
+}
+
+func jsonKind(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string.
+			return "string"
+		}
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return "any"
+}
+
+// lossless returns a description of every value in expected which is missing
+// from got or differs. A missing value is allowed when it is the zero value,
+// as omitempty leaves those out and they decode the same.
+func lossless(path string, expected, got interface{}) []string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an object, got %v", path, got)}
+		}
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var diffs []string
+		for _, k := range keys {
+			v, ok := g[k]
+			if !ok {
+				if !zero(e[k]) {
+					diffs = append(diffs, fmt.Sprintf("%s.%s: dropped %v", path, k, e[k]))
+				}
+				continue
+			}
+			diffs = append(diffs, lossless(path+"."+k, e[k], v)...)
+		}
+		return diffs
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok && len(e) == 0 && got == nil {
+			return nil
+		}
+		if !ok || len(g) != len(e) {
+			return []string{fmt.Sprintf("%s: expected %v, got %v", path, e, got)}
+		}
+		var diffs []string
+		for i := range e {
+			diffs = append(diffs, lossless(fmt.Sprintf("%s[%d]", path, i), e[i], g[i])...)
+		}
+		return diffs
+	}
+	if !reflect.DeepEqual(expected, got) && !(zero(expected) && zero(got)) {
+		return []string{fmt.Sprintf("%s: expected %v, got %v", path, expected, got)}
+	}
+	return nil
+}
+
+// zero reports whether v, decoded from JSON, is a zero value.
+func zero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		for _, f := range v {
+			if !zero(f) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package singularity
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-resty/resty"
+)
+
+// GetActiveTasks retrieves all active Singularity tasks.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apitasksactive
+func (c *Client) GetActiveTasks() (*resty.Response, []SingularityTask, error) {
+	res, err := c.request().
+		Get("/api/tasks/active")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity active tasks error: %v", err)
+	}
+
+	var body []SingularityTask
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity active tasks error: %v", err)
+	}
+	return res, body, nil
+}
+
+// GetScheduledTaskIDs retrieves the IDs of the tasks waiting to be launched.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apitasksscheduledids
+func (c *Client) GetScheduledTaskIDs() (*resty.Response, []SingularityPendingTaskID, error) {
+	res, err := c.request().
+		Get("/api/tasks/scheduled/ids")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity scheduled tasks error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity scheduled tasks error: %v", string(res.Body()))
+	}
+
+	var body []SingularityPendingTaskID
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity scheduled tasks error: %v", err)
+	}
+	return res, body, nil
+}
+
+// GetCleaningTasks retrieves the tasks which are being killed or replaced.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apitaskscleaning
+func (c *Client) GetCleaningTasks() (*resty.Response, []SingularityTaskCleanup, error) {
+	res, err := c.request().
+		Get("/api/tasks/cleaning")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity cleaning tasks error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity cleaning tasks error: %v", string(res.Body()))
+	}
+
+	var body []SingularityTaskCleanup
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity cleaning tasks error: %v", err)
+	}
+	return res, body, nil
+}
+
+// GetActiveTasksByRequestID accepts a request id string and retrieves the
+// active tasks of that Singularity request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apihistoryrequestrequestidtasksactive
+func (c *Client) GetActiveTasksByRequestID(id string) (*resty.Response, []SingularityTaskIDHistory, error) {
+	res, err := c.request().
+		Get("/api/history/request/" + id + "/tasks/active")
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Get Singularity request tasks error: %v", err)
+	}
+
+	var body []SingularityTaskIDHistory
+	err = c.Rest.JSONUnmarshal(res.Body(), &body)
+	if err != nil {
+		return &resty.Response{}, nil, fmt.Errorf("Parse Singularity request tasks error: %v", err)
+	}
+	return res, body, nil
+}
+
+// KillHTTPTask contains a task id and a body parameter required to kill a
+// Singularity task.
+type KillHTTPTask struct {
+	id string
+	SingularityKillTaskRequest
+}
+
+// NewKillTask accepts a task id string, a message and a bool to kill the
+// task immediately rather than waiting for it to shut down gracefully.
+func NewKillTask(id, m string, override bool) KillHTTPTask {
+	return KillHTTPTask{
+		id: id,
+		SingularityKillTaskRequest: SingularityKillTaskRequest{
+			Override: override,
+			Message:  m,
+		},
+	}
+}
+
+// KillTask accepts a *Client and KillHTTPTask and kills a running Singularity
+// task. The SingularityTaskCleanup for the task is returned in Task.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#delete-apitaskstasktaskid
+func KillTask(c *Client, t KillHTTPTask) (HTTPResponse, error) {
+	res, err := c.request().
+		SetHeader("Content-Type", "application/json").
+		SetBody(t.SingularityKillTaskRequest).
+		Delete("/api/tasks/task/" + t.id)
+	if err != nil {
+		return HTTPResponse{}, fmt.Errorf("Kill Singularity task error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return HTTPResponse{}, fmt.Errorf("Kill Singularity task error: %v", string(res.Body()))
+	}
+
+	var data SingularityTaskCleanup
+	err = c.Rest.JSONUnmarshal(res.Body(), &data)
+	if err != nil {
+		return HTTPResponse{}, fmt.Errorf("Parse Singularity task cleanup error: %v", err)
+	}
+	return HTTPResponse{
+		RestyResponse: res,
+		Task:          data,
+	}, nil
+}
+
+// ReadSandboxFile accepts a task id, a path relative to the task sandbox,
+// an offset and a length, and returns that chunk of the file. A negative
+// offset returns an empty chunk whose Offset is the current file size.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apisandboxtaskidread
+func (c *Client) ReadSandboxFile(taskID, path string, offset, length int64) (MesosFileChunk, error) {
+	params := map[string]string{
+		"path": path,
+	}
+	if offset >= 0 {
+		params["offset"] = strconv.FormatInt(offset, 10)
+		params["length"] = strconv.FormatInt(length, 10)
+	}
+	res, err := c.request().
+		SetQueryParams(params).
+		Get("/api/sandbox/" + taskID + "/read")
+	if err != nil {
+		return MesosFileChunk{}, fmt.Errorf("Read Singularity sandbox file error: %v", err)
+	}
+	if res.StatusCode() < 200 || res.StatusCode() > 299 {
+		return MesosFileChunk{}, fmt.Errorf("Read Singularity sandbox file error: %v", string(res.Body()))
+	}
+
+	var data MesosFileChunk
+	err = c.Rest.JSONUnmarshal(res.Body(), &data)
+	if err != nil {
+		return MesosFileChunk{}, fmt.Errorf("Parse Singularity sandbox file error: %v", err)
+	}
+	return data, nil
+}
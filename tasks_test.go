@@ -0,0 +1,131 @@
+package singularity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGetActiveTasks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tasks/active":
+			w.Write([]byte(`[{"taskId":{"id":"r1-d1-1-1","requestId":"r1","deployId":"d1","host":"h1","instanceNo":1}}]`))
+		case "/api/history/request/r1/tasks/active":
+			w.Write([]byte(`[{"taskId":{"id":"r1-d1-1-1","requestId":"r1"},"lastTaskState":"TASK_RUNNING","updatedAt":1}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	_, tasks, err := c.GetActiveTasks()
+	if err != nil {
+		t.Fatalf("GetActiveTasks: unexpected error %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].TaskID.Host != "h1" || tasks[0].TaskID.InstanceNo != 1 {
+		t.Errorf("GetActiveTasks: got %+v", tasks)
+	}
+
+	_, history, err := c.GetActiveTasksByRequestID("r1")
+	if err != nil {
+		t.Fatalf("GetActiveTasksByRequestID: unexpected error %v", err)
+	}
+	if len(history) != 1 || history[0].LastTaskState != "TASK_RUNNING" {
+		t.Errorf("GetActiveTasksByRequestID: got %+v", history)
+	}
+}
+
+func TestKillTask(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		if r.URL.Path != "/api/tasks/task/r1-d1-1-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"taskId":{"id":"r1-d1-1-1"},"cleanupType":"USER_REQUESTED","user":"me"}`))
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	res, err := KillTask(c, NewKillTask("r1-d1-1-1", "stuck", true))
+	if err != nil {
+		t.Fatalf("KillTask: unexpected error %v", err)
+	}
+	if gotPath != "DELETE /api/tasks/task/r1-d1-1-1" {
+		t.Errorf("KillTask: expected %s, got %s", "DELETE /api/tasks/task/r1-d1-1-1", gotPath)
+	}
+	cleanup, ok := res.Task.(SingularityTaskCleanup)
+	if !ok || cleanup.CleanupType != "USER_REQUESTED" {
+		t.Errorf("KillTask: got %+v", res.Task)
+	}
+
+	if _, err := KillTask(c, NewKillTask("missing", "", false)); err == nil {
+		t.Errorf("KillTask: expected error for missing task")
+	}
+}
+
+func TestClientReadSandboxFile(t *testing.T) {
+	const content = "hello world"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/sandbox/t1/read" || q.Get("path") != "stdout" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if q.Get("offset") == "" {
+			w.Write([]byte(`{"data":"","offset":11}`))
+			return
+		}
+		if q.Get("offset") != "6" || q.Get("length") != "100" {
+			t.Errorf("ReadSandboxFile: unexpected query %v", q)
+		}
+		w.Write([]byte(`{"data":"world","offset":6,"nextOffset":11}`))
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	chunk, err := c.ReadSandboxFile("t1", "stdout", -1, 0)
+	if err != nil {
+		t.Fatalf("ReadSandboxFile: unexpected error %v", err)
+	}
+	if chunk.Offset != int64(len(content)) {
+		t.Errorf("ReadSandboxFile: expected offset %d, got %d", len(content), chunk.Offset)
+	}
+
+	chunk, err = c.ReadSandboxFile("t1", "stdout", 6, 100)
+	if err != nil {
+		t.Fatalf("ReadSandboxFile: unexpected error %v", err)
+	}
+	if chunk.Data != "world" {
+		t.Errorf("ReadSandboxFile: expected %s, got %s", "world", chunk.Data)
+	}
+
+	if _, err := c.ReadSandboxFile("t1", "stderr", 0, 100); err == nil {
+		t.Errorf("ReadSandboxFile: expected error for missing file")
+	}
+}
+
+func TestClientGetPendingAndCleaningTasks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tasks/scheduled/ids":
+			w.Write([]byte(`[{"id":"r1-d1-1","requestId":"r1","pendingType":"NEW_DEPLOY"}]`))
+		case "/api/tasks/cleaning":
+			w.Write([]byte(`[{"taskId":{"id":"t1"},"cleanupType":"BOUNCING"}]`))
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(ts)
+
+	_, pending, err := c.GetScheduledTaskIDs()
+	if err != nil || len(pending) != 1 || pending[0].RequestID != "r1" || pending[0].PendingType != "NEW_DEPLOY" {
+		t.Errorf("GetScheduledTaskIDs: got %+v, %v", pending, err)
+	}
+	_, cleaning, err := c.GetCleaningTasks()
+	if err != nil || len(cleaning) != 1 || cleaning[0].TaskID.ID != "t1" || cleaning[0].CleanupType != "BOUNCING" {
+		t.Errorf("GetCleaningTasks: got %+v, %v", cleaning, err)
+	}
+}
@@ -0,0 +1,140 @@
+// Package tracing records the HTTP calls of a singularity.Client as spans,
+// named and labelled after the OpenTelemetry semantic conventions for HTTP
+// clients.
+//
+// It does not depend on OpenTelemetry itself. Tracer and Span are the parts
+// of its API that are used, so that a go.opentelemetry.io/otel/trace.Tracer
+// fits them with a small wrapper:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span}
+//	}
+//
+// where otelSpan converts Attributes to attribute.KeyValues and calls
+// span.SetStatus(codes.Error, description) from SetError.
+//
+// A Propagator sends the trace context of each attempt to Singularity, such
+// as in a W3C traceparent header. An otel propagation.TextMapPropagator fits
+// it with:
+//
+//	type otelPropagator struct{ propagation.TextMapPropagator }
+//
+//	func (p otelPropagator) Inject(ctx context.Context, h http.Header) {
+//		p.TextMapPropagator.Inject(ctx, propagation.HeaderCarrier(h))
+//	}
+//
+// Spans are children of the span in the context of the Client, which is set
+// with Client.WithContext.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+// Tracer starts spans.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single HTTP attempt.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	// SetError marks the span as failed.
+	SetError(description string)
+	End()
+}
+
+// Propagator writes the trace context of ctx into the headers of a request.
+type Propagator interface {
+	Inject(ctx context.Context, h http.Header)
+}
+
+// Attribute is a key and a string, int or bool value.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attribute keys, from the OpenTelemetry semantic conventions where there
+// is one.
+const (
+	MethodKey      = "http.request.method"
+	TemplateKey    = "url.template"
+	StatusCodeKey  = "http.response.status_code"
+	ResendCountKey = "http.request.resend_count"
+	ErrorTypeKey   = "error.type"
+	OperationKey   = "singularity.operation"
+)
+
+type spanKey struct{}
+
+// Hook starts a span for every HTTP attempt of a Client.
+type Hook struct {
+	tracer     Tracer
+	propagator Propagator
+}
+
+var _ singularity.Hook = (*Hook)(nil)
+
+// NewHook returns a Hook which starts spans with t. Add it to a Client with
+// AddHook.
+func NewHook(t Tracer) *Hook {
+	return &Hook{tracer: t}
+}
+
+// SetPropagator sets the Propagator which adds the trace context of each
+// attempt to its request.
+func (h *Hook) SetPropagator(p Propagator) *Hook {
+	h.propagator = p
+	return h
+}
+
+// Before starts a span named after the method and endpoint template, such as
+// "GET /api/requests/request/{requestId}", and adds its trace context to r
+// when a Propagator is set.
+func (h *Hook) Before(ctx context.Context, op singularity.Operation, r *http.Request) context.Context {
+	ctx, span := h.tracer.Start(ctx, op.Method+" "+op.Endpoint)
+	attrs := []Attribute{
+		{MethodKey, op.Method},
+		{TemplateKey, op.Endpoint},
+		{OperationKey, op.Name},
+	}
+	if op.Retry > 0 {
+		attrs = append(attrs, Attribute{ResendCountKey, op.Retry})
+	}
+	span.SetAttributes(attrs...)
+	if h.propagator != nil && r != nil {
+		h.propagator.Inject(ctx, r.Header)
+	}
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// After records the status or error of the attempt and ends its span.
+func (h *Hook) After(ctx context.Context, call singularity.Call) {
+	span, ok := ctx.Value(spanKey{}).(Span)
+	if !ok {
+		return
+	}
+	if call.StatusCode != 0 {
+		span.SetAttributes(Attribute{StatusCodeKey, call.StatusCode})
+	}
+	switch {
+	case call.Err != nil:
+		span.RecordError(call.Err)
+		span.SetAttributes(Attribute{ErrorTypeKey, fmt.Sprintf("%T", call.Err)})
+		span.SetError(call.Err.Error())
+	case call.StatusCode >= 400:
+		span.SetAttributes(Attribute{ErrorTypeKey, strconv.Itoa(call.StatusCode)})
+		span.SetError("")
+	}
+	span.End()
+}
@@ -0,0 +1,115 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	singularity "github.com/lenfree/go-mesos-singularity"
+)
+
+type fakeSpan struct {
+	name   string
+	parent *fakeSpan
+	attrs  map[string]interface{}
+	errs   []error
+	failed bool
+	ended  bool
+}
+
+func (s *fakeSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+func (s *fakeSpan) RecordError(err error)       { s.errs = append(s.errs, err) }
+func (s *fakeSpan) SetError(description string) { s.failed = true }
+func (s *fakeSpan) End()                        { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+type fakeSpanKey struct{}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &fakeSpan{name: name, attrs: map[string]interface{}{}}
+	s.parent, _ = ctx.Value(fakeSpanKey{}).(*fakeSpan)
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, fakeSpanKey{}, s), s
+}
+
+// fakePropagator sends the name of the current span.
+type fakePropagator struct{}
+
+func (fakePropagator) Inject(ctx context.Context, h http.Header) {
+	if s, ok := ctx.Value(fakeSpanKey{}).(*fakeSpan); ok {
+		h.Set("traceparent", s.name)
+	}
+}
+
+func TestHook(t *testing.T) {
+	tracer := &fakeTracer{}
+	h := NewHook(tracer)
+	op := singularity.Operation{Name: "ScaleRequest", Method: "PUT", Endpoint: "/api/requests/request/{requestId}/scale"}
+
+	ctx := h.Before(context.Background(), op, nil)
+	h.After(ctx, singularity.Call{Operation: op, StatusCode: 200})
+
+	op.Retry = 1
+	ctx = h.Before(context.Background(), op, nil)
+	h.After(ctx, singularity.Call{Operation: op, StatusCode: 503})
+
+	ctx = h.Before(context.Background(), op, nil)
+	h.After(ctx, singularity.Call{Operation: op, Err: errors.New("connection refused")})
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("Hook: expected 3 spans, got %d", len(tracer.spans))
+	}
+	ok := tracer.spans[0]
+	if ok.name != "PUT /api/requests/request/{requestId}/scale" || !ok.ended || ok.failed {
+		t.Errorf("Hook: expected an ended successful span, got %+v", ok)
+	}
+	expected := map[string]interface{}{
+		MethodKey:     "PUT",
+		TemplateKey:   "/api/requests/request/{requestId}/scale",
+		OperationKey:  "ScaleRequest",
+		StatusCodeKey: 200,
+	}
+	if !reflect.DeepEqual(ok.attrs, expected) {
+		t.Errorf("Hook: expected attributes %v, got %v", expected, ok.attrs)
+	}
+	if s := tracer.spans[1]; !s.failed || s.attrs[ErrorTypeKey] != "503" || s.attrs[ResendCountKey] != 1 {
+		t.Errorf("Hook: expected a failed retried span, got %+v", s)
+	}
+	if s := tracer.spans[2]; !s.failed || len(s.errs) != 1 || s.attrs[StatusCodeKey] != nil || !s.ended {
+		t.Errorf("Hook: expected a span with a recorded error, got %+v", s)
+	}
+}
+
+func TestHookPropagator(t *testing.T) {
+	var header string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("traceparent")
+		w.Write([]byte(`{"request":{"id":"r1"}}`))
+	}))
+	defer ts.Close()
+
+	tracer := &fakeTracer{}
+	c := singularity.NewClient(singularity.NewConfig().SetHost(strings.TrimPrefix(ts.URL, "http://")).Build()).
+		AddHook(NewHook(tracer).SetPropagator(fakePropagator{}))
+	ctx, parent := tracer.Start(context.Background(), "deploy")
+	if _, err := c.WithContext(ctx).GetRequestByID("r1"); err != nil {
+		t.Fatalf("GetRequestByID: unexpected error %v", err)
+	}
+	if len(tracer.spans) != 2 || tracer.spans[1].parent != parent {
+		t.Fatalf("Hook: expected a child span of %v, got %+v", parent, tracer.spans)
+	}
+	if header != "GET /api/requests/request/{requestId}" {
+		t.Errorf("SetPropagator: expected the attempt's trace context to be sent, got %q", header)
+	}
+}
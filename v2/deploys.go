@@ -0,0 +1,47 @@
+package singularity
+
+// CreateDeploy starts a deploy, and returns its request with the pending
+// deploy. The deploy is validated first.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apideploys
+func (c *Client) CreateDeploy(d SingularityDeployRequest) (*SingularityRequestParent, *Response, error) {
+	if err := d.SingularityDeploy.Validate(); err != nil {
+		return nil, nil, err
+	}
+	out := &SingularityRequestParent{}
+	res, err := c.do("POST", "/api/deploys", nil, d, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// CancelDeploy cancels a pending deploy.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#delete-apideploysdeploydeployidrequestrequestid
+func (c *Client) CancelDeploy(requestID, deployID string) (*SingularityRequestParent, *Response, error) {
+	out := &SingularityRequestParent{}
+	res, err := c.do("DELETE", "/api/deploys/deploy/"+deployID+"/request/"+requestID, nil, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// GetDeployHistory retrieves a deploy with its result, which is nil while
+// the deploy is pending.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apihistoryrequestrequestiddeploydeployid
+func (c *Client) GetDeployHistory(requestID, deployID string) (*SingularityDeployHistory, *Response, error) {
+	out := &SingularityDeployHistory{}
+	res, err := c.do("GET", "/api/history/request/"+requestID+"/deploy/"+deployID, nil, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// GetPendingDeploys retrieves the deploys which have not finished.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apideployspending
+func (c *Client) GetPendingDeploys() ([]SingularityPendingDeploy, *Response, error) {
+	var out []SingularityPendingDeploy
+	res, err := c.do("GET", "/api/deploys/pending", nil, nil, &out)
+	return out, res, err
+}
@@ -0,0 +1,29 @@
+package singularity
+
+import (
+	"testing"
+
+	"github.com/lenfree/go-mesos-singularity/singularitytest"
+)
+
+func TestClientDeploys(t *testing.T) {
+	s := singularitytest.NewServer()
+	defer s.Close()
+	s.AddRequest(SingularityRequest{ID: "web", RequestType: "SERVICE", Instances: 1})
+	c := NewClient(NewConfig().SetHost(s.Host()))
+
+	d := SingularityDeployRequest{SingularityDeploy: SingularityDeploy{ID: "d1", RequestID: "web", Command: "./run.sh"}}
+	if _, _, err := c.CreateDeploy(d); err != nil {
+		t.Fatalf("CreateDeploy: unexpected error %v", err)
+	}
+	h, _, err := c.GetDeployHistory("web", "d1")
+	if err != nil || h.DeployResult == nil || !h.DeployResult.Succeeded() {
+		t.Errorf("GetDeployHistory: expected a succeeded deploy, got %+v, %v", h, err)
+	}
+	if _, _, err := c.CreateDeploy(SingularityDeployRequest{}); err == nil {
+		t.Errorf("CreateDeploy: expected a validation error")
+	}
+	if pending, _, err := c.GetPendingDeploys(); err != nil || len(pending) != 0 {
+		t.Errorf("GetPendingDeploys: expected none, got %+v, %v", pending, err)
+	}
+}
@@ -0,0 +1,28 @@
+package singularity
+
+import (
+	v1 "github.com/lenfree/go-mesos-singularity"
+)
+
+// Models shared with version 1.
+type (
+	Request                  = v1.Request
+	SingularityRequest       = v1.SingularityRequest
+	SingularityRequestParent = v1.SingularityRequestParent
+	SingularityDeploy        = v1.SingularityDeploy
+	SingularityDeployRequest = v1.SingularityDeployRequest
+	SingularityDeployHistory = v1.SingularityDeployHistory
+	SingularityPendingDeploy = v1.SingularityPendingDeploy
+	SingularityTask          = v1.SingularityTask
+	SingularityTaskCleanup   = v1.SingularityTaskCleanup
+	SingularitySlave         = v1.SingularitySlave
+	SingularityWebhook       = v1.SingularityWebhook
+
+	SingularityScaleRequest    = v1.SingularityScaleRequest
+	SingularityDeleteRequest   = v1.SingularityDeleteRequest
+	SingularityPauseRequest    = v1.SingularityPauseRequest
+	SingularityUnpauseRequest  = v1.SingularityUnpauseRequest
+	SingularityBounceRequest   = v1.SingularityBounceRequest
+	SingularityRunNowRequest   = v1.SingularityRunNowRequest
+	SingularityKillTaskRequest = v1.SingularityKillTaskRequest
+)
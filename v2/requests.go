@@ -0,0 +1,85 @@
+package singularity
+
+// GetRequests retrieves every request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apirequests
+func (c *Client) GetRequests() ([]Request, *Response, error) {
+	var out []Request
+	res, err := c.do("GET", "/api/requests", nil, nil, &out)
+	return out, res, err
+}
+
+// GetRequest retrieves a request with the state of its deploys.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apirequestsrequestrequestid
+func (c *Client) GetRequest(id string) (*SingularityRequestParent, *Response, error) {
+	out := &SingularityRequestParent{}
+	res, err := c.do("GET", "/api/requests/request/"+id, nil, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// CreateRequest creates a request, or updates it if it exists. The request
+// is validated first.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequests
+func (c *Client) CreateRequest(r SingularityRequest) (*SingularityRequestParent, *Response, error) {
+	if err := r.Validate(); err != nil {
+		return nil, nil, err
+	}
+	out := &SingularityRequestParent{}
+	res, err := c.do("POST", "/api/requests", nil, r, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// DeleteRequest deletes a request and returns it as it was.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#delete-apirequestsrequestrequestid
+func (c *Client) DeleteRequest(id string, r SingularityDeleteRequest) (*SingularityRequest, *Response, error) {
+	out := &SingularityRequest{}
+	res, err := c.do("DELETE", "/api/requests/request/"+id, nil, r, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// ScaleRequest changes the number of instances of a request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#put-apirequestsrequestrequestidscale
+func (c *Client) ScaleRequest(id string, r SingularityScaleRequest) (*SingularityRequestParent, *Response, error) {
+	return c.requestAction("PUT", id, "scale", r)
+}
+
+// PauseRequest pauses a request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidpause
+func (c *Client) PauseRequest(id string, r SingularityPauseRequest) (*SingularityRequestParent, *Response, error) {
+	return c.requestAction("POST", id, "pause", r)
+}
+
+// UnpauseRequest unpauses a request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidunpause
+func (c *Client) UnpauseRequest(id string, r SingularityUnpauseRequest) (*SingularityRequestParent, *Response, error) {
+	return c.requestAction("POST", id, "unpause", r)
+}
+
+// BounceRequest restarts the tasks of a request.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidbounce
+func (c *Client) BounceRequest(id string, r SingularityBounceRequest) (*SingularityRequestParent, *Response, error) {
+	return c.requestAction("POST", id, "bounce", r)
+}
+
+// RunRequest runs a scheduled or on-demand request now.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#post-apirequestsrequestrequestidrun
+func (c *Client) RunRequest(id string, r SingularityRunNowRequest) (*SingularityRequestParent, *Response, error) {
+	return c.requestAction("POST", id, "run", r)
+}
+
+func (c *Client) requestAction(method, id, action string, body interface{}) (*SingularityRequestParent, *Response, error) {
+	out := &SingularityRequestParent{}
+	res, err := c.do(method, "/api/requests/request/"+id+"/"+action, nil, body, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
@@ -0,0 +1,56 @@
+package singularity
+
+import (
+	"testing"
+
+	v1 "github.com/lenfree/go-mesos-singularity"
+	"github.com/lenfree/go-mesos-singularity/singularitytest"
+)
+
+func TestClientRequests(t *testing.T) {
+	s := singularitytest.NewServer()
+	defer s.Close()
+	c := NewClient(NewConfig().SetHost(s.Host()))
+
+	created, res, err := c.CreateRequest(SingularityRequest{ID: "web", RequestType: "SERVICE", Instances: 1})
+	if err != nil {
+		t.Fatalf("CreateRequest: unexpected error %v", err)
+	}
+	if created.SingularityRequest.ID != "web" || res.StatusCode != 200 || len(res.Body) == 0 {
+		t.Errorf("CreateRequest: got %+v, %+v", created, res)
+	}
+	if _, _, err := c.CreateRequest(SingularityRequest{ID: "bad/id"}); err == nil {
+		t.Errorf("CreateRequest: expected a validation error")
+	}
+
+	scaled, _, err := c.ScaleRequest("web", SingularityScaleRequest{Instances: 3})
+	if err != nil || scaled.SingularityRequest.Instances != 3 {
+		t.Errorf("ScaleRequest: expected 3 instances, got %+v, %v", scaled, err)
+	}
+	paused, _, err := c.PauseRequest("web", SingularityPauseRequest{Message: "maintenance"})
+	if err != nil || paused.State != "PAUSED" {
+		t.Errorf("PauseRequest: expected PAUSED, got %+v, %v", paused, err)
+	}
+	reqs, _, err := c.GetRequests()
+	if err != nil || len(reqs) != 1 || reqs[0].ID != "web" {
+		t.Errorf("GetRequests: got %+v, %v", reqs, err)
+	}
+
+	deleted, _, err := c.DeleteRequest("web", SingularityDeleteRequest{Message: "gone"})
+	if err != nil || deleted.ID != "web" {
+		t.Errorf("DeleteRequest: expected web, got %+v, %v", deleted, err)
+	}
+
+	missing, res, err := c.GetRequest("web")
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != 404 || missing != nil || res.StatusCode != 404 {
+		t.Errorf("GetRequest(deleted): expected a 404 APIError, got %+v, %+v, %v", missing, res, err)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	c := v1.NewClient(v1.NewConfig().SetHost("example.com").Build())
+	if Wrap(c).V1() != c {
+		t.Errorf("Wrap: expected V1 to return the wrapped client")
+	}
+}
@@ -0,0 +1,78 @@
+// Package singularity is version 2 of the Singularity client. Every call
+// returns the typed result of its endpoint, such as a
+// *SingularityRequestParent, together with a *Response describing the HTTP
+// exchange, in place of version 1's HTTPResponse.
+//
+// It shares its models, configuration and hooks with version 1, which stays
+// at github.com/lenfree/go-mesos-singularity; the models are aliased here so
+// that most programs only import this package.
+package singularity
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	v1 "github.com/lenfree/go-mesos-singularity"
+)
+
+// Client calls the Singularity API.
+type Client struct {
+	v1 *v1.Client
+}
+
+// NewConfig returns a builder for the host, port and retries of a Client.
+func NewConfig() v1.ConfigBuilder {
+	return v1.NewConfig()
+}
+
+// NewClient returns a Client for the configuration built by b.
+func NewClient(b v1.ConfigBuilder) *Client {
+	return &Client{v1: v1.NewClient(b.Build())}
+}
+
+// Wrap returns a Client which calls Singularity through an existing
+// version 1 client, sharing its transport and hooks.
+func Wrap(c *v1.Client) *Client {
+	return &Client{v1: c}
+}
+
+// WithContext returns a copy of c whose calls are made with ctx, as
+// version 1's Client.WithContext.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return &Client{v1: c.v1.WithContext(ctx)}
+}
+
+// V1 returns the version 1 client, for calls this package doesn't have.
+func (c *Client) V1() *v1.Client {
+	return c.v1
+}
+
+// Response describes the HTTP exchange of a call.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	// Body is the raw response body, which has already been decoded into
+	// the result of the call.
+	Body     []byte
+	Duration time.Duration
+}
+
+// APIError is returned when Singularity answers with a status outside 2xx.
+// It is the same type as the APIError of version 1.
+type APIError = v1.APIError
+
+// do sends body, if not nil, with method to path, and decodes the response
+// into out, if not nil.
+func (c *Client) do(method, path string, query map[string]string, body, out interface{}) (*Response, error) {
+	res, err := c.v1.API().Do(method, path, query, body, out)
+	if res == nil || res.RawResponse == nil {
+		return nil, err
+	}
+	return &Response{
+		StatusCode: res.StatusCode(),
+		Header:     res.Header(),
+		Body:       res.Body(),
+		Duration:   res.Time(),
+	}, err
+}
@@ -0,0 +1,33 @@
+package singularity
+
+// GetActiveTasks retrieves every active task.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apitasksactive
+func (c *Client) GetActiveTasks() ([]SingularityTask, *Response, error) {
+	var out []SingularityTask
+	res, err := c.do("GET", "/api/tasks/active", nil, nil, &out)
+	return out, res, err
+}
+
+// KillTask kills a task, and returns the cleanup Singularity started for it.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#delete-apitaskstasktaskid
+func (c *Client) KillTask(taskID string, r SingularityKillTaskRequest) (*SingularityTaskCleanup, *Response, error) {
+	out := &SingularityTaskCleanup{}
+	res, err := c.do("DELETE", "/api/tasks/task/"+taskID, nil, r, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// GetSlaves retrieves the Mesos agents, only those in state if it is not
+// empty.
+// https://github.com/HubSpot/Singularity/blob/master/Docs/reference/api.md#get-apislaves
+func (c *Client) GetSlaves(state string) ([]SingularitySlave, *Response, error) {
+	var query map[string]string
+	if state != "" {
+		query = map[string]string{"state": state}
+	}
+	var out []SingularitySlave
+	res, err := c.do("GET", "/api/slaves", query, nil, &out)
+	return out, res, err
+}
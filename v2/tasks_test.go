@@ -0,0 +1,31 @@
+package singularity
+
+import (
+	"testing"
+
+	v1 "github.com/lenfree/go-mesos-singularity"
+	"github.com/lenfree/go-mesos-singularity/singularitytest"
+)
+
+func TestClientTasks(t *testing.T) {
+	s := singularitytest.NewServer()
+	defer s.Close()
+	s.AddTask(SingularityTask{TaskID: v1.SingularityTaskID{ID: "web-1", RequestID: "web"}})
+	s.AddSlave(SingularitySlave{ID: "a1", CurrentState: v1.SingularityMachineState{State: "ACTIVE"}})
+	c := NewClient(NewConfig().SetHost(s.Host()))
+
+	tasks, _, err := c.GetActiveTasks()
+	if err != nil || len(tasks) != 1 || tasks[0].TaskID.ID != "web-1" {
+		t.Errorf("GetActiveTasks: got %+v, %v", tasks, err)
+	}
+	cleanup, _, err := c.KillTask("web-1", SingularityKillTaskRequest{Message: "stuck"})
+	if err != nil || cleanup.TaskID.ID != "web-1" {
+		t.Errorf("KillTask: expected a cleanup of web-1, got %+v, %v", cleanup, err)
+	}
+	if _, _, err := c.KillTask("web-1", SingularityKillTaskRequest{}); err == nil {
+		t.Errorf("KillTask: expected an error for a task which is gone")
+	}
+	if slaves, _, err := c.GetSlaves("DEAD"); err != nil || len(slaves) != 0 {
+		t.Errorf("GetSlaves(DEAD): expected none, got %+v, %v", slaves, err)
+	}
+}
@@ -0,0 +1,315 @@
+package singularity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+// Limits Singularity places on IDs, with its default configuration.
+const (
+	maxRequestIDLength = 100
+	maxDeployIDLength  = 50
+	maxPort            = 65535
+)
+
+var (
+	requestIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	deployIDPattern  = regexp.MustCompile(`^[a-zA-Z0-9_.]+$`)
+)
+
+var (
+	validScheduleTypes   = []string{"CRON", "QUARTZ"}
+	validContainerTypes  = []string{"DOCKER", "MESOS"}
+	validMesosImageTypes = []string{"DOCKER", "APPC"}
+	validDockerNetworks  = []string{"BRIDGE", "HOST", "NONE"}
+	validVolumeModes     = []string{"RO", "RW"}
+	validPortTypes       = []string{"LITERAL", "FROM_OFFER"}
+	validProtocols       = []string{"tcp", "udp"}
+)
+
+// FieldError is a single invalid field. Field is the JSON path of the field,
+// such as containerInfo.docker.portMappings[0].hostPort.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError holds every invalid field found by Validate.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	s := make([]string, 0, len(e))
+	for _, f := range e {
+		s = append(s, f.Error())
+	}
+	return "invalid Singularity object: " + strings.Join(s, "; ")
+}
+
+func (e *ValidationError) add(field, format string, a ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+// err returns e as an error, or nil when there are no violations.
+func (e ValidationError) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func oneOf(s string, valid []string) bool {
+	for _, v := range valid {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *ValidationError) checkID(field, id string, max int, pattern *regexp.Regexp) {
+	switch {
+	case id == "":
+		e.add(field, "is required")
+	case len(id) > max:
+		e.add(field, "must be at most %d characters", max)
+	case !pattern.MatchString(id):
+		e.add(field, "must only contain characters matching %s", pattern)
+	}
+}
+
+// Validate checks a request against the rules Singularity applies when it is
+// created, and returns a ValidationError listing every violation.
+func (r *SingularityRequest) Validate() error {
+	var e ValidationError
+	e.checkID("id", r.ID, maxRequestIDLength, requestIDPattern)
+
+	if !r.RequestType.Valid() {
+		e.add("requestType", "must be one of %s", strings.Join(requestTypeEnum.values, ", "))
+	}
+	switch r.RequestType {
+	case "SCHEDULED":
+		if r.Schedule == "" && r.QuartzSchedule == "" {
+			e.add("schedule", "is required for SCHEDULED requests")
+		}
+		if r.Instances > 1 {
+			e.add("instances", "must be at most 1 for SCHEDULED requests")
+		}
+	case "SERVICE", "WORKER":
+		if r.Instances < 1 {
+			e.add("instances", "must be at least 1 for %s requests", r.RequestType)
+		}
+	case "ON_DEMAND", "RUN_ONCE":
+		if r.Instances > 1 {
+			e.add("instances", "must be at most 1 for %s requests", r.RequestType)
+		}
+	}
+	if r.RequestType != "SCHEDULED" {
+		if r.Schedule != "" {
+			e.add("schedule", "is only allowed for SCHEDULED requests")
+		}
+		if r.QuartzSchedule != "" {
+			e.add("quartzSchedule", "is only allowed for SCHEDULED requests")
+		}
+	}
+	if r.ScheduleType != "" && !oneOf(strings.ToUpper(r.ScheduleType), validScheduleTypes) {
+		e.add("scheduleType", "must be one of %s", strings.Join(validScheduleTypes, ", "))
+	}
+	if r.Schedule != "" {
+		if strings.ToUpper(r.ScheduleType) == "QUARTZ" {
+			if _, err := parseQuartz(r.Schedule); err != nil {
+				e.add("schedule", "invalid quartz schedule: %v", err)
+			}
+		} else if _, err := cron.Parse(r.Schedule); err != nil {
+			e.add("schedule", "invalid cron schedule: %v", err)
+		}
+	}
+	if r.QuartzSchedule != "" {
+		if _, err := parseQuartz(r.QuartzSchedule); err != nil {
+			e.add("quartzSchedule", "invalid quartz schedule: %v", err)
+		}
+	}
+	if r.ScheduleTimeZone != "" {
+		if _, err := time.LoadLocation(r.ScheduleTimeZone); err != nil {
+			e.add("scheduleTimeZone", "unknown time zone %q", r.ScheduleTimeZone)
+		}
+	}
+	if r.LoadBalanced && r.RequestType != "SERVICE" {
+		e.add("loadBalanced", "is only allowed for SERVICE requests")
+	}
+
+	if r.Instances < 0 {
+		e.add("instances", "must not be negative")
+	}
+	if r.NumRetriesOnFailure < 0 {
+		e.add("numRetriesOnFailure", "must not be negative")
+	}
+	if r.MaxTasksPerOffer < 0 {
+		e.add("maxTasksPerOffer", "must not be negative")
+	}
+	if r.TaskExecutionTimeLimitMillis < 0 {
+		e.add("taskExecutionTimeLimitMillis", "must not be negative")
+	}
+	if r.SlavePlacement != nil && !r.SlavePlacement.Valid() {
+		e.add("slavePlacement", "must be one of %s", strings.Join(slavePlacementEnum.values, ", "))
+	}
+	return e.err()
+}
+
+// Validate checks a deploy against the rules Singularity applies when it is
+// created, and returns a ValidationError listing every violation.
+func (d *SingularityDeploy) Validate() error {
+	var e ValidationError
+	e.checkID("id", d.ID, maxDeployIDLength, deployIDPattern)
+	e.checkID("requestId", d.RequestID, maxRequestIDLength, requestIDPattern)
+
+	res := d.SingularityDeployResources
+	if res.Cpus < 0 {
+		e.add("resources.cpus", "must not be negative")
+	}
+	if res.MemoryMb < 0 {
+		e.add("resources.memoryMb", "must not be negative")
+	}
+	if res.DiskMb < 0 {
+		e.add("resources.diskMb", "must not be negative")
+	}
+	if res.NumPorts < 0 {
+		e.add("resources.numPorts", "must not be negative")
+	}
+	if d.DeployInstanceCountPerStep < 0 {
+		e.add("deployInstanceCountPerStep", "must not be negative")
+	}
+	if d.DeployStepWaitTimeMs < 0 {
+		e.add("deployStepWaitTimeMs", "must not be negative")
+	}
+	if d.MaxTaskRetries < 0 {
+		e.add("maxTaskRetries", "must not be negative")
+	}
+
+	for i, u := range d.Uris {
+		if u.URI == "" {
+			e.add(fmt.Sprintf("uris[%d].uri", i), "is required")
+		}
+	}
+
+	// Singularity accepts any deploy with a containerInfo. Without one, a
+	// deploy runs either a command, or executorData with a customExecutorCmd.
+	switch {
+	case !d.ContainerInfo.empty():
+	case d.ExecutorData != nil || (d.CustomExecutorCmd != "" && d.Command == ""):
+		if d.ExecutorData == nil {
+			e.add("executorData", "is required with a custom executor")
+		}
+		if d.CustomExecutorCmd == "" {
+			e.add("customExecutorCmd", "is required with executorData")
+		}
+		if d.Command != "" {
+			e.add("command", "must not be set with a custom executor")
+		}
+	case d.Command == "":
+		e.add("command", "is required unless containerInfo or a custom executor is given")
+	}
+	if !d.ContainerInfo.empty() {
+		e.validateContainer(d.ContainerInfo, res.NumPorts)
+	}
+	if d.ExecutorData != nil {
+		e.validateExecutorData(d.ExecutorData)
+	}
+	return e.err()
+}
+
+func (e *ValidationError) validateContainer(c ContainerInfo, numPorts int64) {
+	if !oneOf(c.Type, validContainerTypes) {
+		e.add("containerInfo.type", "must be one of %s", strings.Join(validContainerTypes, ", "))
+	}
+
+	for i, v := range c.Volumes {
+		field := fmt.Sprintf("containerInfo.volumes[%d]", i)
+		if v.ContainerPath == "" {
+			e.add(field+".containerPath", "is required")
+		}
+		if v.Mode != "" && !oneOf(v.Mode, validVolumeModes) {
+			e.add(field+".mode", "must be one of %s", strings.Join(validVolumeModes, ", "))
+		}
+	}
+
+	if c.Type == "MESOS" {
+		if !c.DockerInfo.empty() {
+			e.add("containerInfo.docker", "is only allowed for DOCKER containers")
+		}
+		if c.Mesos != nil && c.Mesos.Image != nil {
+			e.validateMesosImage(c.Mesos.Image)
+		}
+	}
+	if c.Type != "DOCKER" {
+		return
+	}
+
+	if c.Mesos != nil {
+		e.add("containerInfo.mesos", "is only allowed for MESOS containers")
+	}
+	i := c.DockerInfo
+	if i.Image == "" {
+		e.add("containerInfo.docker.image", "is required for DOCKER containers")
+	}
+	if i.Network != "" && !oneOf(i.Network, validDockerNetworks) {
+		e.add("containerInfo.docker.network", "must be one of %s", strings.Join(validDockerNetworks, ", "))
+	}
+	if len(i.PortMappings) > 0 && i.Network != "" && i.Network != "BRIDGE" {
+		e.add("containerInfo.docker.portMappings", "are only allowed with BRIDGE networking")
+	}
+	for n, p := range i.PortMappings {
+		field := fmt.Sprintf("containerInfo.docker.portMappings[%d]", n)
+		e.validatePort(field+".containerPort", p.ContainerPort, p.ContainerPortType, numPorts)
+		e.validatePort(field+".hostPort", p.HostPort, p.HostPortType, numPorts)
+		if p.Protocol != "" && !oneOf(strings.ToLower(p.Protocol), validProtocols) {
+			e.add(field+".protocol", "must be one of %s", strings.Join(validProtocols, ", "))
+		}
+	}
+}
+
+func (e *ValidationError) validateMesosImage(image *SingularityMesosImage) {
+	const field = "containerInfo.mesos.image"
+	switch image.Type {
+	case "DOCKER":
+		if image.Docker == nil || image.Docker.Name == "" {
+			e.add(field+".docker.name", "is required for DOCKER images")
+		}
+		if image.Appc != nil {
+			e.add(field+".appc", "is only allowed for APPC images")
+		}
+	case "APPC":
+		if image.Appc == nil || image.Appc.Name == "" {
+			e.add(field+".appc.name", "is required for APPC images")
+		}
+		if image.Docker != nil {
+			e.add(field+".docker", "is only allowed for DOCKER images")
+		}
+	default:
+		e.add(field+".type", "must be one of %s", strings.Join(validMesosImageTypes, ", "))
+	}
+}
+
+// validatePort checks a port mapping port. A FROM_OFFER port is an index
+// into the ports offered to the task, so it must be below numPorts.
+func (e *ValidationError) validatePort(field string, port int, portType string, numPorts int64) {
+	switch portType {
+	case "FROM_OFFER":
+		if port < 0 || int64(port) >= numPorts {
+			e.add(field, "port index %d must be less than resources.numPorts (%d)", port, numPorts)
+		}
+	case "", "LITERAL":
+		if port < 1 || port > maxPort {
+			e.add(field, "must be between 1 and %d", maxPort)
+		}
+	default:
+		e.add(field+"Type", "must be one of %s", strings.Join(validPortTypes, ", "))
+	}
+}
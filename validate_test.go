@@ -0,0 +1,144 @@
+package singularity
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fields returns the field paths of a Validate error.
+func fields(t *testing.T, err error) []string {
+	if err == nil {
+		return nil
+	}
+	v, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("Validate: expected ValidationError, got %T", err)
+	}
+	var f []string
+	for _, e := range v {
+		f = append(f, e.Field)
+	}
+	return f
+}
+
+func TestRequestValidate(t *testing.T) {
+	placement := SlavePlacement("EVERYWHERE")
+	var data = []struct {
+		name     string
+		request  SingularityRequest
+		expected []string
+	}{
+		{"service", NewRequest(SERVICE, "my-service").Get(), nil},
+		{"scheduled", SingularityRequest{ID: "job", RequestType: "SCHEDULED", Schedule: "*/5 * * * *"}, nil},
+		{"on demand", SingularityRequest{ID: "job", RequestType: "ON_DEMAND"}, nil},
+		{"missing id and type", SingularityRequest{}, []string{"id", "requestType"}},
+		{"bad id", SingularityRequest{ID: "my/service", RequestType: "SERVICE", Instances: 1}, []string{"id"}},
+		{"scheduled without schedule", SingularityRequest{ID: "job", RequestType: "SCHEDULED"}, []string{"schedule"}},
+		{"quartz", SingularityRequest{ID: "job", RequestType: "SCHEDULED", QuartzSchedule: "0 0 12 ? * MON-FRI", ScheduleTimeZone: "UTC"}, nil},
+		{"bad quartz and zone", SingularityRequest{ID: "job", RequestType: "SCHEDULED", QuartzSchedule: "0 0 12 * * *", ScheduleTimeZone: "Nowhere"}, []string{"quartzSchedule", "scheduleTimeZone"}},
+		{"bad cron", SingularityRequest{ID: "job", RequestType: "SCHEDULED", Schedule: "every day"}, []string{"schedule"}},
+		{"service without instances", SingularityRequest{ID: "s", RequestType: "SERVICE"}, []string{"instances"}},
+		{"worker without instances", SingularityRequest{ID: "w", RequestType: "WORKER"}, []string{"instances"}},
+		{"on demand instances", SingularityRequest{ID: "job", RequestType: "ON_DEMAND", Instances: 2}, []string{"instances"}},
+		{"schedule on service", SingularityRequest{ID: "s", RequestType: "SERVICE", Instances: 1, Schedule: "* * * * *"}, []string{"schedule"}},
+		{"load balanced worker", SingularityRequest{ID: "w", RequestType: "WORKER", Instances: 1, LoadBalanced: true}, []string{"loadBalanced"}},
+		{"negatives", SingularityRequest{ID: "s", RequestType: "SERVICE", Instances: 1, NumRetriesOnFailure: -1, MaxTasksPerOffer: -1}, []string{"numRetriesOnFailure", "maxTasksPerOffer"}},
+		{"placement", SingularityRequest{ID: "s", RequestType: "SERVICE", Instances: 1, SlavePlacement: &placement}, []string{"slavePlacement"}},
+	}
+
+	for _, tt := range data {
+		got := fields(t, tt.request.Validate())
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Validate(%s): expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestDeployValidate(t *testing.T) {
+	base := func() *SingularityDeploy {
+		return NewDeploy("d1").SetRequestID("my-service").SetCommand("./run.sh").Build()
+	}
+	mesos := func(c ContainerInfo) *SingularityDeploy {
+		d := base()
+		d.ContainerInfo = c
+		return d
+	}
+	docker := func(i DockerInfo, ports int64) *SingularityDeploy {
+		d := base()
+		d.ContainerInfo = ContainerInfo{Type: "DOCKER", DockerInfo: i}
+		d.SingularityDeployResources.NumPorts = ports
+		return d
+	}
+	custom := func(cmd, executorCmd string, data *ExecutorData, c ContainerInfo) *SingularityDeploy {
+		d := NewDeploy("d1").SetRequestID("my-service").SetCommand(cmd).Build()
+		d.CustomExecutorCmd = executorCmd
+		d.ExecutorData = data
+		d.ContainerInfo = c
+		return d
+	}
+	executor := NewExecutorData("./run.sh").Build()
+
+	var data = []struct {
+		name     string
+		deploy   *SingularityDeploy
+		expected []string
+	}{
+		{"command", base(), nil},
+		{"docker", docker(DockerInfo{Image: "golang:latest", Network: "BRIDGE", PortMappings: []DockerPortMapping{
+			{ContainerPort: 8080, HostPort: 0, HostPortType: "FROM_OFFER"},
+		}}, 1), nil},
+		{"missing ids", &SingularityDeploy{Command: "./run.sh"}, []string{"id", "requestId"}},
+		{"bad deploy id", NewDeploy("d-1").SetRequestID("my-service").SetCommand("./run.sh").Build(), []string{"id"}},
+		{"no command", NewDeploy("d1").SetRequestID("my-service").Build(), []string{"command"}},
+		{"negative resources", NewDeploy("d1").SetRequestID("r").SetCommand("c").
+			SetResources(SingularityDeployResources{Cpus: -1, MemoryMb: -1}).Build(), []string{"resources.cpus", "resources.memoryMb"}},
+		{"docker without image", docker(DockerInfo{Network: "BRIDGE"}, 0), []string{"containerInfo.docker.image"}},
+		{"mesos", mesos(NewMesosContainerInfo(nil)), nil},
+		{"mesos docker image", mesos(NewMesosContainerInfo(NewMesosDockerImage("golang:latest"))), nil},
+		{"mesos appc image", mesos(NewMesosContainerInfo(NewMesosAppcImage("example.com/app", ""))), nil},
+		{"mesos image without name", mesos(NewMesosContainerInfo(NewMesosDockerImage(""))), []string{"containerInfo.mesos.image.docker.name"}},
+		{"mesos image type", mesos(NewMesosContainerInfo(&SingularityMesosImage{Type: "OCI"})), []string{"containerInfo.mesos.image.type"}},
+		{"mesos with docker settings", mesos(ContainerInfo{Type: "MESOS", DockerInfo: DockerInfo{Image: "i"}}), []string{"containerInfo.docker"}},
+		{"docker with mesos settings", mesos(ContainerInfo{Type: "DOCKER", DockerInfo: DockerInfo{Image: "i"}, Mesos: &SingularityMesosInfo{}}), []string{"containerInfo.mesos"}},
+		{"no container needs command", NewDeploy("d1").SetRequestID("r").RemoveContainerInfo().Build(), []string{"command"}},
+		{"mesos image without command", custom("", "", nil, NewMesosContainerInfo(NewMesosDockerImage("golang:latest"))), nil},
+		{"docker image without command", custom("", "", nil, ContainerInfo{Type: "DOCKER", DockerInfo: DockerInfo{Image: "i"}}), nil},
+		{"custom executor", custom("", "/usr/bin/executor", &executor, ContainerInfo{}), nil},
+		{"custom executor with command", custom("./run.sh", "/usr/bin/executor", &executor, ContainerInfo{}), []string{"command"}},
+		{"executor data without executor", custom("", "", &executor, ContainerInfo{}), []string{"customExecutorCmd"}},
+		{"executor without executor data", custom("", "/usr/bin/executor", nil, ContainerInfo{}), []string{"executorData"}},
+		{"command with executor", custom("./run.sh", "/usr/bin/executor", nil, ContainerInfo{}), nil},
+		{"container with executor data", custom("./run.sh", "", &executor, NewMesosContainerInfo(nil)), nil},
+		{"bad network", docker(DockerInfo{Image: "i", Network: "OVERLAY"}, 0), []string{"containerInfo.docker.network"}},
+		{"port mappings with host network", docker(DockerInfo{Image: "i", Network: "HOST", PortMappings: []DockerPortMapping{
+			{ContainerPort: 80, HostPort: 80},
+		}}, 0), []string{"containerInfo.docker.portMappings"}},
+		{"offer index out of range", docker(DockerInfo{Image: "i", PortMappings: []DockerPortMapping{
+			{ContainerPort: 80, HostPort: 1, HostPortType: "FROM_OFFER"},
+		}}, 1), []string{"containerInfo.docker.portMappings[0].hostPort"}},
+		{"bad literal port and protocol", docker(DockerInfo{Image: "i", PortMappings: []DockerPortMapping{
+			{ContainerPort: 70000, HostPort: 80, Protocol: "sctp"},
+		}}, 0), []string{"containerInfo.docker.portMappings[0].containerPort", "containerInfo.docker.portMappings[0].protocol"}},
+		{"bad port type", docker(DockerInfo{Image: "i", PortMappings: []DockerPortMapping{
+			{ContainerPort: 80, HostPort: 80, HostPortType: "RANDOM"},
+		}}, 0), []string{"containerInfo.docker.portMappings[0].hostPortType"}},
+	}
+
+	for _, tt := range data {
+		got := fields(t, tt.deploy.Validate())
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Validate(%s): expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	d := base()
+	d.ContainerInfo.Volumes = []SingularityVolume{{HostPath: "/tmp", Mode: "RX"}}
+	got := fields(t, d.Validate())
+	expected := []string{"containerInfo.type", "containerInfo.volumes[0].containerPath", "containerInfo.volumes[0].mode"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Validate(volumes): expected %v, got %v", expected, got)
+	}
+	if msg := d.Validate().Error(); msg == "" {
+		t.Errorf("Validate(volumes): expected error message")
+	}
+}